@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -315,10 +316,17 @@ func (ci CI) TestE2E() error {
 		return err
 	}
 
+	if err := engine.LoadDeclarativeCatalogs(); err != nil {
+		return fmt.Errorf("error when loading declarative rule catalogs: %v", err)
+	}
+	rctx.Trace = rulesengine.NewEvalTrace()
+	defer writeRulesEvaluationTrace(rctx.Trace)
+
 	// Eventually we'll introduce mage rules for all repositories, so this condition won't be needed anymore
 	if pr.RepoName == "e2e-tests" || pr.RepoName == "integration-service" ||
 		pr.RepoName == "release-service" || pr.RepoName == "image-controller" ||
-		pr.RepoName == "build-service" || pr.RepoName == "release-service-catalog" {
+		pr.RepoName == "build-service" || pr.RepoName == "release-service-catalog" ||
+		pr.RepoName == "infra-deployments" {
 		return engine.MageEngine.RunRulesOfCategory("ci", rctx)
 	}
 
@@ -349,12 +357,23 @@ func (ci CI) TestE2E() error {
 	return nil
 }
 
+// PlanE2E evaluates the CI rules in dry run mode and stores the resulting test execution plan in the artifact dir
+func (ci CI) PlanE2E() error {
+
+	if err := ci.init(); err != nil {
+		return fmt.Errorf("error when running ci init: %v", err)
+	}
+
+	if err := engine.LoadDeclarativeCatalogs(); err != nil {
+		return fmt.Errorf("error when loading declarative rule catalogs: %v", err)
+	}
+
+	return writeRulesExecutionPlan(engine.MageEngine.Plan(rctx, "ci"))
+}
+
 func (ci CI) UnregisterSprayproxy() {
-	err := unregisterPacServer()
-	if err != nil {
-		if alertErr := HandleErrorWithAlert(fmt.Errorf("failed to unregister SprayProxy: %+v", err), slack.ErrorSeverityLevelInfo); alertErr != nil {
-			klog.Warning(alertErr)
-		}
+	if err := repos.UnregisterKonfluxFromSprayProxy(); err != nil {
+		klog.Warning(err)
 	}
 }
 
@@ -367,9 +386,9 @@ func RunE2ETests() error {
 	switch rctx.RepoName {
 	case "release-service-catalog":
 		rctx.IsPaired = isPRPairingRequired("release-service")
-		return engine.MageEngine.RunRules(rctx, "tests", "release-service-catalog")
+		return engine.MageEngine.RunRules(rctx, engine.WithDeclarativeCatalogs("tests", "release-service-catalog")...)
 	case "infra-deployments":
-		return engine.MageEngine.RunRules(rctx, "tests", "infra-deployments")
+		return engine.MageEngine.RunRules(rctx, engine.WithDeclarativeCatalogs("tests", "infra-deployments")...)
 	default:
 		labelFilter := utils.GetEnv("E2E_TEST_SUITE_LABEL", "!upgrade-create && !upgrade-verify && !upgrade-cleanup && !release-pipelines")
 		return runTests(labelFilter, "e2e-report.xml")
@@ -1110,38 +1129,6 @@ func registerPacServer() error {
 	return nil
 }
 
-func unregisterPacServer() error {
-	var err error
-	var pacHost string
-	sprayProxyConfig, err = newSprayProxy()
-	if err != nil {
-		return fmt.Errorf("failed to set up SprayProxy credentials: %+v", err)
-	}
-	// for debugging purposes
-	klog.Infof("Before unregistering pac server...")
-	err = printRegisteredPacServers()
-	if err != nil {
-		klog.Error(err)
-	}
-
-	pacHost, err = sprayproxy.GetPaCHost()
-	if err != nil {
-		return fmt.Errorf("failed to get PaC host: %+v", err)
-	}
-	_, err = sprayProxyConfig.UnregisterServer(pacHost)
-	if err != nil {
-		return fmt.Errorf("error when unregistering PaC server %s from SprayProxy server %s: %+v", pacHost, sprayProxyConfig.BaseURL, err)
-	}
-	klog.Infof("Unregistered PaC servers: %v", pacHost)
-	// for debugging purposes
-	klog.Infof("After unregistering server...")
-	err = printRegisteredPacServers()
-	if err != nil {
-		klog.Error(err)
-	}
-	return nil
-}
-
 func printRegisteredPacServers() error {
 	servers, err := sprayProxyConfig.GetServers()
 	if err != nil {
@@ -1319,6 +1306,10 @@ func isValidPacHost(server string) bool {
 
 func (Local) PreviewTestSelection() error {
 
+	if err := engine.LoadDeclarativeCatalogs(); err != nil {
+		return err
+	}
+
 	rctx := rulesengine.NewRuleCtx()
 	files, err := utils.GetChangedFiles("e2e-tests")
 	if err != nil {
@@ -1327,17 +1318,103 @@ func (Local) PreviewTestSelection() error {
 	}
 	rctx.DiffFiles = files
 	rctx.DryRun = true
+	rctx.Trace = rulesengine.NewEvalTrace()
+
+	err = engine.MageEngine.RunRules(rctx, engine.WithDeclarativeCatalogs("tests", "e2e-repo")...)
+	writeRulesEvaluationTrace(rctx.Trace)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// writeRulesEvaluationTrace prints the rules evaluation tree and stores it as JSON in the artifact dir
+func writeRulesEvaluationTrace(trace *rulesengine.EvalTrace) {
+	klog.Infof("Rules evaluation trace:\n%s", trace.String())
 
-	err = engine.MageEngine.RunRules(rctx, "tests", "e2e-repo")
+	traceFile := filepath.Join(artifactDir, "rules-evaluation-trace.json")
+	if err := trace.WriteJSON(traceFile); err != nil {
+		klog.Errorf("failed to write the rules evaluation trace to %s: %+v", traceFile, err)
+		return
+	}
+	klog.Infof("Rules evaluation trace written to %s", traceFile)
+}
+
+// PlanTestSelection evaluates the e2e-repo test selection rules without running anything and stores the plan in the artifact dir
+func (Local) PlanTestSelection() error {
+	if err := engine.LoadDeclarativeCatalogs(); err != nil {
+		return err
+	}
 
+	rctx := rulesengine.NewRuleCtx()
+	files, err := utils.GetChangedFiles("e2e-tests")
 	if err != nil {
+		klog.Error(err)
 		return err
 	}
+	rctx.DiffFiles = files
+
+	return writeRulesExecutionPlan(engine.MageEngine.Plan(rctx, engine.WithDeclarativeCatalogs("tests", "e2e-repo")...))
+}
+
+// CheckRuleConflicts reports the rules of every category matching the same changed files but setting contradictory label filters.
+// A file of each directory of the repository is used as changed file.
+func (Local) CheckRuleConflicts() error {
+	if err := engine.LoadDeclarativeCatalogs(); err != nil {
+		return err
+	}
+
+	output, err := sh.Output("git", "ls-files")
+	if err != nil {
+		return fmt.Errorf("failed to list the repository files: %+v", err)
+	}
+	var files []string
+	dirs := map[string]bool{}
+	for _, file := range strings.Split(output, "\n") {
+		if dir := filepath.Dir(file); file != "" && !dirs[dir] {
+			dirs[dir] = true
+			files = append(files, file)
+		}
+	}
+
+	conflicts, err := engine.MageEngine.FindConflicts(rulesengine.NewRuleCtx(), rulesengine.ProbesFromFiles(files...))
+	if err != nil {
+		return err
+	}
+	for _, c := range conflicts {
+		klog.Error(c.String())
+	}
+	if len(conflicts) != 0 {
+		return fmt.Errorf("found %d conflicting rules", len(conflicts))
+	}
+	klog.Infof("No conflicting rules found for %d probes", len(files)+1)
+
+	return nil
+}
+
+// writeRulesExecutionPlan prints the execution plan and stores it as JSON and Markdown in the artifact dir
+func writeRulesExecutionPlan(plan *rulesengine.ExecutionPlan, err error) error {
+	if err != nil {
+		return fmt.Errorf("error when computing the rules execution plan: %+v", err)
+	}
+	klog.Infof("Rules execution plan:\n%s", plan.Markdown())
+
+	prefix := filepath.Join(artifactDir, "rules-execution-plan")
+	if err := plan.WriteFiles(prefix); err != nil {
+		return fmt.Errorf("failed to write the rules execution plan to %s: %+v", artifactDir, err)
+	}
+	klog.Infof("Rules execution plan written to %s.json and %s.md", prefix, prefix)
 
 	return nil
 }
 
 func (Local) RunRuleDemo() error {
+	if err := engine.LoadDeclarativeCatalogs(); err != nil {
+		return err
+	}
+
 	rctx := rulesengine.NewRuleCtx()
 	files, err := utils.GetChangedFiles("e2e-tests")
 	if err != nil {
@@ -1358,6 +1435,10 @@ func (Local) RunRuleDemo() error {
 
 func (Local) RunInfraDeploymentsRuleDemo() error {
 
+	if err := engine.LoadDeclarativeCatalogs(); err != nil {
+		return err
+	}
+
 	rctx := rulesengine.NewRuleCtx()
 	rctx.Parallel = true
 	rctx.OutputDir = artifactDir
@@ -1374,5 +1455,5 @@ func (Local) RunInfraDeploymentsRuleDemo() error {
 	rctx.DiffFiles = files
 
 	// filtering the rule engine to load only infra-deployments rule catalog within the test category
-	return engine.MageEngine.RunRules(rctx, "tests", "infra-deployments")
+	return engine.MageEngine.RunRules(rctx, engine.WithDeclarativeCatalogs("tests", "infra-deployments")...)
 }
@@ -0,0 +1,153 @@
+package rulesengine
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/klog"
+)
+
+// RuleConflict reports two rules of the same category that match the same changed files
+// but set contradictory label filters.
+type RuleConflict struct {
+	Category          string
+	First             string
+	Second            string
+	DiffFiles         Files
+	FirstLabelFilter  string
+	SecondLabelFilter string
+	// LabelFilter is the label filter resulting from applying both rules in the engine order
+	LabelFilter string
+}
+
+func (c RuleConflict) String() string {
+
+	return fmt.Sprintf("[%s] %q (%s) and %q (%s) both match [%s] and result in the label filter %q",
+		c.Category, c.First, c.FirstLabelFilter, c.Second, c.SecondLabelFilter, c.DiffFiles.String(), c.LabelFilter)
+}
+
+// FindConflicts evaluates every rule registered in the engine alone, in dry run mode, against each of the
+// probes (a set of changed files) and reports the pairs of rules of the same category that match the same
+// probe but whose label filters are contradictory: when applied one after the other, in the engine order,
+// the labels selected by one of them are dropped, or a label is both selected and excluded.
+// Pairs where the first rule is exclusive are not reported since the second one is never applied.
+// Only the given categories are checked, all of them when none is given.
+func (e *RuleEngine) FindConflicts(rctx *RuleCtx, probes []Files, categories ...string) ([]RuleConflict, error) {
+
+	if len(categories) == 0 {
+		categories = sortedKeys(*e)
+	}
+
+	var conflicts []RuleConflict
+	for _, cat := range categories {
+
+		rules, err := e.loadCatalogs(cat)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, probe := range probes {
+
+			var matched RuleCatalog
+			filters := map[int]string{}
+			for _, rule := range rules {
+				prctx, err := e.probe(rctx, probe, rule)
+				if err != nil {
+					klog.Warningf("skipping the rule %q, failed to evaluate it against [%s]: %v", rule.Name, probe.String(), err)
+					continue
+				}
+				if len(prctx.Plan.AppliedRules) == 0 || prctx.LabelFilter == rctx.LabelFilter {
+					continue
+				}
+				filters[len(matched)] = prctx.LabelFilter
+				matched = append(matched, rule)
+			}
+
+			for i := range matched {
+				if matched[i].Exclusive {
+					continue
+				}
+				for j := i + 1; j < len(matched); j++ {
+					prctx, err := e.probe(rctx, probe, matched[i], matched[j])
+					if err != nil {
+						klog.Warningf("skipping the rules %q and %q, failed to evaluate them against [%s]: %v", matched[i].Name, matched[j].Name, probe.String(), err)
+						continue
+					}
+					if !contradictoryLabelFilters(prctx.LabelFilter, filters[i], filters[j]) {
+						continue
+					}
+					conflicts = append(conflicts, RuleConflict{
+						Category:          cat,
+						First:             matched[i].Name,
+						Second:            matched[j].Name,
+						DiffFiles:         probe,
+						FirstLabelFilter:  filters[i],
+						SecondLabelFilter: filters[j],
+						LabelFilter:       prctx.LabelFilter,
+					})
+				}
+			}
+		}
+	}
+
+	return conflicts, nil
+}
+
+// probe runs the given rules against a copy of rctx with the probe as changed files
+func (e *RuleEngine) probe(rctx *RuleCtx, probe Files, rules ...Rule) (*RuleCtx, error) {
+
+	prctx := rctx.Clone()
+	prctx.DiffFiles = append(Files{}, probe...)
+	prctx.DryRun = true
+	prctx.Plan = NewExecutionPlan()
+	prctx.Trace = nil
+
+	return prctx, e.runLoadedCatalog(rules, prctx)
+}
+
+var labelFilterSeparators = regexp.MustCompile(`[,|&()\s]+`)
+
+// labelFilterTerms splits a ginkgo label filter into its labels, negated ones keep their "!" prefix.
+// Operators are ignored, this is only meant to tell which labels are selected or excluded.
+func labelFilterTerms(filter string) map[string]bool {
+
+	terms := map[string]bool{}
+	for _, t := range labelFilterSeparators.Split(filter, -1) {
+		if t != "" {
+			terms[t] = true
+		}
+	}
+
+	return terms
+}
+
+func contradictoryLabelFilters(combined string, filters ...string) bool {
+
+	terms := labelFilterTerms(combined)
+	for _, f := range filters {
+		for t := range labelFilterTerms(f) {
+			if !terms[t] {
+				return true
+			}
+		}
+	}
+	for t := range terms {
+		if !strings.HasPrefix(t, "!") && terms["!"+t] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ProbesFromFiles returns the probes for FindConflicts: no changed file and each of the files modified on its own
+func ProbesFromFiles(names ...string) []Files {
+
+	probes := []Files{{}}
+	for _, name := range names {
+		probes = append(probes, Files{{Status: "M", Name: name}})
+	}
+
+	return probes
+}
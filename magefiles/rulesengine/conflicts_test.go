@@ -0,0 +1,87 @@
+package rulesengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindConflicts(t *testing.T) {
+	changed := func(glob string) Conditional {
+		return ConditionFunc(func(rctx *RuleCtx) (bool, error) { return len(rctx.DiffFiles.FilterByDirGlob(glob)) != 0, nil })
+	}
+	setLabelFilter := func(filter string) []Action {
+		return []Action{CtxActionFunc(func(rctx *RuleCtx) error {
+			rctx.LabelFilter = filter
+			return nil
+		})}
+	}
+	addLabel := func(label string) []Action {
+		return []Action{CtxActionFunc(func(rctx *RuleCtx) error {
+			rctx.AddLabelToLabelFilter(label)
+			return nil
+		})}
+	}
+
+	e := RuleEngine{
+		"tests": {
+			"build": {
+				{Name: "build", Condition: changed("tests/build/**"), Actions: addLabel("build")},
+				{Name: "all but build", Condition: changed("tests/**"), Actions: setLabelFilter("!build")},
+			},
+			"release": {
+				{Name: "release", Condition: changed("tests/**"), Actions: addLabel("release")},
+				{Name: "release only", Condition: changed("tests/release/**"), Actions: setLabelFilter("release"), Exclusive: true, Priority: 10},
+			},
+		},
+		"other": {
+			"build": {
+				{Name: "default", Condition: changed("pkg/**"), Actions: setLabelFilter("build")},
+			},
+		},
+	}
+
+	conflicts, err := e.FindConflicts(NewRuleCtx(), ProbesFromFiles("tests/build/build.go", "tests/release/release.go", "pkg/utils/util.go"))
+	assert.NoError(t, err)
+	// adding labels doesn't conflict, the exclusive rule is applied alone and
+	// rules of different categories are never applied together
+	if assert.Len(t, conflicts, 1) {
+		assert.Equal(t, "tests", conflicts[0].Category)
+		assert.Equal(t, "build", conflicts[0].First)
+		assert.Equal(t, "all but build", conflicts[0].Second)
+		assert.Equal(t, "!build", conflicts[0].LabelFilter)
+		assert.Equal(t, Files{{Status: "M", Name: "tests/build/build.go"}}, conflicts[0].DiffFiles)
+	}
+}
+
+func TestRunRulesOrder(t *testing.T) {
+	var applied []string
+	rule := func(name string, priority int, exclusive bool, ok bool) Rule {
+		r := Rule{Name: name, Priority: priority, Exclusive: exclusive,
+			Condition: ConditionFunc(func(rctx *RuleCtx) (bool, error) { return ok, nil })}
+		// a rule chain is applied while evaluated
+		r.Condition = All{r.Condition, ConditionFunc(func(rctx *RuleCtx) (bool, error) {
+			applied = append(applied, name)
+			return true, nil
+		})}
+		return r
+	}
+
+	e := RuleEngine{"ci": {
+		"b": {rule("b1", 0, false, true), rule("b2", 0, true, false)},
+		"a": {rule("a1", 0, false, true), rule("a2", 5, true, true), rule("a3", 10, false, false)},
+		"c": {rule("c1", 0, false, true)},
+	}}
+
+	for i := 0; i < 10; i++ {
+		applied = nil
+		assert.NoError(t, e.RunRules(NewRuleCtx(), "ci"))
+		// the matching rule chains don't stop the evaluation unless exclusive
+		assert.Equal(t, []string{"a2"}, applied)
+	}
+
+	delete(e["ci"], "a")
+	applied = nil
+	assert.NoError(t, e.RunRulesOfCategory("ci", NewRuleCtx()))
+	assert.Equal(t, []string{"b1", "c1"}, applied)
+}
@@ -0,0 +1,327 @@
+package rulesengine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/klog"
+	"sigs.k8s.io/yaml"
+)
+
+// CatalogSpec is the declarative (YAML/JSON) representation of a RuleCatalog.
+// A catalog file declares the category and the catalog name it should be
+// registered under within the engine, together with the rules it is made of.
+type CatalogSpec struct {
+	Category string     `json:"category"`
+	Catalog  string     `json:"catalog"`
+	Rules    []RuleSpec `json:"rules"`
+}
+
+// RuleSpec is the declarative representation of a Rule.
+type RuleSpec struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Condition   ConditionSpec `json:"condition"`
+	Actions     []ActionSpec  `json:"actions,omitempty"`
+	Priority    int           `json:"priority,omitempty"`
+	Exclusive   bool          `json:"exclusive,omitempty"`
+}
+
+// ConditionSpec is the declarative representation of a Conditional.
+// A node is either a combinator (all/any/none) or a leaf condition.
+// When diffGlob and diffStatus are set on the same node, the status is
+// checked against the files matching the glob only.
+type ConditionSpec struct {
+	All        []ConditionSpec `json:"all,omitempty"`
+	Any        []ConditionSpec `json:"any,omitempty"`
+	None       []ConditionSpec `json:"none,omitempty"`
+	DiffGlob   string          `json:"diffGlob,omitempty"`
+	DiffStatus string          `json:"diffStatus,omitempty"`
+	NoDiff     bool            `json:"noDiff,omitempty"`
+	RepoName   string          `json:"repoName,omitempty"`
+	JobType    string          `json:"jobType,omitempty"`
+	JobName    string          `json:"jobName,omitempty"`
+	EventType  string          `json:"eventType,omitempty"`
+	Ref        string          `json:"ref,omitempty"`
+}
+
+// ActionSpec is the declarative representation of an Action.
+// Exactly one of the fields has to be set.
+type ActionSpec struct {
+	AddLabel     string   `json:"addLabel,omitempty"`
+	AddFocusFile string   `json:"addFocusFile,omitempty"`
+	SetEnv       *EnvSpec `json:"setEnv,omitempty"`
+	Ref          string   `json:"ref,omitempty"`
+}
+
+// EnvSpec describes an environment variable set by the setEnv action.
+type EnvSpec struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// CatalogRefs holds the Go conditionals and actions that declarative
+// catalogs can refer to by name with the `ref` key.
+type CatalogRefs struct {
+	Conditions map[string]Conditional
+	Actions    map[string]Action
+}
+
+// LoadCatalogFile parses the YAML/JSON catalog file at path and builds its RuleCatalog.
+func LoadCatalogFile(path string, refs CatalogRefs) (*CatalogSpec, RuleCatalog, error) {
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read rule catalog file %s: %+v", path, err)
+	}
+
+	spec := &CatalogSpec{}
+	if err := yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse rule catalog file %s: %+v", path, err)
+	}
+
+	catalog, err := spec.Build(refs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid rule catalog file %s: %+v", path, err)
+	}
+
+	return spec, catalog, nil
+}
+
+// Build converts the declarative catalog into a RuleCatalog.
+func (cs *CatalogSpec) Build(refs CatalogRefs) (RuleCatalog, error) {
+
+	if cs.Category == "" || cs.Catalog == "" {
+		return nil, fmt.Errorf("both category and catalog have to be set")
+	}
+	if len(cs.Rules) == 0 {
+		return nil, fmt.Errorf("catalog %s has no rules", cs.Catalog)
+	}
+
+	var catalog RuleCatalog
+	for i, rs := range cs.Rules {
+		if rs.Name == "" {
+			return nil, fmt.Errorf("rule #%d has no name", i)
+		}
+		cond, err := rs.Condition.build(refs)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %+v", rs.Name, err)
+		}
+
+		var actions []Action
+		for _, as := range rs.Actions {
+			action, err := as.build(refs)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %+v", rs.Name, err)
+			}
+			actions = append(actions, action)
+		}
+
+		catalog = append(catalog, Rule{Name: rs.Name, Description: rs.Description, Condition: cond, Actions: actions, Priority: rs.Priority, Exclusive: rs.Exclusive})
+	}
+
+	return catalog, nil
+}
+
+func (cs ConditionSpec) build(refs CatalogRefs) (Conditional, error) {
+
+	var conds []Conditional
+
+	for _, combinator := range []struct {
+		specs []ConditionSpec
+		wrap  func([]Conditional) Conditional
+	}{
+		{cs.All, func(c []Conditional) Conditional { return All(c) }},
+		{cs.Any, func(c []Conditional) Conditional { return Any(c) }},
+		{cs.None, func(c []Conditional) Conditional { return None(c) }},
+	} {
+		if len(combinator.specs) == 0 {
+			continue
+		}
+		var children []Conditional
+		for _, child := range combinator.specs {
+			c, err := child.build(refs)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, c)
+		}
+		conds = append(conds, combinator.wrap(children))
+	}
+
+	if cs.DiffGlob != "" || cs.DiffStatus != "" {
+		glob, status := cs.DiffGlob, cs.DiffStatus
+		conds = append(conds, ConditionFunc(func(rctx *RuleCtx) (bool, error) {
+			files := rctx.DiffFiles
+			if glob != "" {
+				files = files.FilterByDirGlob(glob)
+			}
+			if status != "" {
+				files = files.FilterByStatus(status)
+			}
+			return len(files) != 0, nil
+		}))
+	}
+	if cs.NoDiff {
+		conds = append(conds, ConditionFunc(func(rctx *RuleCtx) (bool, error) {
+			return len(rctx.DiffFiles) == 0, nil
+		}))
+	}
+	if cs.RepoName != "" {
+		repoName := cs.RepoName
+		conds = append(conds, ConditionFunc(func(rctx *RuleCtx) (bool, error) {
+			return rctx.RepoName == repoName, nil
+		}))
+	}
+	if cs.JobType != "" {
+		jobType := cs.JobType
+		conds = append(conds, ConditionFunc(func(rctx *RuleCtx) (bool, error) {
+			return rctx.JobType == jobType, nil
+		}))
+	}
+	if cs.JobName != "" {
+		jobName := cs.JobName
+		conds = append(conds, ConditionFunc(func(rctx *RuleCtx) (bool, error) {
+			return strings.Contains(rctx.JobName, jobName), nil
+		}))
+	}
+	if cs.EventType != "" {
+		eventType := cs.EventType
+		conds = append(conds, ConditionFunc(func(rctx *RuleCtx) (bool, error) {
+			return rctx.TektonEventType == eventType, nil
+		}))
+	}
+	if cs.Ref != "" {
+		c, ok := refs.Conditions[cs.Ref]
+		if !ok {
+			return nil, fmt.Errorf("unknown condition ref %q", cs.Ref)
+		}
+		conds = append(conds, c)
+	}
+
+	switch len(conds) {
+	case 0:
+		return nil, fmt.Errorf("empty condition")
+	case 1:
+		return conds[0], nil
+	default:
+		// keys set on the same node must all be satisfied
+		return All(conds), nil
+	}
+}
+
+func (as ActionSpec) build(refs CatalogRefs) (Action, error) {
+
+	var actions []Action
+
+	if as.AddLabel != "" {
+		label := as.AddLabel
+		actions = append(actions, CtxActionFunc(func(rctx *RuleCtx) error {
+			rctx.AddLabelToLabelFilter(label)
+			return nil
+		}))
+	}
+	if as.AddFocusFile != "" {
+		file := as.AddFocusFile
+		actions = append(actions, CtxActionFunc(func(rctx *RuleCtx) error {
+			for _, f := range rctx.FocusFiles {
+				if f == file {
+					return nil
+				}
+			}
+			rctx.FocusFiles = append(rctx.FocusFiles, file)
+			return nil
+		}))
+	}
+	if as.SetEnv != nil {
+		if as.SetEnv.Name == "" {
+			return nil, fmt.Errorf("setEnv action requires a name")
+		}
+		env := *as.SetEnv
+		actions = append(actions, SimulatedAction{
+			Action: func(rctx *RuleCtx) error {
+				return os.Setenv(env.Name, env.Value)
+			},
+			DryRun: func(rctx *RuleCtx) error {
+				rctx.RecordEnv(env.Name, env.Value)
+				return nil
+			},
+		})
+	}
+	if as.Ref != "" {
+		a, ok := refs.Actions[as.Ref]
+		if !ok {
+			return nil, fmt.Errorf("unknown action ref %q", as.Ref)
+		}
+		actions = append(actions, a)
+	}
+
+	if len(actions) != 1 {
+		return nil, fmt.Errorf("an action must set exactly one of addLabel, addFocusFile, setEnv or ref")
+	}
+
+	return actions[0], nil
+}
+
+// RegisterCatalog registers the catalog under the given category.
+// It fails when a catalog with the same name is already registered, so that
+// declarative catalogs cannot silently override the Go ones.
+func (e *RuleEngine) RegisterCatalog(category, name string, catalog RuleCatalog) error {
+
+	if *e == nil {
+		*e = RuleEngine{}
+	}
+	if _, ok := (*e)[category]; !ok {
+		(*e)[category] = map[string]RuleCatalog{}
+	}
+	if _, ok := (*e)[category][name]; ok {
+		return fmt.Errorf("catalog %s is already registered in category %s", name, category)
+	}
+	(*e)[category][name] = catalog
+
+	return nil
+}
+
+// LoadCatalogsFromDir loads every *.yaml, *.yml and *.json catalog file found
+// in dir, registers them into the engine and returns their specs. A missing
+// directory is not an error.
+func (e *RuleEngine) LoadCatalogsFromDir(dir string, refs CatalogRefs) ([]*CatalogSpec, error) {
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read rule catalogs directory %s: %+v", dir, err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch filepath.Ext(entry.Name()) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+
+	var specs []*CatalogSpec
+	for _, file := range files {
+		spec, catalog, err := LoadCatalogFile(file, refs)
+		if err != nil {
+			return nil, err
+		}
+		if err := e.RegisterCatalog(spec.Category, spec.Catalog, catalog); err != nil {
+			return nil, err
+		}
+		klog.Infof("Registered declarative catalog %s in category %s from %s", spec.Catalog, spec.Category, file)
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
@@ -0,0 +1,96 @@
+package rulesengine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const componentCatalog = `
+category: tests
+catalog: my-component
+rules:
+  - name: My component rule
+    description: Run my-component suite when its manifests change
+    condition:
+      all:
+        - diffGlob: "components/my-component/**/*"
+        - none:
+            - jobType: periodic
+    actions:
+      - addLabel: my-component
+      - addFocusFile: tests/my-component/my-component.go
+      - ref: record
+`
+
+func TestLoadCatalogsFromDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "my-component.yaml"), []byte(componentCatalog), 0600))
+
+	executed := 0
+	refs := CatalogRefs{Actions: map[string]Action{"record": ActionFunc(func(rctx *RuleCtx) error {
+		executed++
+		return nil
+	})}}
+
+	e := RuleEngine{"tests": {}}
+	specs, err := e.LoadCatalogsFromDir(dir, refs)
+	assert.NoError(t, err)
+	if assert.Len(t, specs, 1) {
+		assert.Equal(t, "my-component", specs[0].Catalog)
+	}
+	assert.Len(t, e["tests"]["my-component"], 1)
+
+	rctx := NewRuleCtx()
+	rctx.DiffFiles = Files{{Status: "M", Name: "components/my-component/base/deployment.yaml"}}
+	assert.NoError(t, e.RunRules(rctx, "tests", "my-component"))
+	assert.Equal(t, "my-component", rctx.LabelFilter)
+	assert.Equal(t, []string{"tests/my-component/my-component.go"}, rctx.FocusFiles)
+	assert.Equal(t, 1, executed)
+
+	rctx = NewRuleCtx()
+	rctx.JobType = "periodic"
+	rctx.DiffFiles = Files{{Status: "M", Name: "components/my-component/base/deployment.yaml"}}
+	assert.NoError(t, e.RunRules(rctx, "tests", "my-component"))
+	assert.Empty(t, rctx.LabelFilter)
+
+	// registering the same catalog twice must not override the existing one
+	_, err = e.LoadCatalogsFromDir(dir, refs)
+	assert.Error(t, err)
+}
+
+func TestRunRulesOfSeveralCatalogs(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "my-component.yaml"), []byte(componentCatalog), 0600))
+
+	refs := CatalogRefs{Actions: map[string]Action{"record": ActionFunc(func(rctx *RuleCtx) error { return nil })}}
+	e := RuleEngine{"tests": {"go-catalog": RuleCatalog{{Name: "Go rule", Condition: ConditionFunc(func(rctx *RuleCtx) (bool, error) { return true, nil }),
+		Actions: []Action{ActionFunc(func(rctx *RuleCtx) error {
+			rctx.AddLabelToLabelFilter("go")
+			return nil
+		})}}}}}
+	_, err := e.LoadCatalogsFromDir(dir, refs)
+	assert.NoError(t, err)
+
+	rctx := NewRuleCtx()
+	rctx.DiffFiles = Files{{Status: "M", Name: "components/my-component/base/deployment.yaml"}}
+	assert.NoError(t, e.RunRules(rctx, "tests", "go-catalog", "my-component"))
+	assert.Equal(t, "go,my-component", rctx.LabelFilter)
+
+	assert.Error(t, e.RunRules(NewRuleCtx(), "tests", "go-catalog", "missing"))
+}
+
+func TestCatalogSpecValidation(t *testing.T) {
+	for name, spec := range map[string]CatalogSpec{
+		"missing category": {Catalog: "c", Rules: []RuleSpec{{Name: "r", Condition: ConditionSpec{NoDiff: true}}}},
+		"empty condition":  {Category: "tests", Catalog: "c", Rules: []RuleSpec{{Name: "r"}}},
+		"unknown ref":      {Category: "tests", Catalog: "c", Rules: []RuleSpec{{Name: "r", Condition: ConditionSpec{Ref: "missing"}}}},
+		"ambiguous action": {Category: "tests", Catalog: "c", Rules: []RuleSpec{{Name: "r", Condition: ConditionSpec{NoDiff: true},
+			Actions: []ActionSpec{{AddLabel: "a", AddFocusFile: "b"}}}}},
+	} {
+		_, err := spec.Build(CatalogRefs{})
+		assert.Error(t, err, name)
+	}
+}
@@ -1,14 +1,17 @@
 package engine
 
 import (
+	"sync"
+
 	"github.com/konflux-ci/e2e-tests/magefiles/rulesengine"
 	"github.com/konflux-ci/e2e-tests/magefiles/rulesengine/repos"
+	"github.com/konflux-ci/e2e-tests/pkg/utils"
 )
 
 var MageEngine = rulesengine.RuleEngine{
 	"tests": {
-		"e2e-repo":                repos.E2ETestRulesCatalog,
-		"infra-deployments":       repos.InfraDeploymentsRulesCatalog,
+		"e2e-repo":          repos.E2ETestRulesCatalog,
+		"infra-deployments": repos.InfraDeploymentsRulesCatalog,
 	},
 	"demo": {
 		"local-workflow": repos.DemoCatalog,
@@ -21,7 +24,62 @@ var MageEngine = rulesengine.RuleEngine{
 		"integration-service":     repos.IntegrationServiceCICatalog,
 		"image-controller":        repos.ImageControllerCICatalog,
 		"build-service":           repos.BuildServiceCICatalog,
-		// TODO: to be implemented in a follow-up PR
-		//"infra-deployments": repos.InfraDeploymentsCIChainCatalog,
+		"infra-deployments":       repos.InfraDeploymentsCIChainCatalog,
+	},
+}
+
+// DeclarativeCatalogsDirEnv is the env var overriding the directory declarative rule catalogs are loaded from
+const DeclarativeCatalogsDirEnv = "MAGE_RULES_CATALOGS_DIR"
+
+// DefaultDeclarativeCatalogsDir is the directory declarative rule catalogs are loaded from by default
+const DefaultDeclarativeCatalogsDir = "magefiles/rulesengine/catalogs"
+
+// CatalogRefs are the Go conditionals and actions declarative catalogs can refer to with the `ref` key
+var CatalogRefs = rulesengine.CatalogRefs{
+	Conditions: map[string]rulesengine.Conditional{
+		"isPeriodicJob":        rulesengine.ConditionFunc(repos.IsPeriodicJob),
+		"isRehearseJob":        rulesengine.ConditionFunc(repos.IsRehearseJob),
+		"isLoadTestJob":        rulesengine.ConditionFunc(repos.IsLoadTestJob),
+		"isTektonPushEvent":    rulesengine.ConditionFunc(repos.IsTektonPushEventType),
+		"pkgFilesChanged":      rulesengine.ConditionFunc(repos.CheckPkgFilesChanged),
+		"mageFilesChanged":     rulesengine.ConditionFunc(repos.CheckMageFilesChanged),
+		"cmdFilesChanged":      rulesengine.ConditionFunc(repos.CheckCmdFilesChanged),
+		"tektonFilesChanged":   rulesengine.ConditionFunc(repos.CheckTektonFilesChanged),
+		"preflightChecked":     rulesengine.ConditionFunc(repos.IsPrelightChecked),
+		"infraDeploymentsPair": &repos.InfraDeploymentsPRPairingRule,
 	},
+	Actions: map[string]rulesengine.Action{
+		"executeTests":       repos.TestAction,
+		"selectDefaultTests": rulesengine.CtxActionFunc(repos.SelectDefaultTests),
+	},
+}
+
+var (
+	loadDeclarativeOnce sync.Once
+	loadDeclarativeErr  error
+	// declarativeCatalogs are the names of the loaded declarative catalogs by category
+	declarativeCatalogs = map[string][]string{}
+)
+
+// LoadDeclarativeCatalogs registers the YAML/JSON rule catalogs found in the
+// declarative catalogs directory into the MageEngine, next to the Go catalogs.
+// The catalogs are loaded once, so it is safe to call it from every target.
+func LoadDeclarativeCatalogs() error {
+
+	loadDeclarativeOnce.Do(func() {
+		specs, err := MageEngine.LoadCatalogsFromDir(utils.GetEnv(DeclarativeCatalogsDirEnv, DefaultDeclarativeCatalogsDir), CatalogRefs)
+		for _, spec := range specs {
+			declarativeCatalogs[spec.Category] = append(declarativeCatalogs[spec.Category], spec.Catalog)
+		}
+		loadDeclarativeErr = err
+	})
+
+	return loadDeclarativeErr
+}
+
+// WithDeclarativeCatalogs returns the RunRules args selecting the given catalogs of the category
+// together with the declarative catalogs loaded into that category, so they are evaluated as one catalog.
+func WithDeclarativeCatalogs(category string, catalogs ...string) []string {
+
+	return append(append([]string{category}, catalogs...), declarativeCatalogs[category]...)
 }
@@ -0,0 +1,174 @@
+package rulesengine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+	"k8s.io/klog"
+)
+
+// ExecutionPlan is the outcome of evaluating the rules in dry run mode:
+// the rules that would be applied and the resulting test selection,
+// env vars and commands.
+type ExecutionPlan struct {
+	AppliedRules []string          `json:"appliedRules"`
+	LabelFilter  string            `json:"labelFilter"`
+	FocusFiles   []string          `json:"focusFiles"`
+	Timeout      string            `json:"timeout"`
+	EnvVars      map[string]string `json:"envVars"`
+	Commands     []string          `json:"commands"`
+}
+
+func NewExecutionPlan() *ExecutionPlan {
+
+	return &ExecutionPlan{EnvVars: map[string]string{}}
+}
+
+// Plan evaluates the rules selected by args (see RunRules) in dry run mode against
+// a copy of rctx, so that rctx is left untouched, and returns the resulting plan.
+func (e *RuleEngine) Plan(rctx *RuleCtx, args ...string) (*ExecutionPlan, error) {
+
+	prctx := rctx.Clone()
+	prctx.DryRun = true
+	prctx.Plan = NewExecutionPlan()
+
+	if err := e.RunRules(prctx, args...); err != nil {
+		return prctx.Plan, err
+	}
+
+	prctx.Plan.LabelFilter = prctx.LabelFilter
+	prctx.Plan.FocusFiles = prctx.FocusFiles
+	prctx.Plan.Timeout = prctx.Timeout.String()
+
+	return prctx.Plan, nil
+}
+
+// JSON renders the plan as indented JSON
+func (p *ExecutionPlan) JSON() ([]byte, error) {
+
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// Markdown renders the plan as a Markdown summary, suitable for a PR comment
+func (p *ExecutionPlan) Markdown() string {
+
+	var sb strings.Builder
+
+	sb.WriteString("## Test execution plan\n\n")
+
+	sb.WriteString("### Applied rules\n\n")
+	if len(p.AppliedRules) == 0 {
+		sb.WriteString("No rule matched.\n")
+	}
+	for _, r := range p.AppliedRules {
+		fmt.Fprintf(&sb, "* %s\n", r)
+	}
+
+	sb.WriteString("\n### Test selection\n\n")
+	fmt.Fprintf(&sb, "* Label filter: `%s`\n", p.LabelFilter)
+	if len(p.FocusFiles) == 0 {
+		sb.WriteString("* Focus files: none\n")
+	} else {
+		fmt.Fprintf(&sb, "* Focus files: `%s`\n", strings.Join(p.FocusFiles, "`, `"))
+	}
+	fmt.Fprintf(&sb, "* Timeout: %s\n", p.Timeout)
+
+	if len(p.EnvVars) != 0 {
+		sb.WriteString("\n### Env vars\n\n| Name | Value |\n|---|---|\n")
+		var names []string
+		for name := range p.EnvVars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(&sb, "| %s | `%s` |\n", name, p.EnvVars[name])
+		}
+	}
+
+	if len(p.Commands) != 0 {
+		sb.WriteString("\n### Commands\n\n```\n")
+		for _, c := range p.Commands {
+			sb.WriteString(c + "\n")
+		}
+		sb.WriteString("```\n")
+	}
+
+	return sb.String()
+}
+
+// WriteFiles writes the plan as JSON and Markdown using the given path prefix, i.e. <prefix>.json and <prefix>.md
+func (p *ExecutionPlan) WriteFiles(prefix string) error {
+
+	data, err := p.JSON()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(prefix+".json", data, 0644); err != nil {
+		return err
+	}
+
+	return os.WriteFile(prefix+".md", []byte(p.Markdown()), 0644)
+}
+
+// Setenv sets the env var, in dry run mode it is only logged and recorded in the plan
+func (gca *RuleCtx) Setenv(key, value string) error {
+
+	if gca.DryRun {
+		gca.RecordEnv(key, value)
+		return nil
+	}
+
+	return os.Setenv(key, value)
+}
+
+// RecordEnv logs an env var that would be set by an action in dry run mode and records it in the plan
+func (gca *RuleCtx) RecordEnv(key, value string) {
+
+	klog.Infof("Set %s: %s", key, value)
+	if gca.Plan != nil {
+		gca.Plan.EnvVars[key] = value
+	}
+}
+
+// RecordCommand logs a command that would be executed by an action in dry run mode and records it in the plan
+func (gca *RuleCtx) RecordCommand(cmd string, args ...string) {
+
+	command := strings.TrimSpace(fmt.Sprintf("%s %s", cmd, strings.Join(args, " ")))
+	klog.Infof("Running command: %s", command)
+	if gca.Plan != nil {
+		gca.Plan.Commands = append(gca.Plan.Commands, command)
+	}
+}
+
+// RunCommand runs the command, when a plan is being computed the command is only recorded
+func (gca *RuleCtx) RunCommand(cmd string, args ...string) error {
+
+	if gca.Plan != nil {
+		gca.RecordCommand(cmd, args...)
+		return nil
+	}
+
+	return sh.RunV(cmd, args...)
+}
+
+// Clone returns a copy of the RuleCtx that can be modified without affecting the original
+func (gca *RuleCtx) Clone() *RuleCtx {
+
+	c := *gca
+	c.RuleData = make(map[string]any, len(gca.RuleData))
+	for k, v := range gca.RuleData {
+		c.RuleData[k] = v
+	}
+	c.DiffFiles = append(Files{}, gca.DiffFiles...)
+	c.RequiredBinaries = append([]string{}, gca.RequiredBinaries...)
+	c.FocusFiles = append([]string{}, gca.FocusFiles...)
+	c.SkipFiles = append([]string{}, gca.SkipFiles...)
+	c.FocusStrings = append([]string{}, gca.FocusStrings...)
+	c.SkipStrings = append([]string{}, gca.SkipStrings...)
+
+	return &c
+}
@@ -0,0 +1,68 @@
+package rulesengine
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlan(t *testing.T) {
+	always := ConditionFunc(func(rctx *RuleCtx) (bool, error) { return true, nil })
+	executed := false
+	catalog := RuleCatalog{
+		{Name: "first", Condition: always, Actions: []Action{CtxActionFunc(func(rctx *RuleCtx) error {
+			rctx.AddLabelToLabelFilter("build")
+			return nil
+		}), SimulatedAction{
+			Action: func(rctx *RuleCtx) error { return os.Setenv("PLAN_TEST_ENV", "value") },
+			DryRun: func(rctx *RuleCtx) error {
+				rctx.RecordEnv("PLAN_TEST_ENV", "value")
+				return nil
+			},
+		}}},
+		{Name: "second", Condition: always, Actions: []Action{CtxActionFunc(func(rctx *RuleCtx) error {
+			rctx.FocusFiles = append(rctx.FocusFiles, "tests/build/build.go")
+			return rctx.RunCommand("ginkgo", "--label-filter=build", "./cmd")
+		}), ActionFunc(func(rctx *RuleCtx) error {
+			// actions that can't be simulated are never run in dry run mode
+			executed = true
+			return nil
+		})}},
+	}
+	e := RuleEngine{"tests": {"repo": catalog}}
+
+	rctx := NewRuleCtx()
+	plan, err := e.Plan(rctx, "tests", "repo")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"first", "second"}, plan.AppliedRules)
+	assert.Equal(t, "build", plan.LabelFilter)
+	assert.Equal(t, []string{"tests/build/build.go"}, plan.FocusFiles)
+	assert.Equal(t, map[string]string{"PLAN_TEST_ENV": "value"}, plan.EnvVars)
+	assert.Equal(t, []string{"ginkgo --label-filter=build ./cmd"}, plan.Commands)
+	assert.True(t, strings.Contains(plan.Markdown(), "`build`"))
+	assert.False(t, executed)
+
+	// neither the original context nor the environment are modified
+	assert.Empty(t, rctx.LabelFilter)
+	assert.Empty(t, rctx.FocusFiles)
+	assert.False(t, rctx.DryRun)
+	assert.Empty(t, os.Getenv("PLAN_TEST_ENV"))
+}
+
+func TestPlanReportsNestedRulesOnce(t *testing.T) {
+	always := ConditionFunc(func(rctx *RuleCtx) (bool, error) { return true, nil })
+	nested := Rule{Name: "nested", Condition: always, Actions: []Action{CtxActionFunc(func(rctx *RuleCtx) error {
+		rctx.AddLabelToLabelFilter("build")
+		return nil
+	})}}
+	chain := Rule{Name: "chain", Condition: All{&nested, Any{&nested, None{&nested}}},
+		Actions: []Action{CtxActionFunc(func(rctx *RuleCtx) error { return nil })}}
+	e := RuleEngine{"ci": {"repo": RuleCatalog{chain}}}
+
+	plan, err := e.Plan(NewRuleCtx(), "ci", "repo")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"nested", "chain"}, plan.AppliedRules)
+}
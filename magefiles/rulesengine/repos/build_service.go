@@ -17,7 +17,8 @@ var BuildServiceCIRule = rulesengine.Rule{Name: "build-service repo CI Workflow
 		&PreflightInstallGinkgoRule,
 		rulesengine.Any{rulesengine.None{&BootstrapClusterWithSprayProxyRuleChain}, &BootstrapClusterWithSprayProxyRuleChain},
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(ExecuteTestAction)},
+	Actions:   []rulesengine.Action{TestAction},
+	Exclusive: true,
 }
 
 var BuildServiceRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General Required Settings for build-service repository jobs",
@@ -25,24 +26,27 @@ var BuildServiceRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General Req
 	Condition: rulesengine.Any{
 		IsBuildServiceRepoPR,
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 		rctx.RequiresSprayProxyRegistering = true
 		klog.Info("require sprayproxy registering is set to TRUE")
 
 		rctx.LabelFilter = "build-service"
 		klog.Info("setting 'build-service' test label")
-
-		if rctx.DryRun {
+		return nil
+	}), rulesengine.SimulatedAction{
+		Action: func(rctx *rulesengine.RuleCtx) error {
+			rctx.ComponentEnvVarPrefix = "BUILD_SERVICE"
+			// Option to execute the tests in Openshift CI
+			if os.Getenv("KONFLUX_CI") != "true" {
+				rctx.ComponentImageTag = "redhat-appstudio-build-service-image"
+			}
+			return SetEnvVarsForComponentImageDeployment(rctx)
+		},
+		DryRun: func(rctx *rulesengine.RuleCtx) error {
 			klog.Info("setting up env vars for deploying component image")
 			return nil
-		}
-		rctx.ComponentEnvVarPrefix = "BUILD_SERVICE"
-		// Option to execute the tests in Openshift CI
-		if os.Getenv("KONFLUX_CI") != "true" {
-			rctx.ComponentImageTag = "redhat-appstudio-build-service-image"
-		}
-		return SetEnvVarsForComponentImageDeployment(rctx)
-	})},
+		},
+	}},
 }
 
 var IsBuildServiceRepoPR = rulesengine.ConditionFunc(func(rctx *rulesengine.RuleCtx) (bool, error) {
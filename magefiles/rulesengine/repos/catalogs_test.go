@@ -0,0 +1,390 @@
+package repos
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/konflux-ci/e2e-tests/magefiles/rulesengine"
+	"github.com/konflux-ci/e2e-tests/magefiles/rulesengine/rulesenginetest"
+	"github.com/stretchr/testify/assert"
+)
+
+const defaultLabelFilter = "!upgrade-create && !upgrade-verify && !upgrade-cleanup && !release-pipelines"
+
+var testEngine = rulesengine.RuleEngine{
+	"tests": {
+		"e2e-repo":          E2ETestRulesCatalog,
+		"infra-deployments": InfraDeploymentsRulesCatalog,
+	},
+	"demo": {
+		"local-workflow": DemoCatalog,
+	},
+	"ci": {
+		"e2e-repo":                E2ECIChainCatalog,
+		"release-service":         ReleaseServiceCICatalog,
+		"release-service-catalog": ReleaseServiceCatalogCICatalog,
+		"integration-service":     IntegrationServiceCICatalog,
+		"image-controller":        ImageControllerCICatalog,
+		"build-service":           BuildServiceCICatalog,
+		"infra-deployments":       InfraDeploymentsCIChainCatalog,
+	},
+}
+
+// stubbedChangedFiles is returned by the stubbed getChangedFiles
+var stubbedChangedFiles rulesengine.Files
+
+func TestMain(m *testing.M) {
+	// some rules glob the test files of the e2e-tests repo
+	if err := os.Chdir("../../.."); err != nil {
+		panic(err)
+	}
+	os.Unsetenv("SKIP_BOOTSTRAP")
+
+	getChangedFiles = func(string) (rulesengine.Files, error) { return stubbedChangedFiles, nil }
+	isPRPairingRequired = func(string, string, string) bool { return false }
+	selectReleasePipelines = func(int) (string, error) { return "fbc-release rh-advisories", nil }
+	getChangedLines = func(string) ([]LineRange, error) { return nil, nil }
+
+	os.Exit(m.Run())
+}
+
+// stubImpactAnalysis makes the pkg/ change impact analysis run against the fixture repository
+// with the given changed lines, the original functions are restored when the test ends
+func stubImpactAnalysis(t *testing.T, changedLines []LineRange) {
+	origFindImpactedTestFiles, origGetChangedLines := findImpactedTestFiles, getChangedLines
+	t.Cleanup(func() { findImpactedTestFiles, getChangedLines = origFindImpactedTestFiles, origGetChangedLines })
+
+	findImpactedTestFiles = func(_ string, changed rulesengine.Files, lines map[string][]LineRange) ([]string, error) {
+		return FindImpactedTestFiles(impactTestRoot, changed, lines)
+	}
+	getChangedLines = func(string) ([]LineRange, error) { return changedLines, nil }
+}
+
+func TestE2ETestRulesCatalog(t *testing.T) {
+	rulesenginetest.RunCatalogTestCases(t, testEngine, []string{"tests", "e2e-repo"}, []rulesenginetest.CatalogTestCase{
+		{
+			Name:                "pkg change with unknown impact runs all suites",
+			DiffFiles:           rulesengine.Files{{Status: "M", Name: "pkg/clients/tekton/templates/pipeline.yaml"}},
+			ExpectedRules:       []string{NonTestFilesRule.Name},
+			ExpectedLabelFilter: defaultLabelFilter,
+			ExpectedCommands:    []string{"ginkgo"},
+		},
+		{
+			Name:      "pkg change focuses the impacted test files",
+			DiffFiles: rulesengine.Files{{Status: "M", Name: "pkg/clients/release/plans.go"}},
+			Setup: func(rctx *rulesengine.RuleCtx) {
+				// only the CreatePlan method of the fixture is changed
+				stubImpactAnalysis(t, []LineRange{{From: 5, To: 5}})
+			},
+			ExpectedRules: []string{BuildORBuildTemplatesTestFileChangeOnlyRule.Name, PkgFilesImpactedTestsRule.Name, TestFilesOnlyRule.Name},
+			// the build suite doesn't use CreatePlan and the release pipelines suite is excluded
+			ExpectedFocusFiles: []string{"tests/release/service/service.go"},
+			ExpectedCommands:   []string{"ginkgo"},
+		},
+		{
+			Name:      "pkg change impacting no test file runs all suites",
+			DiffFiles: rulesengine.Files{{Status: "M", Name: "pkg/clients/release/plans.go"}},
+			Setup: func(rctx *rulesengine.RuleCtx) {
+				// only the package clause is changed
+				stubImpactAnalysis(t, []LineRange{{From: 1, To: 1}})
+			},
+			ExpectedRules:       []string{NonTestFilesRule.Name},
+			ExpectedLabelFilter: defaultLabelFilter,
+			ExpectedCommands:    []string{"ginkgo"},
+		},
+		{
+			Name:                "no change runs all suites",
+			ExpectedRules:       []string{NonTestFilesRule.Name},
+			ExpectedLabelFilter: defaultLabelFilter,
+		},
+		{
+			Name: "release pipelines change runs all suites including release-pipelines",
+			DiffFiles: rulesengine.Files{
+				{Status: "M", Name: "magefiles/magefile.go"},
+				{Status: "M", Name: "tests/release/pipelines/rh_advisories.go"},
+			},
+			ExpectedRules:       []string{NonTestFilesRuleWithReleasePipelines.Name},
+			ExpectedLabelFilter: "!upgrade-create && !upgrade-verify && !upgrade-cleanup",
+		},
+		{
+			Name:               "build test file change focuses the changed file",
+			DiffFiles:          rulesengine.Files{{Status: "M", Name: "tests/build/build.go"}},
+			ExpectedRules:      []string{BuildORBuildTemplatesTestFileChangeOnlyRule.Name, TestFilesOnlyRule.Name},
+			ExpectedFocusFiles: []string{"tests/build/build.go"},
+			ExpectedCommands:   []string{"ginkgo"},
+		},
+		{
+			Name: "build const change focuses the changed build test files",
+			DiffFiles: rulesengine.Files{
+				{Status: "M", Name: "tests/build/const.go"},
+				{Status: "M", Name: "tests/build/multi-platform.go"},
+			},
+			ExpectedFocusFiles: []string{"tests/build/multi-platform.go"},
+		},
+		{
+			Name:               "build templates scenarios change focuses the build templates tests",
+			DiffFiles:          rulesengine.Files{{Status: "M", Name: "tests/build/build_templates_scenarios.go"}},
+			ExpectedFocusFiles: []string{"tests/build/build_templates.go"},
+		},
+		{
+			Name: "integration const change focuses all the integration tests",
+			DiffFiles: rulesengine.Files{
+				{Status: "M", Name: "tests/integration-service/const.go"},
+			},
+			ExpectedFocusFiles: globFiles(t, "tests/integration-*/*.go", "tests/integration-service/const.go"),
+		},
+		{
+			Name:               "konflux-demo config change focuses the demo tests",
+			DiffFiles:          rulesengine.Files{{Status: "M", Name: "tests/konflux-demo/config/scenarios.go"}},
+			ExpectedFocusFiles: []string{"tests/konflux-demo/konflux-demo.go"},
+		},
+		{
+			Name:               "release service test change focuses the changed file",
+			DiffFiles:          rulesengine.Files{{Status: "A", Name: "tests/release/service/happy_path.go"}},
+			ExpectedFocusFiles: []string{"tests/release/service/happy_path.go"},
+		},
+		{
+			Name:               "enterprise contract test change focuses the changed file",
+			DiffFiles:          rulesengine.Files{{Status: "M", Name: "tests/enterprise-contract/contract.go"}},
+			ExpectedFocusFiles: []string{"tests/enterprise-contract/contract.go"},
+		},
+	})
+}
+
+func TestInfraDeploymentsRulesCatalog(t *testing.T) {
+	rulesenginetest.RunCatalogTestCases(t, testEngine, []string{"tests", "infra-deployments"}, []rulesenginetest.CatalogTestCase{
+		{
+			Name:      "integration component change",
+			DiffFiles: rulesengine.Files{{Status: "M", Name: "components/integration/production/kustomization.yaml"}},
+			// the component rules are applied both when the default rule and the components rule are evaluated
+			ExpectedRules:       []string{InfraDeploymentsIntegrationComponentChangeRule.Name, InfraDeploymentsComponentsRule.Name},
+			ExpectedLabelFilter: "integration-service,konflux",
+			ExpectedCommands:    []string{"ginkgo"},
+		},
+		{
+			Name: "several components change",
+			DiffFiles: rulesengine.Files{
+				{Status: "M", Name: "components/release/base/kustomization.yaml"},
+				{Status: "M", Name: "components/enterprise-contract/base/kustomization.yaml"},
+			},
+			ExpectedLabelFilter: "release-service,ec,konflux",
+		},
+		{
+			Name:                "build pipeline config change runs build-templates only",
+			DiffFiles:           rulesengine.Files{{Status: "M", Name: "components/build-service/base/build-pipeline-config/build-pipeline-config.yaml"}},
+			ExpectedLabelFilter: "build-templates,konflux",
+		},
+		{
+			Name:                "build service change",
+			DiffFiles:           rulesengine.Files{{Status: "M", Name: "components/build-service/production/deployment.yaml"}},
+			ExpectedLabelFilter: "build-service,konflux",
+		},
+		{
+			Name:                "other component change runs the default suites",
+			DiffFiles:           rulesengine.Files{{Status: "M", Name: "components/pipeline-service/base/kustomization.yaml"}},
+			ExpectedRules:       []string{InfraDeploymentsDefaultRule.Name},
+			ExpectedLabelFilter: "konflux",
+		},
+		{
+			Name:          "no change runs nothing",
+			ExpectedRules: []string{},
+		},
+	})
+}
+
+func TestDemoCatalog(t *testing.T) {
+	rulesenginetest.RunCatalogTestCases(t, testEngine, []string{"demo", "local-workflow"}, []rulesenginetest.CatalogTestCase{
+		{
+			Name:                "magefiles change bootstraps and runs all suites",
+			DiffFiles:           rulesengine.Files{{Status: "M", Name: "magefiles/magefile.go"}},
+			ExpectedRules:       []string{"Bootstrap a Cluster", NonTestFilesRule.Name},
+			ExpectedLabelFilter: defaultLabelFilter,
+			ExpectedCommands:    []string{"ginkgo"},
+		},
+	})
+}
+
+func TestCIChainCatalogs(t *testing.T) {
+	t.Run("e2e-repo", func(t *testing.T) {
+		stubbedChangedFiles = rulesengine.Files{{Status: "M", Name: "tests/build/build.go"}}
+		defer func() { stubbedChangedFiles = nil }()
+
+		rulesenginetest.RunCatalogTestCases(t, testEngine, []string{"ci", "e2e-repo"}, []rulesenginetest.CatalogTestCase{
+			{
+				Name:               "e2e-tests PR",
+				RepoName:           "e2e-tests",
+				Setup:              func(rctx *rulesengine.RuleCtx) { rctx.PrRemoteName = "user" },
+				ExpectedFocusFiles: []string{"tests/build/build.go"},
+				ExpectedEnvVars:    map[string]string{"INFRA_DEPLOYMENTS_ORG": "user"},
+				ExpectedCommands:   []string{"go install", "ginkgo"},
+			},
+			{
+				Name:          "other repository",
+				RepoName:      "build-service",
+				ExpectedRules: []string{},
+			},
+		})
+	})
+
+	t.Run("infra-deployments", func(t *testing.T) {
+		stubbedChangedFiles = rulesengine.Files{{Status: "M", Name: "components/integration/production/kustomization.yaml"}}
+		defer func() { stubbedChangedFiles = nil }()
+
+		rulesenginetest.RunCatalogTestCases(t, testEngine, []string{"ci", "infra-deployments"}, []rulesenginetest.CatalogTestCase{
+			{
+				Name:     "infra-deployments PR",
+				RepoName: "infra-deployments",
+				Setup: func(rctx *rulesengine.RuleCtx) {
+					rctx.PrRemoteName = "user"
+					rctx.PrBranchName = "feature"
+				},
+				ExpectedLabelFilter: "integration-service,konflux",
+				ExpectedEnvVars:     map[string]string{"INFRA_DEPLOYMENTS_ORG": "user", "INFRA_DEPLOYMENTS_BRANCH": "feature"},
+				ExpectedCommands:    []string{"go install", "ginkgo", "oc get applications.argoproj.io"},
+			},
+			{
+				Name:          "other repository",
+				RepoName:      "e2e-tests",
+				ExpectedRules: []string{},
+			},
+		})
+
+		rulesenginetest.RunCatalogTestCases(t, testEngine, []string{"ci", "infra-deployments"}, []rulesenginetest.CatalogTestCase{
+			{
+				Name:     "infra-deployments PR failing to get the changed files",
+				RepoName: "infra-deployments",
+				Setup: func(rctx *rulesengine.RuleCtx) {
+					getChangedFiles = func(string) (rulesengine.Files, error) { return nil, fmt.Errorf("not cloned") }
+					t.Cleanup(func() { getChangedFiles = func(string) (rulesengine.Files, error) { return stubbedChangedFiles, nil } })
+					rctx.DiffFiles = rulesengine.Files{{Status: "M", Name: "components/pipeline-service/base/kustomization.yaml"}}
+				},
+				ExpectedLabelFilter: "konflux",
+				ExpectedCommands:    []string{"go install", "ginkgo", "oc get"},
+			},
+		})
+	})
+
+	for catalog, label := range map[string]string{
+		"build-service":       "build-service",
+		"integration-service": "integration-service",
+		"image-controller":    "image-controller",
+		"release-service":     "release-service",
+	} {
+		rulesenginetest.RunCatalogTestCases(t, testEngine, []string{"ci", catalog}, []rulesenginetest.CatalogTestCase{
+			{
+				Name:                catalog + " PR",
+				RepoName:            catalog,
+				ExpectedLabelFilter: label,
+				ExpectedCommands:    []string{"go install", "ginkgo"},
+			},
+			{
+				Name:          catalog + " catalog ignores other repositories",
+				RepoName:      "e2e-tests",
+				ExpectedRules: []string{},
+			},
+		})
+	}
+
+	rulesenginetest.RunCatalogTestCases(t, testEngine, []string{"ci", "release-service-catalog"}, []rulesenginetest.CatalogTestCase{
+		{
+			Name:                "release-service-catalog PR from the catalog ITS",
+			RepoName:            "release-service-catalog",
+			JobName:             "konflux-e2e-tests-catalog-abcde",
+			ExpectedRules:       []string{ReleaseServiceCatalogRepoSetDefaultSettingsRule.Name, InfraDeploymentsPRPairingRule.Name, PreflightInstallGinkgoRule.Name, InstallKonfluxRule.Name, ReleaseServiceCatalogCIRule.Name},
+			ExpectedLabelFilter: "fbc-release||rh-advisories",
+			ExpectedCommands:    []string{"go install", "ginkgo"},
+		},
+		{
+			Name:                "release-service-catalog PR from a pipeline ITS",
+			RepoName:            "release-service-catalog",
+			JobName:             "rh-advisories-e2e-test-abcde",
+			ExpectedLabelFilter: "rh-advisories",
+		},
+	})
+
+	// the whole category is run in CI, only the workflow of the job repository has to be
+	for _, repo := range []string{"build-service", "image-controller", "release-service"} {
+		rulesenginetest.RunCatalogTestCases(t, testEngine, []string{"ci"}, []rulesenginetest.CatalogTestCase{
+			{
+				Name:     repo + " PR runs only its own CI rule",
+				RepoName: repo,
+				Setup: func(rctx *rulesengine.RuleCtx) {
+					isPRPairingRequired = func(string, string, string) bool {
+						t.Errorf("the release-service-catalog pairing was checked for a %s PR", repo)
+						return false
+					}
+					t.Cleanup(func() { isPRPairingRequired = func(string, string, string) bool { return false } })
+				},
+				ExpectedLabelFilter: repo,
+				ExpectedCommands:    []string{"go install", "ginkgo"},
+			},
+		})
+	}
+}
+
+func TestWithCleanupRules(t *testing.T) {
+	var cleaned []string
+	cleanupRule := func(name string) *rulesengine.Rule {
+		return &rulesengine.Rule{Name: name, Condition: rulesengine.ConditionFunc(func(*rulesengine.RuleCtx) (bool, error) { return true, nil }),
+			Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(*rulesengine.RuleCtx) error {
+				cleaned = append(cleaned, name)
+				return fmt.Errorf("%s failed", name)
+			})}}
+	}
+	failing := rulesengine.ConditionFunc(func(*rulesengine.RuleCtx) (bool, error) { return false, fmt.Errorf("bootstrap failed") })
+
+	ok, err := withCleanupRules(failing, cleanupRule("collect"), cleanupRule("unregister")).Check(rulesengine.NewRuleCtx())
+	assert.False(t, ok)
+	assert.EqualError(t, err, "bootstrap failed")
+	assert.Equal(t, []string{"collect", "unregister"}, cleaned)
+}
+
+// globFiles returns the files matching the pattern from the e2e-tests repo root, except the excluded ones
+func globFiles(t *testing.T, pattern string, excluded ...string) []string {
+	matched, err := filepath.Glob(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var files []string
+	for _, m := range matched {
+		skip := false
+		for _, e := range excluded {
+			if m == e {
+				skip = true
+			}
+		}
+		if !skip {
+			files = append(files, m)
+		}
+	}
+
+	return files
+}
+
+func TestCatalogConflicts(t *testing.T) {
+	probes := rulesengine.ProbesFromFiles(
+		"magefiles/magefile.go",
+		"pkg/clients/tekton/templates/pipeline.yaml",
+		"tests/build/build.go",
+		"tests/build/const.go",
+		"tests/integration-service/const.go",
+		"tests/release/pipelines/rh_advisories.go",
+		"tests/release/service/happy_path.go",
+		"components/integration/production/kustomization.yaml",
+		"components/build-service/base/build-pipeline-config/build-pipeline-config.yaml",
+		"components/build-service/production/deployment.yaml",
+		"components/release/base/kustomization.yaml",
+		"components/pipeline-service/base/kustomization.yaml",
+	)
+
+	conflicts, err := testEngine.FindConflicts(rulesengine.NewRuleCtx(), probes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range conflicts {
+		t.Error(c.String())
+	}
+}
@@ -31,8 +31,42 @@ import (
 	tektonapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 )
 
+// Functions reaching out to git or GitHub that are called even in dry run mode.
+// They are declared as variables so that they can be stubbed in unit tests.
+var (
+	getChangedFiles        = utils.GetChangedFiles
+	isPRPairingRequired    = IsPRPairingRequired
+	selectReleasePipelines = selectReleasePipelinesTestCases
+	findImpactedTestFiles  = FindImpactedTestFiles
+	getChangedLines        = GetChangedLines
+)
+
+// TestAction runs the test suites selected in the RuleCtx, the ginkgo command is simulated in dry run mode
+var TestAction = rulesengine.SimulatedAction{Action: ExecuteTestAction, DryRun: SimulateTestAction}
+
+// ExecuteTestAction runs ginkgo with the test selection of the RuleCtx
 func ExecuteTestAction(rctx *rulesengine.RuleCtx) error {
 
+	argsToRun, err := ginkgoArgs(rctx)
+	if err != nil {
+		return err
+	}
+	return sh.RunV("ginkgo", argsToRun...)
+}
+
+// SimulateTestAction records the ginkgo command in the execution plan. Without a plan, ginkgo is
+// run in dry run mode, so that the selected specs are only reported.
+func SimulateTestAction(rctx *rulesengine.RuleCtx) error {
+
+	argsToRun, err := ginkgoArgs(rctx)
+	if err != nil {
+		return err
+	}
+	return rctx.RunCommand("ginkgo", argsToRun...)
+}
+
+func ginkgoArgs(rctx *rulesengine.RuleCtx) ([]string, error) {
+
 	/* This is so that we don't have ginkgo add the prefixes to
 	the command args i.e. '--ginkgo.xx' || '--test.xx' || '--go.xx'
 	we let ginkgo handle that when we actually run the ginkgo cmd.
@@ -54,7 +88,7 @@ func ExecuteTestAction(rctx *rulesengine.RuleCtx) error {
 	var flagSet, err = gtypes.BuildRunCommandFlagSet(&suiteConfig, &reporterConfig, &cliConfig, &goFlagsConfig)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	errs := gtypes.VetConfig(flagSet, suiteConfig, reporterConfig)
@@ -85,7 +119,7 @@ func ExecuteTestAction(rctx *rulesengine.RuleCtx) error {
 		klog.Error(err)
 	}
 	argsToRun = append(argsToRun, "./cmd", "--")
-	return sh.RunV("ginkgo", argsToRun...)
+	return argsToRun, nil
 
 }
 
@@ -301,41 +335,40 @@ var PrepareBranchRule = rulesengine.Rule{Name: "Prepare E2E branch for CI",
 			}
 		}
 
-		return IsPRPairingRequired("e2e-tests", rctx.PrRemoteName, rctx.PrBranchName), nil
+		return isPRPairingRequired("e2e-tests", rctx.PrRemoteName, rctx.PrBranchName), nil
 	}), rulesengine.None{rulesengine.ConditionFunc(IsPeriodicJob),
 		rulesengine.ConditionFunc(IsRehearseJob)}},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
-
-		if rctx.DryRun {
-
+	Actions: []rulesengine.Action{rulesengine.SimulatedAction{
+		Action: func(rctx *rulesengine.RuleCtx) error {
+			return GitCheckoutRemoteBranch(rctx.PrRemoteName, rctx.PrBranchName)
+		},
+		DryRun: func(rctx *rulesengine.RuleCtx) error {
 			for _, arg := range [][]string{
 				{"remote", "add", rctx.PrRemoteName, fmt.Sprintf("https://github.com/%s/e2e-tests.git", rctx.PrRemoteName)},
 				{"fetch", rctx.PrRemoteName},
 				{"checkout", rctx.PrCommitSha},
 				{"pull", "--rebase", "upstream", "main"},
 			} {
-				klog.Infof("git %s", arg)
+				rctx.RecordCommand("git", arg...)
 			}
-
 			return nil
-		}
-
-		return GitCheckoutRemoteBranch(rctx.PrRemoteName, rctx.PrBranchName)
-	})}}
+		},
+	}}}
 
 var PreflightInstallGinkgoRule = rulesengine.Rule{Name: "Preflight Check",
 	Description: "Check the envroniment has all the minimal pre-req variables/tools installed and install ginkgo.",
 	Condition:   rulesengine.ConditionFunc(IsPrelightChecked),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
-
-		if rctx.DryRun {
+	Actions: []rulesengine.Action{rulesengine.SimulatedAction{
+		Action: func(rctx *rulesengine.RuleCtx) error {
+			return sh.RunV("go", "install", "-mod=mod", "github.com/onsi/ginkgo/v2/ginkgo")
+		},
+		DryRun: func(rctx *rulesengine.RuleCtx) error {
 			klog.Info("Installing Ginkgo Test Runner")
-			klog.Info("Running command: go install -mod=mod github.com/onsi/ginkgo/v2/ginkgo")
+			rctx.RecordCommand("go", "install", "-mod=mod", "github.com/onsi/ginkgo/v2/ginkgo")
 			klog.Info("Ginkgo Installation Complete.")
 			return nil
-		}
-		return sh.RunV("go", "install", "-mod=mod", "github.com/onsi/ginkgo/v2/ginkgo")
-	}),
+		},
+	},
 	},
 }
 
@@ -344,15 +377,16 @@ var InstallKonfluxRule = rulesengine.Rule{Name: "Install Konflux",
 	Condition: rulesengine.ConditionFunc(func(rctx *rulesengine.RuleCtx) (bool, error) {
 		return os.Getenv("SKIP_BOOTSTRAP") != "true", nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
-
-		if rctx.DryRun {
+	Actions: []rulesengine.Action{rulesengine.SimulatedAction{
+		Action: func(rctx *rulesengine.RuleCtx) error {
+			return retry(InstallKonflux, 2, 10*time.Second)
+		},
+		DryRun: func(rctx *rulesengine.RuleCtx) error {
 			klog.Info("Installing Konflux in Preview mode.")
 			klog.Info("Konflux Installation Complete.")
 			return nil
-		}
-		return retry(InstallKonflux, 2, 10*time.Second)
-	}),
+		},
+	},
 	},
 }
 
@@ -361,23 +395,41 @@ var RegisterKonfluxToSprayProxyRule = rulesengine.Rule{Name: "Register SprayProx
 	Condition: rulesengine.Any{
 		rulesengine.ConditionFunc(IsSprayProxyRequired),
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
-
-		if rctx.DryRun {
+	Actions: []rulesengine.Action{rulesengine.SimulatedAction{
+		Action: func(rctx *rulesengine.RuleCtx) error {
+			err := registerPacServer()
+			if err != nil {
+				os.Setenv(constants.SKIP_PAC_TESTS_ENV, "true")
+				if alertErr := HandleErrorWithAlert(fmt.Errorf("failed to register SprayProxy: %+v", err), slack.ErrorSeverityLevelError); alertErr != nil {
+					return alertErr
+				}
+			}
+			return nil
+		},
+		DryRun: func(rctx *rulesengine.RuleCtx) error {
 			klog.Info("Registering Konflux to SprayProxy.")
 			klog.Info("Registration Complete.")
 			return nil
-		}
+		},
+	},
+	},
+}
 
-		err := registerPacServer()
-		if err != nil {
-			os.Setenv(constants.SKIP_PAC_TESTS_ENV, "true")
-			if alertErr := HandleErrorWithAlert(fmt.Errorf("failed to register SprayProxy: %+v", err), slack.ErrorSeverityLevelError); alertErr != nil {
-				return alertErr
-			}
-		}
-		return nil
-	}),
+var UnregisterKonfluxFromSprayProxyRule = rulesengine.Rule{Name: "Unregister SprayProxy",
+	Description: "Unregister Konflux from the SprayProxy it was registered to.",
+	Condition: rulesengine.Any{
+		rulesengine.ConditionFunc(IsSprayProxyRequired),
+	},
+	Actions: []rulesengine.Action{rulesengine.SimulatedAction{
+		Action: func(rctx *rulesengine.RuleCtx) error {
+			return UnregisterKonfluxFromSprayProxy()
+		},
+		DryRun: func(rctx *rulesengine.RuleCtx) error {
+			klog.Info("Unregistering Konflux from SprayProxy.")
+			klog.Info("Unregistration Complete.")
+			return nil
+		},
+	},
 	},
 }
 
@@ -386,16 +438,16 @@ var SetupMultiPlatformTestsRule = rulesengine.Rule{Name: "Setup multi-platform t
 	Condition: rulesengine.Any{
 		rulesengine.ConditionFunc(IsMultiPlatformConfigRequired),
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
-
-		if rctx.DryRun {
+	Actions: []rulesengine.Action{rulesengine.SimulatedAction{
+		Action: func(rctx *rulesengine.RuleCtx) error {
+			return SetupMultiPlatformTests()
+		},
+		DryRun: func(rctx *rulesengine.RuleCtx) error {
 			klog.Info("Setting up multi platform tests.")
 			klog.Info("Multi platform tests configured.")
 			return nil
-		}
-
-		return SetupMultiPlatformTests()
-	}),
+		},
+	},
 	},
 }
 
@@ -434,6 +486,53 @@ func registerPacServer() error {
 	return nil
 }
 
+// UnregisterKonfluxFromSprayProxy unregisters the PaC server of the cluster from SprayProxy,
+// a failure is only reported with a Slack alert since it doesn't affect the tests.
+func UnregisterKonfluxFromSprayProxy() error {
+	if err := unregisterPacServer(); err != nil {
+		return HandleErrorWithAlert(fmt.Errorf("failed to unregister SprayProxy: %+v", err), slack.ErrorSeverityLevelInfo)
+	}
+	return nil
+}
+
+func unregisterPacServer() error {
+	sprayProxyConfig, err := newSprayProxy()
+	if err != nil {
+		return fmt.Errorf("failed to set up SprayProxy credentials: %+v", err)
+	}
+
+	pacHost, err := sprayproxy.GetPaCHost()
+	if err != nil {
+		return fmt.Errorf("failed to get PaC host: %+v", err)
+	}
+	_, err = sprayProxyConfig.UnregisterServer(pacHost)
+	if err != nil {
+		return fmt.Errorf("error when unregistering PaC server %s from SprayProxy server %s: %+v", pacHost, sprayProxyConfig.BaseURL, err)
+	}
+	klog.Infof("Unregistered PaC server: %s", pacHost)
+	// for debugging purposes
+	err = printRegisteredPacServers(sprayProxyConfig)
+	if err != nil {
+		klog.Error(err)
+	}
+	return nil
+}
+
+// withCleanupRules returns a conditional checking cond and then the cleanup rules, even when cond failed.
+// The errors of the cleanup rules are only logged, the outcome is the one of cond.
+func withCleanupRules(cond rulesengine.Conditional, cleanup ...*rulesengine.Rule) rulesengine.ConditionFunc {
+
+	return func(rctx *rulesengine.RuleCtx) (bool, error) {
+		ok, err := cond.Check(rctx)
+		for _, rule := range cleanup {
+			if _, cleanupErr := rule.Check(rctx); cleanupErr != nil {
+				klog.Errorf("failed to apply the cleanup rule %q: %v", rule.Name, cleanupErr)
+			}
+		}
+		return ok, err
+	}
+}
+
 func newSprayProxy() (*sprayproxy.SprayProxyConfig, error) {
 	var sprayProxyUrl, sprayProxyToken string
 	if sprayProxyUrl = os.Getenv("QE_SPRAYPROXY_HOST"); sprayProxyUrl == "" {
@@ -560,10 +659,16 @@ func SetEnvVarsForComponentImageDeployment(rctx *rulesengine.RuleCtx) error {
 		}
 	}
 
-	os.Setenv(fmt.Sprintf("%s_IMAGE_REPO", rctx.ComponentEnvVarPrefix), repository)
-	os.Setenv(fmt.Sprintf("%s_IMAGE_TAG", rctx.ComponentEnvVarPrefix), tag)
-	os.Setenv(fmt.Sprintf("%s_PR_OWNER", rctx.ComponentEnvVarPrefix), rctx.PrRemoteName)
-	os.Setenv(fmt.Sprintf("%s_PR_SHA", rctx.ComponentEnvVarPrefix), rctx.PrCommitSha)
+	for key, value := range map[string]string{
+		fmt.Sprintf("%s_IMAGE_REPO", rctx.ComponentEnvVarPrefix): repository,
+		fmt.Sprintf("%s_IMAGE_TAG", rctx.ComponentEnvVarPrefix):  tag,
+		fmt.Sprintf("%s_PR_OWNER", rctx.ComponentEnvVarPrefix):   rctx.PrRemoteName,
+		fmt.Sprintf("%s_PR_SHA", rctx.ComponentEnvVarPrefix):     rctx.PrCommitSha,
+	} {
+		if err := rctx.Setenv(key, value); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
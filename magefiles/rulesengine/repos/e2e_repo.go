@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/konflux-ci/e2e-tests/magefiles/rulesengine"
-	"github.com/konflux-ci/e2e-tests/pkg/utils"
 	"k8s.io/klog"
 )
 
@@ -16,7 +15,7 @@ var NonTestFilesRule = rulesengine.Rule{Name: "E2E Default PR Test Exectuion",
 	Description: "Runs all suites when any non test files are modified in the e2e-repo PR",
 	Condition: rulesengine.All{
 		rulesengine.Any{
-			rulesengine.ConditionFunc(CheckPkgFilesChanged),
+			rulesengine.ConditionFunc(CheckPkgFilesChangedWithUnknownImpact),
 			rulesengine.ConditionFunc(CheckMageFilesChanged),
 			rulesengine.ConditionFunc(CheckCmdFilesChanged),
 			rulesengine.ConditionFunc(CheckNoFilesChanged),
@@ -24,7 +23,9 @@ var NonTestFilesRule = rulesengine.Rule{Name: "E2E Default PR Test Exectuion",
 		},
 		rulesengine.None{rulesengine.ConditionFunc(CheckReleasePipelinesTestsChanged)},
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(ExecuteDefaultTestAction)},
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(SelectDefaultTests), TestAction},
+	// running all the suites supersedes any other test selection
+	Exclusive: true,
 }
 
 var NonTestFilesRuleWithReleasePipelines = rulesengine.Rule{Name: "E2E PR Test Execution including release-pipelines test suite",
@@ -39,14 +40,15 @@ var NonTestFilesRuleWithReleasePipelines = rulesengine.Rule{Name: "E2E PR Test E
 		},
 		rulesengine.ConditionFunc(CheckReleasePipelinesTestsChanged),
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(ExecuteAllTestsExceptUpgradeTestSuite)},
+	Actions:   []rulesengine.Action{rulesengine.CtxActionFunc(SelectAllTestsExceptUpgradeTestSuite), TestAction},
+	Exclusive: true,
 }
 
 var TestFilesOnlyRule = rulesengine.Rule{Name: "E2E PR Test File Diff Execution",
 	Description: "Runs specific tests when test files are the only changes in the e2e-repo PR",
 	Condition: rulesengine.All{
 		rulesengine.None{
-			rulesengine.ConditionFunc(CheckPkgFilesChanged),
+			rulesengine.ConditionFunc(CheckPkgFilesChangedWithUnknownImpact),
 			rulesengine.ConditionFunc(CheckMageFilesChanged),
 			rulesengine.ConditionFunc(CheckCmdFilesChanged),
 			rulesengine.ConditionFunc(CheckNoFilesChanged),
@@ -62,9 +64,10 @@ var TestFilesOnlyRule = rulesengine.Rule{Name: "E2E PR Test File Diff Execution"
 			&IntegrationTestsConstFileChangeRule,
 			&IntegrationTestsFileChangeRule,
 			&EcTestFileChangeRule,
+			&PkgFilesImpactedTestsRule,
 		},
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(ExecuteTestAction)}}
+	Actions: []rulesengine.Action{TestAction}}
 
 func CheckReleasePipelinesTestsChanged(rctx *rulesengine.RuleCtx) (bool, error) {
 
@@ -86,7 +89,7 @@ var BuildORBuildTemplatesTestFileChangeOnlyRule = rulesengine.Rule{Name: "E2E PR
 			len(rctx.DiffFiles.FilterByDirString("tests/build/const.go")) == 0 &&
 			len(rctx.DiffFiles.FilterByDirString("tests/build/source_build.go")) == 0, nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 
 		for _, file := range rctx.DiffFiles.FilterByDirGlob("tests/build/*.go") {
 
@@ -103,7 +106,7 @@ var BuildTemplateDependentFileChangeRule = rulesengine.Rule{Name: "E2E PR Build
 
 		return len(rctx.DiffFiles.FilterByDirString("tests/build/build_templates_scenarios.go")) != 0 || len(rctx.DiffFiles.FilterByDirString("tests/build/source_build.go")) != 0, nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 
 		rctx.FocusFiles = dedupeAppendFiles(rctx.FocusFiles, "tests/build/build_templates.go")
 
@@ -117,7 +120,7 @@ var BuildNonTestFileChangeRule = rulesengine.Rule{Name: "E2E PR Build Test Helpe
 
 		return len(rctx.DiffFiles.FilterByDirGlob("tests/build/const.go")) != 0, nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 
 		for _, file := range rctx.DiffFiles.FilterByDirGlob("tests/build/*.go") {
 
@@ -138,7 +141,7 @@ var ReleaseTestTestFilesChangeRule = rulesengine.Rule{Name: "E2E PR Release Test
 
 		return len(rctx.DiffFiles.FilterByDirGlob("tests/release/*/*.go")) != 0, nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 
 		for _, file := range rctx.DiffFiles.FilterByDirGlob("tests/release/*/*.go") {
 
@@ -156,7 +159,7 @@ var ReleaseTestHelperFilesChangeOnlyRule = rulesengine.Rule{Name: "E2E PR Releas
 
 		return len(rctx.DiffFiles.FilterByDirGlob("tests/release/*.go")) != 0 && len(rctx.DiffFiles.FilterByDirGlob("tests/release/*/*.go")) == 0, nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 
 		matched, err := filepath.Glob("tests/release/*/*.go")
 		if err != nil {
@@ -178,7 +181,7 @@ var KonfluxDemoTestFileChangedRule = rulesengine.Rule{Name: "E2E PR Konflux-Demo
 
 		return len(rctx.DiffFiles.FilterByDirGlob("tests/*-demo/*.go")) != 0, nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 
 		for _, file := range rctx.DiffFiles.FilterByDirGlob("tests/*-demo/*-demo.go") {
 
@@ -195,7 +198,7 @@ var KonfluxDemoConfigsFileOnlyChangeRule = rulesengine.Rule{Name: "E2E PR Konflu
 
 		return len(rctx.DiffFiles.FilterByDirGlob("tests/*-demo/*.go")) == 0 && len(rctx.DiffFiles.FilterByDirGlob("tests/*-demo/*/*")) != 0, nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 
 		matched, err := filepath.Glob("tests/*-demo/*-demo.go")
 		if err != nil {
@@ -217,7 +220,7 @@ var IntegrationTestsFileChangeRule = rulesengine.Rule{Name: "E2E PR Integration
 
 		return len(rctx.DiffFiles.FilterByDirGlob("tests/integration-*/*.go")) != 0, nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 
 		for _, file := range rctx.DiffFiles.FilterByDirGlob("tests/integration-*/*.go") {
 
@@ -241,7 +244,7 @@ var IntegrationTestsConstFileChangeRule = rulesengine.Rule{Name: "E2E PR Integra
 
 		return len(rctx.DiffFiles.FilterByDirGlob("tests/integration-*/const.go")) != 0, nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 
 		matched, err := filepath.Glob("tests/integration-*/*.go")
 		if err != nil {
@@ -269,7 +272,7 @@ var EcTestFileChangeRule = rulesengine.Rule{Name: "E2E PR EC Test File Change Ru
 	Condition: rulesengine.ConditionFunc(func(rctx *rulesengine.RuleCtx) (bool, error) {
 		return len(rctx.DiffFiles.FilterByDirGlob("tests/enterprise-*/*.go")) != 0, nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 
 		for _, file := range rctx.DiffFiles.FilterByDirGlob("tests/enterprise-*/*.go") {
 
@@ -294,22 +297,22 @@ var InfraDeploymentsPRPairingRule = rulesengine.Rule{Name: "Set Required Setting
 			}
 		}
 
-		return IsPRPairingRequired("infra-deployments", rctx.PrRemoteName, rctx.PrBranchName), nil
+		return isPRPairingRequired("infra-deployments", rctx.PrRemoteName, rctx.PrBranchName), nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
-
-		if rctx.DryRun {
-
-			klog.Infof("Set INFRA_DEPLOYMENTS_ORG: %s", rctx.PrRemoteName)
-			klog.Infof("Set INFRA_DEPLOYMENTS_BRANCH: %s", rctx.PrBranchName)
+	Actions: []rulesengine.Action{rulesengine.SimulatedAction{
+		Action: func(rctx *rulesengine.RuleCtx) error {
+			klog.Infof("pairing with infra-deployments org %q and branch %q", rctx.PrRemoteName, rctx.PrBranchName)
+			if err := os.Setenv("INFRA_DEPLOYMENTS_ORG", rctx.PrRemoteName); err != nil {
+				return err
+			}
+			return os.Setenv("INFRA_DEPLOYMENTS_BRANCH", rctx.PrBranchName)
+		},
+		DryRun: func(rctx *rulesengine.RuleCtx) error {
+			rctx.RecordEnv("INFRA_DEPLOYMENTS_ORG", rctx.PrRemoteName)
+			rctx.RecordEnv("INFRA_DEPLOYMENTS_BRANCH", rctx.PrBranchName)
 			return nil
-		}
-
-		klog.Infof("pairing with infra-deployments org %q and branch %q", rctx.PrRemoteName, rctx.PrBranchName)
-		os.Setenv("INFRA_DEPLOYMENTS_ORG", rctx.PrRemoteName)
-		os.Setenv("INFRA_DEPLOYMENTS_BRANCH", rctx.PrBranchName)
-		return nil
-	})},
+		},
+	}},
 }
 
 var E2ERepoCIRuleChain = rulesengine.Rule{Name: "E2E Repo CI Workflow Rule Chain",
@@ -320,6 +323,7 @@ var E2ERepoCIRuleChain = rulesengine.Rule{Name: "E2E Repo CI Workflow Rule Chain
 		&PreflightInstallGinkgoRule,
 		&BootstrapClusterRuleChain,
 		rulesengine.Any{&NonTestFilesRule, &NonTestFilesRuleWithReleasePipelines, &TestFilesOnlyRule}},
+	Exclusive: true,
 }
 
 var E2ERepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General Required Settings for E2E Repo Jobs",
@@ -327,14 +331,14 @@ var E2ERepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General Required Set
 	Condition: rulesengine.Any{
 		IsE2ETestsRepoPR,
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 		var err error
 
 		rctx.RequiresMultiPlatformTests = true
 		rctx.RequiresSprayProxyRegistering = true
 		klog.Info("multi-platform tests and require sprayproxy registering are set to TRUE")
 
-		rctx.DiffFiles, err = utils.GetChangedFiles(rctx.RepoName)
+		rctx.DiffFiles, err = getChangedFiles(rctx.RepoName)
 		return err
 	})},
 }
@@ -371,16 +375,16 @@ func CheckCmdFilesChanged(rctx *rulesengine.RuleCtx) (bool, error) {
 
 }
 
-func ExecuteDefaultTestAction(rctx *rulesengine.RuleCtx) error {
+func SelectDefaultTests(rctx *rulesengine.RuleCtx) error {
 	rctx.LabelFilter = "!upgrade-create && !upgrade-verify && !upgrade-cleanup && !release-pipelines"
-	return ExecuteTestAction(rctx)
+	return nil
 
 }
 
-func ExecuteAllTestsExceptUpgradeTestSuite(rctx *rulesengine.RuleCtx) error {
+func SelectAllTestsExceptUpgradeTestSuite(rctx *rulesengine.RuleCtx) error {
 	rctx.LabelFilter = "!upgrade-create && !upgrade-verify && !upgrade-cleanup"
 	rctx.Timeout = 2*time.Hour + 30*time.Minute
-	return ExecuteTestAction(rctx)
+	return nil
 
 }
 
@@ -17,7 +17,8 @@ var ImageControllerCIRule = rulesengine.Rule{Name: "image-controller repo CI Wor
 		&PreflightInstallGinkgoRule,
 		rulesengine.Any{rulesengine.None{&BootstrapClusterWithSprayProxyRuleChain}, &BootstrapClusterWithSprayProxyRuleChain},
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(ExecuteTestAction)},
+	Actions:   []rulesengine.Action{TestAction},
+	Exclusive: true,
 }
 
 var ImageControllerRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General Required Settings for image-controller repository jobs",
@@ -25,24 +26,27 @@ var ImageControllerRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General
 	Condition: rulesengine.Any{
 		IsImageControllerRepoPR,
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 		rctx.RequiresSprayProxyRegistering = true
 		klog.Info("require sprayproxy registering is set to TRUE")
 
 		rctx.LabelFilter = "image-controller"
 		klog.Info("setting 'image-controller' test label")
-
-		if rctx.DryRun {
+		return nil
+	}), rulesengine.SimulatedAction{
+		Action: func(rctx *rulesengine.RuleCtx) error {
+			rctx.ComponentEnvVarPrefix = "IMAGE_CONTROLLER"
+			// TODO keep only "KONFLUX_CI" option once we migrate off openshift-ci
+			if os.Getenv("KONFLUX_CI") != "true" {
+				rctx.ComponentImageTag = "redhat-appstudio-image-controller-image"
+			}
+			return SetEnvVarsForComponentImageDeployment(rctx)
+		},
+		DryRun: func(rctx *rulesengine.RuleCtx) error {
 			klog.Info("setting up env vars for deploying component image")
 			return nil
-		}
-		rctx.ComponentEnvVarPrefix = "IMAGE_CONTROLLER"
-		// TODO keep only "KONFLUX_CI" option once we migrate off openshift-ci
-		if os.Getenv("KONFLUX_CI") != "true" {
-			rctx.ComponentImageTag = "redhat-appstudio-image-controller-image"
-		}
-		return SetEnvVarsForComponentImageDeployment(rctx)
-	})},
+		},
+	}},
 }
 
 var IsImageControllerRepoPR = rulesengine.ConditionFunc(func(rctx *rulesengine.RuleCtx) (bool, error) {
@@ -2,9 +2,12 @@ package repos
 
 import (
 	"fmt"
-	"strings"
+	"os"
+	"path/filepath"
 
 	"github.com/konflux-ci/e2e-tests/magefiles/rulesengine"
+	"github.com/magefile/mage/sh"
+	"k8s.io/klog"
 )
 
 // Default Rule of repo infra-deployments running konflux-demo suite.
@@ -21,12 +24,13 @@ var InfraDeploymentsDefaultRule = rulesengine.Rule{Name: "Infra Deployments Defa
 		&InfraDeploymentsJVMComponentChangeRule,
 		rulesengine.ConditionFunc(CheckNoFilesChanged)},
 
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(ExecuteInfraDeploymentsDefaultTestAction)}}
+	Actions:   []rulesengine.Action{rulesengine.CtxActionFunc(SelectInfraDeploymentsDefaultTests), TestAction},
+	Exclusive: true}
 
-// ExecuteInfraDeploymentsDefaultTestAction excutes all the e2e-tests and component suites
-func ExecuteInfraDeploymentsDefaultTestAction(rctx *rulesengine.RuleCtx) error {
+// SelectInfraDeploymentsDefaultTests selects all the e2e-tests and component suites
+func SelectInfraDeploymentsDefaultTests(rctx *rulesengine.RuleCtx) error {
 	rctx.LabelFilter = "konflux"
-	return ExecuteTestAction(rctx)
+	return nil
 }
 
 // InfraDeploymentsComponentsRule defines rules of test suites running of each changed component
@@ -41,13 +45,13 @@ var InfraDeploymentsComponentsRule = rulesengine.Rule{Name: "Infra-deployments P
 		&InfraDeploymentsReleaseServiceComponentChangeRule,
 		&InfraDeploymentsEnterpriseControllerComponentChangeRule,
 		&InfraDeploymentsJVMComponentChangeRule},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 		// Adding "konflux" to the label filter when component is updated
 		AddLabelToLabelFilter(rctx, "konflux")
 		return nil
 
 	}),
-		rulesengine.ActionFunc(ExecuteTestAction)}}
+		TestAction}}
 
 var InfraDeploymentsIntegrationComponentChangeRule = rulesengine.Rule{Name: "Infra-deployments PR Integration component File Change Rule",
 	Description: "Map Integration tests files when Integration component files are changed in the infra-deployments PR",
@@ -56,7 +60,7 @@ var InfraDeploymentsIntegrationComponentChangeRule = rulesengine.Rule{Name: "Inf
 		return len(rctx.DiffFiles.FilterByDirGlob("components/integration/**/*")) != 0, nil
 
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 		AddLabelToLabelFilter(rctx, "integration-service")
 		return nil
 	})}}
@@ -67,7 +71,7 @@ var InfraDeploymentsEnterpriseControllerComponentChangeRule = rulesengine.Rule{N
 
 		return len(rctx.DiffFiles.FilterByDirGlob("components/enterprise-contract/**/*")) != 0, nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 		AddLabelToLabelFilter(rctx, "ec")
 		return nil
 	})}}
@@ -78,7 +82,7 @@ var InfraDeploymentsJVMComponentChangeRule = rulesengine.Rule{Name: "Infra-deplo
 
 		return len(rctx.DiffFiles.FilterByDirGlob("components/jvm-build-service/**/*")) != 0, nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 		AddLabelToLabelFilter(rctx, "jvm-build-service")
 		return nil
 	})}}
@@ -89,7 +93,7 @@ var InfraDeploymentsImageControllerComponentChangeRule = rulesengine.Rule{Name:
 
 		return len(rctx.DiffFiles.FilterByDirGlob("components/image-controller/**/*")) != 0, nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 		AddLabelToLabelFilter(rctx, "image-controller")
 		return nil
 	})}}
@@ -100,7 +104,7 @@ var InfraDeploymentsMultiPlatformComponentChangeRule = rulesengine.Rule{Name: "I
 
 		return len(rctx.DiffFiles.FilterByDirGlob("components/multi-platform-controller/**/*")) != 0, nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 		AddLabelToLabelFilter(rctx, "multi-platform")
 		return nil
 	})}}
@@ -111,7 +115,7 @@ var InfraDeploymentsBuildTemplatesComponentChangeRule = rulesengine.Rule{Name: "
 		return len(rctx.DiffFiles.FilterByDirGlob("components/build-service/base/build-pipeline-config/build-pipeline-config.yaml")) != 0, nil
 	}),
 	Actions: []rulesengine.Action{
-		rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+		rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 			AddLabelToLabelFilter(rctx, "build-templates")
 			return nil
 		}),
@@ -125,7 +129,7 @@ var InfraDeploymentsBuildServiceComponentChangeRule = rulesengine.Rule{Name: "In
 		return len(rctx.DiffFiles.FilterByDirGlob("components/build-service/**/*")) > len(rctx.DiffFiles.FilterByDirGlob("components/build-service/base/build-pipeline-config/*")), nil
 	}),
 	Actions: []rulesengine.Action{
-		rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+		rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 			AddLabelToLabelFilter(rctx, "build-service")
 			return nil
 		}),
@@ -138,7 +142,7 @@ var InfraDeploymentsReleaseServiceComponentChangeRule = rulesengine.Rule{Name: "
 
 		return len(rctx.DiffFiles.FilterByDirGlob("components/release/**/*")) != 0, nil
 	}),
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 		AddLabelToLabelFilter(rctx, "release-service")
 		return nil
 	})}}
@@ -147,11 +151,108 @@ var InfraDeploymentsRulesCatalog = rulesengine.RuleCatalog{InfraDeploymentsDefau
 
 // AddLabelToLabelFilter ensures the given label is added to the LabelFilter of rctx
 func AddLabelToLabelFilter(rctx *rulesengine.RuleCtx, label string) {
-	if !strings.Contains(rctx.LabelFilter, label) {
-		if rctx.LabelFilter == "" {
-			rctx.LabelFilter = label
-		} else {
-			rctx.LabelFilter = fmt.Sprintf("%s,%s", rctx.LabelFilter, label)
-		}
-	}
+	rctx.AddLabelToLabelFilter(label)
 }
+
+var InfraDeploymentsCIChainCatalog = rulesengine.RuleCatalog{InfraDeploymentsCIRuleChain}
+
+var InfraDeploymentsCIRuleChain = rulesengine.Rule{Name: "Infra-deployments Repo CI Workflow Rule Chain",
+	Description: "Execute the full workflow for infra-deployments repo in CI",
+	Condition: rulesengine.All{
+		&InfraDeploymentsRepoSetDefaultSettingsRule,
+		// the cleanup runs even when the bootstrap or the tests failed
+		withCleanupRules(
+			rulesengine.All{
+				&PreflightInstallGinkgoRule,
+				&BootstrapClusterRuleChain,
+				&InfraDeploymentsChangedFilesRule,
+				rulesengine.Any{&InfraDeploymentsDefaultRule, &InfraDeploymentsComponentsRule},
+			},
+			&InfraDeploymentsCollectArtifactsRule,
+			&UnregisterKonfluxFromSprayProxyRule,
+		),
+	},
+	Exclusive: true,
+}
+
+var InfraDeploymentsRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General Required Settings for infra-deployments repository jobs",
+	Description: "Set multiplatform and SprayProxy settings to true and deploy Konflux from the PR branch for infra-deployments jobs",
+	Condition: rulesengine.Any{
+		IsInfraDeploymentsRepoPR,
+	},
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
+		rctx.RequiresMultiPlatformTests = true
+		rctx.RequiresSprayProxyRegistering = true
+		klog.Info("multi-platform tests and require sprayproxy registering are set to TRUE")
+		return nil
+	}), rulesengine.SimulatedAction{
+		// Konflux is installed from the infra-deployments PR branch
+		Action: func(rctx *rulesengine.RuleCtx) error {
+			klog.Infof("installing infra-deployments from org %q and branch %q", rctx.PrRemoteName, rctx.PrBranchName)
+			if err := os.Setenv("INFRA_DEPLOYMENTS_ORG", rctx.PrRemoteName); err != nil {
+				return err
+			}
+			return os.Setenv("INFRA_DEPLOYMENTS_BRANCH", rctx.PrBranchName)
+		},
+		DryRun: func(rctx *rulesengine.RuleCtx) error {
+			rctx.RecordEnv("INFRA_DEPLOYMENTS_ORG", rctx.PrRemoteName)
+			rctx.RecordEnv("INFRA_DEPLOYMENTS_BRANCH", rctx.PrBranchName)
+			return nil
+		},
+	}},
+}
+
+var InfraDeploymentsChangedFilesRule = rulesengine.Rule{Name: "Get the changed files of the infra-deployments PR",
+	Description: "Get the files changed by the infra-deployments PR from the repo cloned when installing Konflux",
+	Condition:   IsInfraDeploymentsRepoPR,
+	Actions: []rulesengine.Action{rulesengine.SimulatedAction{
+		Action: func(rctx *rulesengine.RuleCtx) error {
+			files, err := getChangedFiles(rctx.RepoName)
+			if err != nil {
+				return err
+			}
+			rctx.DiffFiles = files
+			return nil
+		},
+		DryRun: func(rctx *rulesengine.RuleCtx) error {
+			files, err := getChangedFiles(rctx.RepoName)
+			if err != nil {
+				// the repo isn't cloned when Konflux is installed in dry run mode
+				klog.Infof("cannot get the changed files of infra-deployments, keeping %q: %v", rctx.DiffFiles.String(), err)
+				return nil
+			}
+			rctx.DiffFiles = files
+			return nil
+		},
+	}},
+}
+
+var InfraDeploymentsCollectArtifactsRule = rulesengine.Rule{Name: "Collect infra-deployments artifacts",
+	Description: "Store the state of the Argo CD applications deployed from the infra-deployments PR in the artifact dir",
+	Condition:   IsInfraDeploymentsRepoPR,
+	Actions: []rulesengine.Action{rulesengine.SimulatedAction{
+		Action: func(rctx *rulesengine.RuleCtx) error {
+			output, err := sh.Output("oc", argoCDApplicationsArgs...)
+			if err != nil {
+				return fmt.Errorf("failed to get the Argo CD applications: %+v", err)
+			}
+			file := filepath.Join(rctx.OutputDir, "infra-deployments-argocd-applications.yaml")
+			if err := os.WriteFile(file, []byte(output), 0600); err != nil {
+				return fmt.Errorf("failed to store the Argo CD applications to %s: %+v", file, err)
+			}
+			klog.Infof("Argo CD applications stored in %s", file)
+			return nil
+		},
+		DryRun: func(rctx *rulesengine.RuleCtx) error {
+			rctx.RecordCommand("oc", argoCDApplicationsArgs...)
+			return nil
+		},
+	}},
+}
+
+var argoCDApplicationsArgs = []string{"get", "applications.argoproj.io", "-n", "openshift-gitops", "-o", "yaml"}
+
+var IsInfraDeploymentsRepoPR = rulesengine.ConditionFunc(func(rctx *rulesengine.RuleCtx) (bool, error) {
+	klog.Info("checking if repository is infra-deployments")
+	return rctx.RepoName == "infra-deployments", nil
+})
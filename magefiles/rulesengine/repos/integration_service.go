@@ -17,7 +17,8 @@ var IntegrationServiceCIRule = rulesengine.Rule{Name: "Integration-service repo
 		&PreflightInstallGinkgoRule,
 		rulesengine.Any{rulesengine.None{&BootstrapClusterWithSprayProxyRuleChain}, &BootstrapClusterWithSprayProxyRuleChain},
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(ExecuteTestAction)},
+	Actions:   []rulesengine.Action{TestAction},
+	Exclusive: true,
 }
 
 var IntegrationServiceRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General Required Settings for integration-service repository jobs",
@@ -25,24 +26,27 @@ var IntegrationServiceRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "Gener
 	Condition: rulesengine.Any{
 		IsIntegrationServiceRepoPR,
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 		rctx.RequiresSprayProxyRegistering = true
 		klog.Info("require sprayproxy registering is set to TRUE")
 
 		rctx.LabelFilter = "integration-service"
 		klog.Info("setting 'integration-service' test label")
-
-		if rctx.DryRun {
+		return nil
+	}), rulesengine.SimulatedAction{
+		Action: func(rctx *rulesengine.RuleCtx) error {
+			rctx.ComponentEnvVarPrefix = "INTEGRATION_SERVICE"
+			// TODO keep only "KONFLUX_CI" option once we migrate off openshift-ci
+			if os.Getenv("KONFLUX_CI") != "true" {
+				rctx.ComponentImageTag = "redhat-appstudio-integration-service-image"
+			}
+			return SetEnvVarsForComponentImageDeployment(rctx)
+		},
+		DryRun: func(rctx *rulesengine.RuleCtx) error {
 			klog.Info("setting up env vars for deploying component image")
 			return nil
-		}
-		rctx.ComponentEnvVarPrefix = "INTEGRATION_SERVICE"
-		// TODO keep only "KONFLUX_CI" option once we migrate off openshift-ci
-		if os.Getenv("KONFLUX_CI") != "true" {
-			rctx.ComponentImageTag = "redhat-appstudio-integration-service-image"
-		}
-		return SetEnvVarsForComponentImageDeployment(rctx)
-	})},
+		},
+	}},
 }
 
 var IsIntegrationServiceRepoPR = rulesengine.ConditionFunc(func(rctx *rulesengine.RuleCtx) (bool, error) {
@@ -18,7 +18,8 @@ var ReleaseServiceCIRule = rulesengine.Rule{Name: "Release-service repo CI Workf
 		&PreflightInstallGinkgoRule,
 		rulesengine.Any{rulesengine.None{&InstallKonfluxRule}, &InstallKonfluxRule},
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(ExecuteTestAction)},
+	Actions:   []rulesengine.Action{TestAction},
+	Exclusive: true,
 }
 
 var ReleaseServiceRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General Required Settings for release-service repository jobs",
@@ -26,24 +27,27 @@ var ReleaseServiceRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General R
 	Condition: rulesengine.Any{
 		IsReleaseServiceRepoPR,
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 		rctx.LabelFilter = "release-service"
 		klog.Info("setting 'release-service' test label")
-
-		if rctx.DryRun {
+		return nil
+	}), rulesengine.SimulatedAction{
+		Action: func(rctx *rulesengine.RuleCtx) error {
+			rctx.ComponentEnvVarPrefix = "RELEASE_SERVICE"
+			// TODO keep only "KONFLUX_CI" option once we migrate off openshift-ci
+
+			if os.Getenv("KONFLUX_CI") != "true" {
+				rctx.ComponentImageTag = "redhat-appstudio-release-service-image"
+			}
+			//This is env variable is specified for release service
+			os.Setenv(fmt.Sprintf("%s_CATALOG_REVISION", rctx.ComponentEnvVarPrefix), "development")
+			return SetEnvVarsForComponentImageDeployment(rctx)
+		},
+		DryRun: func(rctx *rulesengine.RuleCtx) error {
 			klog.Info("setting up env vars for deploying component image")
 			return nil
-		}
-		rctx.ComponentEnvVarPrefix = "RELEASE_SERVICE"
-		// TODO keep only "KONFLUX_CI" option once we migrate off openshift-ci
-
-		if os.Getenv("KONFLUX_CI") != "true" {
-			rctx.ComponentImageTag = "redhat-appstudio-release-service-image"
-		}
-		//This is env variable is specified for release service
-		os.Setenv(fmt.Sprintf("%s_CATALOG_REVISION", rctx.ComponentEnvVarPrefix), "development")
-		return SetEnvVarsForComponentImageDeployment(rctx)
-	})},
+		},
+	}},
 }
 
 var IsReleaseServiceRepoPR = rulesengine.ConditionFunc(func(rctx *rulesengine.RuleCtx) (bool, error) {
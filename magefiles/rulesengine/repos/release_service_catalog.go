@@ -16,6 +16,8 @@ var ReleaseServiceCatalogCICatalog = rulesengine.RuleCatalog{ReleaseServiceCatal
 var ReleaseServiceCatalogCIPairedRule = rulesengine.Rule{Name: "Release-service-catalog repo CI Workflow Paired Rule",
 	Description: "Execute the Paired workflow for release-service-catalog repo in CI",
 	Condition: rulesengine.All{
+		// checked first, so that GitHub isn't asked about the pairing of the PRs of other repositories
+		IsReleaseServiceCatalogRepoPR,
 		rulesengine.ConditionFunc(isPaired),
 		rulesengine.None{
 			rulesengine.ConditionFunc(isRehearse),
@@ -25,12 +27,14 @@ var ReleaseServiceCatalogCIPairedRule = rulesengine.Rule{Name: "Release-service-
 		&PreflightInstallGinkgoRule,
 		rulesengine.Any{rulesengine.None{&InstallKonfluxRule}, &InstallKonfluxRule},
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(ExecuteReleaseCatalogPairedAction)},
+	Actions:   []rulesengine.Action{rulesengine.CtxActionFunc(SelectReleaseCatalogPairedTests), TestAction},
+	Exclusive: true,
 }
 
 var ReleaseServiceCatalogCIRule = rulesengine.Rule{Name: "Release-service-catalog repo CI Workflow Rule",
 	Description: "Execute the full workflow for release-service-catalog repo in CI",
 	Condition: rulesengine.All{
+		IsReleaseServiceCatalogRepoPR,
 		rulesengine.Any{
 			rulesengine.None{rulesengine.ConditionFunc(isPaired)},
 			rulesengine.ConditionFunc(isRehearse),
@@ -40,7 +44,8 @@ var ReleaseServiceCatalogCIRule = rulesengine.Rule{Name: "Release-service-catalo
 		&PreflightInstallGinkgoRule,
 		rulesengine.Any{rulesengine.None{&InstallKonfluxRule}, &InstallKonfluxRule},
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(ExecuteReleaseCatalogAction)},
+	Actions:   []rulesengine.Action{rulesengine.CtxActionFunc(SelectReleaseCatalogTests), TestAction},
+	Exclusive: true,
 }
 
 var ReleaseServiceCatalogRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General Required Settings for release-service-catalog repository jobs",
@@ -48,10 +53,10 @@ var ReleaseServiceCatalogRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "Ge
 	Condition: rulesengine.Any{
 		IsReleaseServiceCatalogRepoPR,
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
 		// The job may come from different ITS
 		if strings.Contains(rctx.JobName, "konflux-e2e-tests-catalog") {
-			testcases, err := selectReleasePipelinesTestCases(rctx.PrNum)
+			testcases, err := selectReleasePipelines(rctx.PrNum)
 			if err != nil {
 				rctx.LabelFilter = "release-pipelines"
 				klog.Errorf("an error occurred in selectReleasePipelinesTestCases: %s", err)
@@ -68,36 +73,39 @@ var ReleaseServiceCatalogRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "Ge
 			}
 		}
 		klog.Info("setting test label for release-pipelines: ", rctx.LabelFilter)
+		return nil
+	}), rulesengine.SimulatedAction{
+		Action: func(rctx *rulesengine.RuleCtx) error {
+			rctx.ComponentEnvVarPrefix = "RELEASE_SERVICE"
+
+			//This is env variable is specified for release service catalog
+			os.Setenv(fmt.Sprintf("%s_CATALOG_URL", rctx.ComponentEnvVarPrefix), fmt.Sprintf("https://github.com/%s/%s", rctx.PrRemoteName, rctx.RepoName))
+			if rctx.PrRemoteName == "konflux-ci" {
+				os.Setenv(fmt.Sprintf("%s_CATALOG_REVISION", rctx.ComponentEnvVarPrefix), rctx.PrBranchName)
+			} else {
+				os.Setenv(fmt.Sprintf("%s_CATALOG_REVISION", rctx.ComponentEnvVarPrefix), rctx.PrCommitSha)
+			}
 
-		if rctx.DryRun {
+			// Failed at https://github.com/redhat-appstudio/infra-deployments/blob/2228e063a7fd8af4a95b24bb13ce7360cdc229f0/hack/preview.sh#L293C16-L293C38
+			//os.Setenv("DEPLOY_ONLY", "application-api dev-sso enterprise-contract has pipeline-service integration internal-services release")
+
+			if rctx.IsPaired && !strings.Contains(rctx.JobName, "rehearse") {
+				os.Setenv(fmt.Sprintf("%s_IMAGE_REPO", rctx.ComponentEnvVarPrefix),
+					"quay.io/redhat-user-workloads/rhtap-release-2-tenant/release-service/release-service")
+				pairedSha := GetPairedCommitSha("release-service", rctx)
+				if pairedSha != "" {
+					os.Setenv(fmt.Sprintf("%s_IMAGE_TAG", rctx.ComponentEnvVarPrefix), fmt.Sprintf("on-pr-%s", pairedSha))
+				}
+				os.Setenv(fmt.Sprintf("%s_PR_OWNER", rctx.ComponentEnvVarPrefix), rctx.PrRemoteName)
+				os.Setenv(fmt.Sprintf("%s_PR_SHA", rctx.ComponentEnvVarPrefix), pairedSha)
+			}
+			return nil
+		},
+		DryRun: func(rctx *rulesengine.RuleCtx) error {
 			klog.Info("setting up env vars for deploying component image")
 			return nil
-		}
-		rctx.ComponentEnvVarPrefix = "RELEASE_SERVICE"
-
-		//This is env variable is specified for release service catalog
-		os.Setenv(fmt.Sprintf("%s_CATALOG_URL", rctx.ComponentEnvVarPrefix), fmt.Sprintf("https://github.com/%s/%s", rctx.PrRemoteName, rctx.RepoName))
-		if rctx.PrRemoteName == "konflux-ci" {
-			os.Setenv(fmt.Sprintf("%s_CATALOG_REVISION", rctx.ComponentEnvVarPrefix), rctx.PrBranchName)
-		} else {
-			os.Setenv(fmt.Sprintf("%s_CATALOG_REVISION", rctx.ComponentEnvVarPrefix), rctx.PrCommitSha)
-		}
-
-		// Failed at https://github.com/redhat-appstudio/infra-deployments/blob/2228e063a7fd8af4a95b24bb13ce7360cdc229f0/hack/preview.sh#L293C16-L293C38
-		//os.Setenv("DEPLOY_ONLY", "application-api dev-sso enterprise-contract has pipeline-service integration internal-services release")
-
-		if rctx.IsPaired && !strings.Contains(rctx.JobName, "rehearse") {
-			os.Setenv(fmt.Sprintf("%s_IMAGE_REPO", rctx.ComponentEnvVarPrefix),
-				"quay.io/redhat-user-workloads/rhtap-release-2-tenant/release-service/release-service")
-			pairedSha := GetPairedCommitSha("release-service", rctx)
-			if pairedSha != "" {
-				os.Setenv(fmt.Sprintf("%s_IMAGE_TAG", rctx.ComponentEnvVarPrefix), fmt.Sprintf("on-pr-%s", pairedSha))
-			}
-			os.Setenv(fmt.Sprintf("%s_PR_OWNER", rctx.ComponentEnvVarPrefix), rctx.PrRemoteName)
-			os.Setenv(fmt.Sprintf("%s_PR_SHA", rctx.ComponentEnvVarPrefix), pairedSha)
-		}
-		return nil
-	})},
+		},
+	}},
 }
 
 var IsReleaseServiceCatalogRepoPR = rulesengine.ConditionFunc(func(rctx *rulesengine.RuleCtx) (bool, error) {
@@ -111,18 +119,18 @@ var isRehearse = func(rctx *rulesengine.RuleCtx) (bool, error) {
 }
 
 var isPaired = func(rctx *rulesengine.RuleCtx) (bool, error) {
-	rctx.IsPaired = IsPRPairingRequired("release-service", rctx.PrRemoteName, rctx.PrBranchName)
+	rctx.IsPaired = isPRPairingRequired("release-service", rctx.PrRemoteName, rctx.PrBranchName)
 	return rctx.IsPaired, nil
 }
 
-func ExecuteReleaseCatalogPairedAction(rctx *rulesengine.RuleCtx) error {
+func SelectReleaseCatalogPairedTests(rctx *rulesengine.RuleCtx) error {
 	rctx.LabelFilter += " && !fbc-release && !multiarch-advisories && !rh-advisories && !release-to-github && !rh-push-to-registry-redhat-io && !rhtap-service-push"
-	return ExecuteTestAction(rctx)
+	return nil
 }
 
-func ExecuteReleaseCatalogAction(rctx *rulesengine.RuleCtx) error {
+func SelectReleaseCatalogTests(rctx *rulesengine.RuleCtx) error {
 	rctx.Timeout = 2*time.Hour + 30*time.Minute
-	return ExecuteTestAction(rctx)
+	return nil
 }
 
 func selectReleasePipelinesTestCases(prNum int) (string, error) {
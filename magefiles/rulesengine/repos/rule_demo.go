@@ -74,7 +74,8 @@ var installGinkgo = func(rctx *rulesengine.RuleCtx) error {
 var preflight_check_rule = rulesengine.Rule{Name: "Bootstrap a Cluster",
 	Description: "Boostrap the cluster when the envroniment has all the pre-req environment variables/tools installed.",
 	Condition:   rulesengine.ConditionFunc(isPreflightCheck),
-	Actions:     []rulesengine.Action{rulesengine.ActionFunc(installGinkgo), rulesengine.ActionFunc(bootstrapCluster)},
+	// the demo actions only log what they would do, so they are run in dry run mode as well
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(installGinkgo), rulesengine.CtxActionFunc(bootstrapCluster)},
 }
 
 //Demo of what the magefile, func (Local) PrepareCluster() AND func (Local) TestE2E(), would look like as a RuleChain in the rule framework
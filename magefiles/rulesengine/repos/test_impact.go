@@ -0,0 +1,423 @@
+package repos
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/konflux-ci/e2e-tests/magefiles/rulesengine"
+	"github.com/magefile/mage/sh"
+	"k8s.io/klog"
+)
+
+// Test suite packages that are not run on e2e-tests PRs by default (see SelectDefaultTests),
+// so they are never selected by the pkg/ change impact analysis
+var pkgImpactExcludedSuites = []string{"tests/upgrade", "tests/release/pipelines"}
+
+const pkgImpactedTestFilesKey = "pkgImpactedTestFiles"
+
+type goFileInfo struct {
+	path     string
+	pkg      string
+	imports  map[string]bool
+	declared []string
+	// lines spanned by each declared identifier
+	spans map[string]LineRange
+	used  map[string]bool
+}
+
+// LineRange is an inclusive range of line numbers of a file
+type LineRange struct {
+	From int
+	To   int
+}
+
+func (lr LineRange) overlaps(other LineRange) bool {
+
+	return lr.From <= other.To && other.From <= lr.To
+}
+
+// FindImpactedTestFiles returns the test suite files under tests/ that reference, directly or through
+// other packages, the identifiers declared in the Go files changed under pkg/.
+// The impact is propagated by identifier names: a file is impacted when its package is, or transitively imports,
+// the package of an impacted file and it uses one of the identifiers declared in it. Checking the transitive
+// imports catches methods called through struct fields, like the controllers of the framework.
+// Name clashes can only widen the result.
+// When the changed lines of a file are known, only the identifiers whose declaration overlaps them are
+// considered changed, otherwise all the identifiers declared in the file are.
+// An error is returned when the impact can't be determined, i.e. a non Go file or a deleted file.
+func FindImpactedTestFiles(root string, changed rulesengine.Files, changedLines map[string][]LineRange) ([]string, error) {
+
+	module, err := readModulePath(root)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]*goFileInfo{}
+	for _, dir := range []string{"pkg", "tests"} {
+		if err := parseGoFiles(root, dir, module, files); err != nil {
+			return nil, err
+		}
+	}
+
+	suites, err := findTestSuitePackages(root, module)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := newPackageDeps(files)
+	impacted := map[string]bool{}
+	var queue []*goFileInfo
+	for _, file := range changed.FilterByDirGlob("pkg/**") {
+		if strings.HasSuffix(file.Name, "_test.go") {
+			continue
+		}
+		if !strings.HasSuffix(file.Name, ".go") {
+			return nil, fmt.Errorf("cannot determine the impact of the non Go file %s", file.Name)
+		}
+		info, ok := files[file.Name]
+		if !ok || strings.HasPrefix(file.Status, "D") {
+			return nil, fmt.Errorf("cannot determine the impact of the removed or renamed file %s", file.Name)
+		}
+		impacted[info.path] = true
+		queue = append(queue, info.changedDeclarations(changedLines[file.Name]))
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, f := range files {
+			if impacted[f.path] || !deps.dependsOn(f.pkg, current.pkg) {
+				continue
+			}
+			for _, name := range current.declared {
+				if f.used[name] {
+					impacted[f.path] = true
+					queue = append(queue, f)
+					break
+				}
+			}
+		}
+	}
+
+	var testFiles []string
+	for p := range impacted {
+		if suites[files[p].pkg] {
+			testFiles = append(testFiles, p)
+		}
+	}
+	sort.Strings(testFiles)
+
+	return testFiles, nil
+}
+
+// changedDeclarations returns a copy of the file info restricted to the identifiers declared in the changed lines
+func (f *goFileInfo) changedDeclarations(lines []LineRange) *goFileInfo {
+
+	if lines == nil {
+		return f
+	}
+
+	changed := *f
+	changed.declared = nil
+	for _, name := range f.declared {
+		for _, l := range lines {
+			if f.spans[name].overlaps(l) {
+				changed.declared = append(changed.declared, name)
+				break
+			}
+		}
+	}
+
+	return &changed
+}
+
+// CheckPkgFilesChangedWithUnknownImpact is true when files under pkg/ changed and
+// the test suites they impact can't be determined, so all of them have to run.
+// No impacted test suite is treated the same way, so that a pkg/ change is always tested.
+func CheckPkgFilesChangedWithUnknownImpact(rctx *rulesengine.RuleCtx) (bool, error) {
+
+	if ok, _ := CheckPkgFilesChanged(rctx); !ok {
+		return false, nil
+	}
+	files, err := getPkgImpactedTestFiles(rctx)
+
+	return err != nil || len(files) == 0, nil
+}
+
+var PkgFilesImpactedTestsRule = rulesengine.Rule{Name: "E2E PR Pkg Files Impacted Tests Rule",
+	Description: "Map the test files that use, directly or transitively, the Go code changed under pkg/ in the e2e-repo PR",
+	Condition: rulesengine.ConditionFunc(func(rctx *rulesengine.RuleCtx) (bool, error) {
+
+		if ok, _ := CheckPkgFilesChanged(rctx); !ok {
+			return false, nil
+		}
+		files, err := getPkgImpactedTestFiles(rctx)
+
+		return err == nil && len(files) != 0, nil
+	}),
+	Actions: []rulesengine.Action{rulesengine.CtxActionFunc(func(rctx *rulesengine.RuleCtx) error {
+
+		files, err := getPkgImpactedTestFiles(rctx)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			rctx.FocusFiles = dedupeAppendFiles(rctx.FocusFiles, file)
+		}
+
+		return nil
+	})}}
+
+// getPkgImpactedTestFiles runs the impact analysis once per RuleCtx and caches the result in its rule data
+func getPkgImpactedTestFiles(rctx *rulesengine.RuleCtx) ([]string, error) {
+
+	if cached, ok := rctx.GetRuleData(pkgImpactedTestFilesKey).(pkgImpactResult); ok {
+		return cached.files, cached.err
+	}
+
+	changedLines := map[string][]LineRange{}
+	for _, file := range rctx.DiffFiles.FilterByDirGlob("pkg/**/*.go") {
+		lines, err := getChangedLines(file.Name)
+		if err != nil {
+			klog.Infof("cannot get the changed lines of %s, all its declarations are considered changed: %v", file.Name, err)
+			continue
+		}
+		changedLines[file.Name] = lines
+	}
+
+	files, err := findImpactedTestFiles(".", rctx.DiffFiles, changedLines)
+	if err != nil {
+		klog.Infof("cannot determine the test suites impacted by the pkg/ changes, all suites will run: %v", err)
+	} else {
+		klog.Infof("test files impacted by the pkg/ changes: %s", strings.Join(files, ", "))
+	}
+	_ = rctx.AddRuleData(pkgImpactedTestFilesKey, pkgImpactResult{files, err})
+
+	return files, err
+}
+
+type pkgImpactResult struct {
+	files []string
+	err   error
+}
+
+// packageDeps resolves the transitive imports between the parsed packages
+type packageDeps struct {
+	imports map[string]map[string]bool
+	closure map[string]map[string]bool
+}
+
+func newPackageDeps(files map[string]*goFileInfo) *packageDeps {
+
+	d := &packageDeps{imports: map[string]map[string]bool{}, closure: map[string]map[string]bool{}}
+	for _, f := range files {
+		if d.imports[f.pkg] == nil {
+			d.imports[f.pkg] = map[string]bool{}
+		}
+		for imp := range f.imports {
+			d.imports[f.pkg][imp] = true
+		}
+	}
+
+	return d
+}
+
+// dependsOn is true when pkg is target or imports it, directly or transitively
+func (d *packageDeps) dependsOn(pkg, target string) bool {
+
+	return pkg == target || d.transitiveImports(pkg)[target]
+}
+
+func (d *packageDeps) transitiveImports(pkg string) map[string]bool {
+
+	if c, ok := d.closure[pkg]; ok {
+		return c
+	}
+	c := map[string]bool{}
+	// guards against import cycles, which can't happen in valid code anyway
+	d.closure[pkg] = c
+	for imp := range d.imports[pkg] {
+		if _, local := d.imports[imp]; !local {
+			continue
+		}
+		c[imp] = true
+		for t := range d.transitiveImports(imp) {
+			c[t] = true
+		}
+	}
+
+	return c
+}
+
+// GetChangedLines returns the lines of the file changed against upstream/main, parsed from the git diff hunks
+func GetChangedLines(file string) ([]LineRange, error) {
+
+	output, err := sh.Output("git", "diff", "-U0", "upstream/main..HEAD", "--", file)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseDiffHunks(output)
+}
+
+// parseDiffHunks returns the lines of the new version of a file covered by the hunks of its unified diff
+func parseDiffHunks(output string) ([]LineRange, error) {
+
+	lines := []LineRange{}
+	for _, line := range strings.Split(output, "\n") {
+		// i.e. "@@ -10,2 +10,3 @@ func foo() {"
+		if !strings.HasPrefix(line, "@@ ") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") {
+			continue
+		}
+		start, count := strings.TrimPrefix(fields[2], "+"), "1"
+		if i := strings.Index(start, ","); i != -1 {
+			start, count = start[:i], start[i+1:]
+		}
+		from, err := strconv.Atoi(start)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the diff hunk %q: %+v", line, err)
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the diff hunk %q: %+v", line, err)
+		}
+		if n == 0 {
+			// pure deletion, the lines around it are the ones affected
+			lines = append(lines, LineRange{From: from, To: from + 1})
+			continue
+		}
+		lines = append(lines, LineRange{From: from, To: from + n - 1})
+	}
+
+	return lines, nil
+}
+
+func readModulePath(root string) (string, error) {
+
+	f, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module ")), nil
+		}
+	}
+
+	return "", fmt.Errorf("no module declared in %s/go.mod", root)
+}
+
+func parseGoFiles(root, dir, module string, files map[string]*goFileInfo) error {
+
+	fset := token.NewFileSet()
+
+	return filepath.WalkDir(filepath.Join(root, dir), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "testdata" || d.Name() == "vendor" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(p, ".go") || strings.HasSuffix(p, "_test.go") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		parsed, err := parser.ParseFile(fset, p, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %+v", rel, err)
+		}
+
+		info := &goFileInfo{path: rel, pkg: path.Join(module, path.Dir(rel)), imports: map[string]bool{}, spans: map[string]LineRange{}, used: map[string]bool{}}
+		declare := func(name string, node ast.Node) {
+			info.declared = append(info.declared, name)
+			info.spans[name] = LineRange{From: fset.Position(node.Pos()).Line, To: fset.Position(node.End()).Line}
+		}
+		for _, imp := range parsed.Imports {
+			if importPath, err := strconv.Unquote(imp.Path.Value); err == nil {
+				info.imports[importPath] = true
+			}
+		}
+		for _, decl := range parsed.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				declare(decl.Name.Name, decl)
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						declare(spec.Name.Name, spec)
+					case *ast.ValueSpec:
+						for _, n := range spec.Names {
+							if n.Name != "_" {
+								declare(n.Name, spec)
+							}
+						}
+					}
+				}
+			}
+		}
+		ast.Inspect(parsed, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok {
+				info.used[ident.Name] = true
+			}
+			return true
+		})
+		files[rel] = info
+
+		return nil
+	})
+}
+
+// findTestSuitePackages returns the test suite packages run by the e2e test binary in cmd/
+func findTestSuitePackages(root, module string) (map[string]bool, error) {
+
+	suites := map[string]bool{}
+	fset := token.NewFileSet()
+	matches, err := filepath.Glob(filepath.Join(root, "cmd", "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range matches {
+		parsed, err := parser.ParseFile(fset, m, nil, parser.ImportsOnly)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %+v", m, err)
+		}
+		for _, imp := range parsed.Imports {
+			importPath, err := strconv.Unquote(imp.Path.Value)
+			if err != nil || !strings.HasPrefix(importPath, module+"/tests/") {
+				continue
+			}
+			suites[importPath] = true
+		}
+	}
+	for _, excluded := range pkgImpactExcludedSuites {
+		delete(suites, path.Join(module, excluded))
+	}
+
+	return suites, nil
+}
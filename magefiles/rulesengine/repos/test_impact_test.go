@@ -0,0 +1,47 @@
+package repos
+
+import (
+	"testing"
+
+	"github.com/konflux-ci/e2e-tests/magefiles/rulesengine"
+	"github.com/stretchr/testify/assert"
+)
+
+// impactTestRoot is a small repository the impact analysis runs against, relative to the
+// e2e-tests repository root the tests run from (see TestMain)
+const impactTestRoot = "magefiles/rulesengine/repos/testdata/impact"
+
+func TestFindImpactedTestFiles(t *testing.T) {
+	root := impactTestRoot
+	plans := rulesengine.Files{{Status: "M", Name: "pkg/clients/release/plans.go"}}
+
+	files, err := FindImpactedTestFiles(root, plans, nil)
+	assert.NoError(t, err)
+	// release pipelines are excluded, like with the default label filter
+	assert.Equal(t, []string{"tests/build/build.go", "tests/release/service/service.go"}, files)
+
+	// only CreatePlan is changed
+	files, err = FindImpactedTestFiles(root, plans, map[string][]LineRange{"pkg/clients/release/plans.go": {{From: 5, To: 5}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tests/release/service/service.go"}, files)
+
+	_, err = FindImpactedTestFiles(root, rulesengine.Files{{Status: "M", Name: "pkg/clients/release/templates/plan.yaml"}}, nil)
+	assert.Error(t, err)
+
+	_, err = FindImpactedTestFiles(root, rulesengine.Files{{Status: "D", Name: "pkg/clients/release/removed.go"}}, nil)
+	assert.Error(t, err)
+}
+
+func TestParseDiffHunks(t *testing.T) {
+	diff := `diff --git a/pkg/clients/release/plans.go b/pkg/clients/release/plans.go
+index 1111111..2222222 100644
+--- a/pkg/clients/release/plans.go
++++ b/pkg/clients/release/plans.go
+@@ -20 +20 @@ func (r *ReleaseController) CreateReleasePlan(
+@@ -40,0 +41,3 @@ func (r *ReleaseController) CreateReleasePlan(
+@@ -90,2 +93,0 @@ func (r *ReleaseController) GetReleasePlanAdmission(
+`
+	lines, err := parseDiffHunks(diff)
+	assert.NoError(t, err)
+	assert.Equal(t, []LineRange{{From: 20, To: 20}, {From: 41, To: 43}, {From: 93, To: 94}}, lines)
+}
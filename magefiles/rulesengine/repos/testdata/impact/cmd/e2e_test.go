@@ -0,0 +1,7 @@
+package cmd
+
+import (
+	_ "example.com/e2e/tests/build"
+	_ "example.com/e2e/tests/release/pipelines"
+	_ "example.com/e2e/tests/release/service"
+)
@@ -0,0 +1,7 @@
+package release
+
+type Controller struct{}
+
+func (c *Controller) CreatePlan() {}
+
+func (c *Controller) CreateAdmission() {}
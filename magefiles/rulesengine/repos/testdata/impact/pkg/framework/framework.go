@@ -0,0 +1,7 @@
+package framework
+
+import "example.com/e2e/pkg/clients/release"
+
+type Framework struct {
+	Release *release.Controller
+}
@@ -0,0 +1,7 @@
+package release
+
+import "example.com/e2e/pkg/framework"
+
+func SetupPlan(f *framework.Framework) {
+	f.Release.CreatePlan()
+}
@@ -0,0 +1,5 @@
+package build
+
+import "example.com/e2e/pkg/framework"
+
+var _ = func(f *framework.Framework) { f.Release.CreateAdmission() }
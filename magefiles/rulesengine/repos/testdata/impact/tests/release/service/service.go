@@ -0,0 +1,8 @@
+package service
+
+import (
+	"example.com/e2e/pkg/framework"
+	"example.com/e2e/pkg/utils/release"
+)
+
+var _ = func(f *framework.Framework) { release.SetupPlan(f) }
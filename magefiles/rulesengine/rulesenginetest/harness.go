@@ -0,0 +1,79 @@
+// Package rulesenginetest provides a table-driven harness to test the rule catalogs of the rules engine
+package rulesenginetest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konflux-ci/e2e-tests/magefiles/rulesengine"
+	"github.com/stretchr/testify/assert"
+)
+
+// CatalogTestCase describes the expected outcome of running the rules of a
+// category/catalog for a synthetic job (repo, job type and changed files).
+type CatalogTestCase struct {
+	Name      string
+	RepoName  string
+	JobName   string
+	JobType   string
+	EventType string
+	DiffFiles rulesengine.Files
+	// Setup allows to set any other RuleCtx field before the rules are run
+	Setup func(rctx *rulesengine.RuleCtx)
+
+	// ExpectedRules are the rules expected to be applied, in order. Not checked when nil.
+	ExpectedRules       []string
+	ExpectedLabelFilter string
+	ExpectedFocusFiles  []string
+	// ExpectedEnvVars have to be set with the given values, other env vars are ignored
+	ExpectedEnvVars map[string]string
+	// ExpectedCommands are the prefixes of the commands expected to be run, in order. Not checked when nil.
+	ExpectedCommands []string
+	ExpectError      bool
+}
+
+// RunCatalogTestCases runs every test case against the rules selected by args (see RunRules).
+// The rules are run with Plan, so actions only record their side effects instead of executing them.
+func RunCatalogTestCases(t *testing.T, e rulesengine.RuleEngine, args []string, cases []CatalogTestCase) {
+
+	t.Helper()
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			rctx := rulesengine.NewRuleCtx()
+			rctx.RepoName = tc.RepoName
+			rctx.JobName = tc.JobName
+			rctx.JobType = tc.JobType
+			rctx.TektonEventType = tc.EventType
+			rctx.DiffFiles = tc.DiffFiles
+			if tc.Setup != nil {
+				tc.Setup(rctx)
+			}
+
+			plan, err := e.Plan(rctx, args...)
+			if tc.ExpectError {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			if tc.ExpectedRules != nil && len(tc.ExpectedRules)+len(plan.AppliedRules) != 0 {
+				assert.Equal(t, tc.ExpectedRules, plan.AppliedRules, "applied rules")
+			}
+			assert.Equal(t, tc.ExpectedLabelFilter, plan.LabelFilter, "label filter")
+			assert.ElementsMatch(t, tc.ExpectedFocusFiles, plan.FocusFiles, "focus files")
+			for name, value := range tc.ExpectedEnvVars {
+				assert.Equal(t, value, plan.EnvVars[name], "env var %s", name)
+			}
+			if tc.ExpectedCommands != nil {
+				if assert.Len(t, plan.Commands, len(tc.ExpectedCommands), "commands: %v", plan.Commands) {
+					for i, prefix := range tc.ExpectedCommands {
+						assert.True(t, strings.HasPrefix(plan.Commands[i], prefix), "command %q doesn't start with %q", plan.Commands[i], prefix)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package rulesengine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// TraceNode records the evaluation of a single Conditional or the execution of an Action
+type TraceNode struct {
+	Kind      string       `json:"kind"`
+	Name      string       `json:"name,omitempty"`
+	Result    *bool        `json:"result,omitempty"`
+	Error     string       `json:"error,omitempty"`
+	Mutations []Mutation   `json:"mutations,omitempty"`
+	Children  []*TraceNode `json:"children,omitempty"`
+}
+
+// Mutation describes a change of a RuleCtx field made by an action
+type Mutation struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// EvalTrace collects the evaluation tree of the rules run by the engine.
+// Tracing is enabled by setting a non nil EvalTrace in RuleCtx.Trace.
+type EvalTrace struct {
+	Nodes []*TraceNode `json:"nodes"`
+	stack []*TraceNode
+}
+
+func NewEvalTrace() *EvalTrace {
+
+	return &EvalTrace{}
+}
+
+func (t *EvalTrace) enter(kind, name string) *TraceNode {
+
+	node := &TraceNode{Kind: kind, Name: name}
+	if len(t.stack) == 0 {
+		t.Nodes = append(t.Nodes, node)
+	} else {
+		parent := t.stack[len(t.stack)-1]
+		parent.Children = append(parent.Children, node)
+	}
+	t.stack = append(t.stack, node)
+
+	return node
+}
+
+func (t *EvalTrace) exit(node *TraceNode, err error) {
+
+	if err != nil {
+		node.Error = err.Error()
+	}
+	t.stack = t.stack[:len(t.stack)-1]
+}
+
+// String renders the trace as an indented tree
+func (t *EvalTrace) String() string {
+
+	var sb strings.Builder
+	for _, node := range t.Nodes {
+		node.write(&sb, 0)
+	}
+
+	return sb.String()
+}
+
+func (n *TraceNode) write(sb *strings.Builder, depth int) {
+
+	sb.WriteString(strings.Repeat("  ", depth))
+	sb.WriteString(n.Kind)
+	if n.Name != "" {
+		fmt.Fprintf(sb, " %q", n.Name)
+	}
+	if n.Result != nil {
+		fmt.Fprintf(sb, " => %t", *n.Result)
+	}
+	if n.Error != "" {
+		fmt.Fprintf(sb, " (error: %s)", n.Error)
+	}
+	sb.WriteString("\n")
+	for _, m := range n.Mutations {
+		fmt.Fprintf(sb, "%s  ~ %s: %q -> %q\n", strings.Repeat("  ", depth), m.Field, m.Before, m.After)
+	}
+	for _, child := range n.Children {
+		child.write(sb, depth+1)
+	}
+}
+
+// JSON renders the trace as indented JSON
+func (t *EvalTrace) JSON() ([]byte, error) {
+
+	return json.MarshalIndent(t, "", "  ")
+}
+
+// WriteJSON writes the trace as JSON to the given file
+func (t *EvalTrace) WriteJSON(path string) error {
+
+	data, err := t.JSON()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// traceCheck evaluates the check and records it in the trace when tracing is enabled
+func (gca *RuleCtx) traceCheck(kind, name string, check func() (bool, error)) (bool, error) {
+
+	if gca.Trace == nil {
+		return check()
+	}
+
+	node := gca.Trace.enter(kind, name)
+	ok, err := check()
+	node.Result = &ok
+	gca.Trace.exit(node, err)
+
+	return ok, err
+}
+
+// traceAction executes the action and records it, along with the RuleCtx fields it changed, in the trace
+func (gca *RuleCtx) traceAction(action Action) error {
+
+	return gca.traceRun(action, action.Execute)
+}
+
+// traceRun runs the given function of the action (i.e. Execute or Simulate) and records it in the trace
+func (gca *RuleCtx) traceRun(action Action, run func(rctx *RuleCtx) error) error {
+
+	if gca.Trace == nil {
+		return run(gca)
+	}
+
+	node := gca.Trace.enter("action", funcName(action))
+	before := gca.snapshot()
+	err := run(gca)
+	after := gca.snapshot()
+	for _, field := range snapshotFields {
+		if before[field] != after[field] {
+			node.Mutations = append(node.Mutations, Mutation{Field: field, Before: before[field], After: after[field]})
+		}
+	}
+	gca.Trace.exit(node, err)
+
+	return err
+}
+
+func (gca *RuleCtx) traceEnter(kind, name string) func(err error) {
+
+	if gca.Trace == nil {
+		return func(error) {}
+	}
+
+	node := gca.Trace.enter(kind, name)
+	return func(err error) { gca.Trace.exit(node, err) }
+}
+
+var snapshotFields = []string{"LabelFilter", "FocusFiles", "Timeout", "DiffFiles", "IsPaired",
+	"RequiresMultiPlatformTests", "RequiresSprayProxyRegistering", "ComponentEnvVarPrefix", "ComponentImageTag"}
+
+func (gca *RuleCtx) snapshot() map[string]string {
+
+	return map[string]string{
+		"LabelFilter":                   gca.LabelFilter,
+		"FocusFiles":                    strings.Join(gca.FocusFiles, ","),
+		"Timeout":                       gca.Timeout.String(),
+		"DiffFiles":                     gca.DiffFiles.String(),
+		"IsPaired":                      fmt.Sprint(gca.IsPaired),
+		"RequiresMultiPlatformTests":    fmt.Sprint(gca.RequiresMultiPlatformTests),
+		"RequiresSprayProxyRegistering": fmt.Sprint(gca.RequiresSprayProxyRegistering),
+		"ComponentEnvVarPrefix":         gca.ComponentEnvVarPrefix,
+		"ComponentImageTag":             gca.ComponentImageTag,
+	}
+}
+
+// funcName returns a readable name for conditionals and actions implemented as functions
+func funcName(v any) string {
+
+	if sa, ok := v.(SimulatedAction); ok {
+		return funcName(sa.Action)
+	}
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Func {
+		return fmt.Sprintf("%T", v)
+	}
+	if fn := runtime.FuncForPC(value.Pointer()); fn != nil {
+		name := fn.Name()
+		// strip the package path, i.e. github.com/konflux-ci/e2e-tests/magefiles/rulesengine/repos.CheckPkgFilesChanged
+		return name[strings.LastIndex(name, "/")+1:]
+	}
+
+	return fmt.Sprintf("%T", v)
+}
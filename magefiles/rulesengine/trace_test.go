@@ -0,0 +1,47 @@
+package rulesengine
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvalTrace(t *testing.T) {
+	labelRule := Rule{Name: "label rule",
+		Condition: ConditionFunc(func(rctx *RuleCtx) (bool, error) { return true, nil }),
+		Actions: []Action{ActionFunc(func(rctx *RuleCtx) error {
+			rctx.AddLabelToLabelFilter("build")
+			return nil
+		})}}
+	chain := Rule{Name: "chain",
+		Condition: All{&labelRule, None{ConditionFunc(func(rctx *RuleCtx) (bool, error) { return false, nil })}},
+	}
+
+	e := RuleEngine{"tests": {"repo": RuleCatalog{chain}}}
+	rctx := NewRuleCtx()
+	rctx.Trace = NewEvalTrace()
+	assert.NoError(t, e.RunRules(rctx, "tests", "repo"))
+
+	assert.Len(t, rctx.Trace.Nodes, 1)
+	root := rctx.Trace.Nodes[0]
+	assert.Equal(t, "rule", root.Kind)
+	assert.Equal(t, "chain", root.Name)
+	assert.True(t, *root.Result)
+
+	all := root.Children[0]
+	assert.Equal(t, "all", all.Kind)
+	assert.Len(t, all.Children, 2)
+	nested := all.Children[0]
+	assert.Equal(t, "label rule", nested.Name)
+	action := nested.Children[len(nested.Children)-1]
+	assert.Equal(t, "action", action.Kind)
+	assert.Equal(t, []Mutation{{Field: "LabelFilter", Before: "", After: "build"}}, action.Mutations)
+	assert.False(t, *all.Children[1].Children[0].Result)
+
+	assert.True(t, strings.Contains(rctx.Trace.String(), `rule "label rule" => true`))
+	data, err := rctx.Trace.JSON()
+	assert.NoError(t, err)
+	assert.True(t, json.Valid(data))
+}
@@ -2,6 +2,8 @@ package rulesengine
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,85 +16,26 @@ type RuleEngine map[string]map[string]RuleCatalog
 
 func (e *RuleEngine) ListCatagoriesOfCatalogs() string {
 
-	var ty []string
-	for k := range *e {
-
-		ty = append(ty, k)
-	}
-
-	return strings.Join(ty, ",")
+	return strings.Join(sortedKeys(*e), ",")
 
 }
 
 func (e *RuleEngine) ListCatalogsByCategory(cat string) (string, error) {
 
-	var cats []string
-	found := false
-
-	for k, v := range *e {
-
-		if k == cat {
-			found = true
-			for k := range v {
-
-				cats = append(cats, k)
-			}
-		}
-	}
-
+	catalogs, found := (*e)[cat]
 	if !found {
 		return "", fmt.Errorf("%s is not a category registered in the engine", cat)
 	}
 
-	return strings.Join(cats, ","), nil
+	return strings.Join(sortedKeys(catalogs), ","), nil
 
 }
 
 func (e *RuleEngine) RunRules(rctx *RuleCtx, args ...string) error {
 
-	var fullCatalogs RuleCatalog
-	foundCat := false
-	foundCtl := false
-	for k, v := range *e {
-
-		if len(args) >= 1 {
-
-			if k != args[0] {
-				continue
-			}
-			foundCat = true
-			for k, v := range v {
-
-				if len(args) == 2 && foundCat {
-					if k != args[1] {
-						continue
-					}
-					fullCatalogs = append(fullCatalogs, v...)
-					foundCtl = true
-					klog.Infof("Loading the catalog for, %s, from category, %s", args[1], args[0])
-					break
-
-				} else {
-					klog.Infof("Loading the catalogs for category %s", args[0])
-					fullCatalogs = append(fullCatalogs, v...)
-
-				}
-
-			}
-		} else {
-			for _, v := range v {
-
-				fullCatalogs = append(fullCatalogs, v...)
-			}
-		}
-	}
-
-	if !foundCat && len(args) == 1 {
-		return fmt.Errorf("%s is not a category registered in the engine", args[0])
-	}
-
-	if !foundCtl && len(args) == 2 {
-		return fmt.Errorf("%s is not a catalog registered in the engine", args[1])
+	fullCatalogs, err := e.loadCatalogs(args...)
+	if err != nil {
+		return err
 	}
 
 	return e.runLoadedCatalog(fullCatalogs, rctx)
@@ -101,50 +44,70 @@ func (e *RuleEngine) RunRules(rctx *RuleCtx, args ...string) error {
 
 func (e *RuleEngine) RunRulesOfCategory(cat string, rctx *RuleCtx) error {
 
+	return e.RunRules(rctx, cat)
+
+}
+
+// loadCatalogs returns the rules of the catalogs selected by args: every catalog when no argument is given,
+// the catalogs of a category or the given catalogs of a category. Categories and catalogs are loaded in
+// alphabetical order and the rules are then sorted by priority, so that the rules are always evaluated
+// in the same order.
+func (e *RuleEngine) loadCatalogs(args ...string) (RuleCatalog, error) {
+
 	var fullCatalogs RuleCatalog
-	found := false
-	for k, v := range *e {
+	for _, cat := range sortedKeys(*e) {
 
-		if k == cat {
-			found = true
-			for _, v := range v {
+		if len(args) >= 1 && cat != args[0] {
+			continue
+		}
+		for _, ctl := range sortedKeys((*e)[cat]) {
 
-				fullCatalogs = append(fullCatalogs, v...)
+			if len(args) >= 2 && !slices.Contains(args[1:], ctl) {
+				continue
 			}
+			fullCatalogs = append(fullCatalogs, (*e)[cat][ctl]...)
 		}
 	}
 
-	if !found {
-		return fmt.Errorf("%s is not a category registered in the engine", cat)
+	if len(args) >= 1 {
+		catalogs, found := (*e)[args[0]]
+		if !found {
+			return nil, fmt.Errorf("%s is not a category registered in the engine", args[0])
+		}
+		if len(args) >= 2 {
+			for _, ctl := range args[1:] {
+				if _, found := catalogs[ctl]; !found {
+					return nil, fmt.Errorf("%s is not a catalog registered in the engine", ctl)
+				}
+			}
+			klog.Infof("Loading the catalog for, %s, from category, %s", strings.Join(args[1:], ", "), args[0])
+		} else {
+			klog.Infof("Loading the catalogs for category %s", args[0])
+		}
 	}
 
-	return e.runLoadedCatalog(fullCatalogs, rctx)
-
+	return fullCatalogs.SortByPriority(), nil
 }
 
 func (e *RuleEngine) runLoadedCatalog(loaded RuleCatalog, rctx *RuleCtx) error {
 
 	var matched RuleCatalog
 	for _, rule := range loaded {
-		ok, err := rule.Eval(rctx)
+		ok, err := rctx.traceCheck("rule", rule.Name, func() (bool, error) { return rule.Eval(rctx) })
 		if err != nil {
 			return err
 		}
 		// In most cases, a rule chain has no action to execute
 		// since a majority of the actions are encapsulated
-		// within the rules that compose the chain.
-		if len(rule.Actions) == 0 {
-			// In case the rule chain condition was evaluated to true,
-			// it means that the rule was applied, so stop iterating over next catalog rules.
-			// Otherwise continue
-			if ok {
-				return nil
-			}
-			continue
-		}
-		if ok {
+		// within the rules that compose the chain, so it was
+		// already applied while it was evaluated.
+		if ok && len(rule.Actions) != 0 {
 			matched = append(matched, rule)
 		}
+		if ok && rule.Exclusive {
+			klog.Infof("The exclusive rule %q has matched, the remaining rules are skipped.", rule.Name)
+			break
+		}
 	}
 
 	if len(matched) == 0 {
@@ -168,7 +131,14 @@ func (e *RuleEngine) dryRun(matched RuleCatalog, rctx *RuleCtx) error {
 	klog.Info("DryRun has been enabled will apply them in dry run mode")
 	for _, rule := range matched {
 
-		return rule.DryRun(rctx)
+		exit := rctx.traceEnter("dry-run", rule.Name)
+		err := rule.DryRun(rctx)
+		exit(err)
+
+		if err != nil {
+			klog.Errorf("Failed to execute rule in dry run mode: %s", rule.String())
+			return err
+		}
 
 	}
 
@@ -180,7 +150,9 @@ func (e *RuleEngine) run(matched RuleCatalog, rctx *RuleCtx) error {
 	klog.Info("Will apply rules")
 	for _, rule := range matched {
 
+		exit := rctx.traceEnter("apply", rule.Name)
 		err := rule.Apply(rctx)
+		exit(err)
 
 		if err != nil {
 			klog.Errorf("Failed to execute rule: %s", rule.String())
@@ -194,6 +166,16 @@ func (e *RuleEngine) run(matched RuleCatalog, rctx *RuleCtx) error {
 
 type RuleCatalog []Rule
 
+// SortByPriority returns a copy of the catalog with the rules sorted by descending priority.
+// Rules with the same priority keep their order.
+func (rc RuleCatalog) SortByPriority() RuleCatalog {
+
+	sorted := append(RuleCatalog{}, rc...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Priority > sorted[j].Priority })
+
+	return sorted
+}
+
 func (rc *RuleCatalog) String() string {
 
 	var names []string
@@ -217,6 +199,11 @@ type Any []Conditional
 
 func (a Any) Check(rctx *RuleCtx) (bool, error) {
 
+	return rctx.traceCheck("any", "", func() (bool, error) { return a.check(rctx) })
+}
+
+func (a Any) check(rctx *RuleCtx) (bool, error) {
+
 	// Initial logic was to pass on the first
 	// eval to true but that might not be the
 	// case. So not eval all and as long as any
@@ -240,6 +227,11 @@ type All []Conditional
 
 func (a All) Check(rctx *RuleCtx) (bool, error) {
 
+	return rctx.traceCheck("all", "", func() (bool, error) { return a.check(rctx) })
+}
+
+func (a All) check(rctx *RuleCtx) (bool, error) {
+
 	for _, c := range a {
 
 		ok, err := c.Check(rctx)
@@ -258,6 +250,11 @@ type None []Conditional
 
 func (a None) Check(rctx *RuleCtx) (bool, error) {
 
+	return rctx.traceCheck("none", "", func() (bool, error) { return a.check(rctx) })
+}
+
+func (a None) check(rctx *RuleCtx) (bool, error) {
+
 	for _, c := range a {
 
 		ok, err := c.Check(rctx)
@@ -272,6 +269,14 @@ func (a None) Check(rctx *RuleCtx) (bool, error) {
 	return true, nil
 }
 
+// Simulator is implemented by the actions that can be simulated. In dry run mode the engine
+// simulates these actions and skips every other one, so that a dry run never changes anything
+// in git, the cluster or SprayProxy, whether or not an action checks rctx.DryRun.
+type Simulator interface {
+	Simulate(rctx *RuleCtx) error
+}
+
+// ActionFunc is an action with effects outside of the RuleCtx, it is skipped in dry run mode
 type ActionFunc func(rctx *RuleCtx) error
 
 func (af ActionFunc) Execute(rctx *RuleCtx) error {
@@ -279,10 +284,42 @@ func (af ActionFunc) Execute(rctx *RuleCtx) error {
 	return af(rctx)
 }
 
+// CtxActionFunc is an action only changing the RuleCtx (i.e. the label filter or the focus files),
+// so it is run in dry run mode as well. It may look up what it sets in git or GitHub (i.e. the
+// changed files of the PR), but only through the read only lookups stubbed in unit tests.
+type CtxActionFunc func(rctx *RuleCtx) error
+
+func (af CtxActionFunc) Execute(rctx *RuleCtx) error {
+
+	return af(rctx)
+}
+
+func (af CtxActionFunc) Simulate(rctx *RuleCtx) error {
+
+	return af(rctx)
+}
+
+// SimulatedAction is an action with effects outside of the RuleCtx along with the function
+// simulating it in dry run mode, i.e. by recording the commands it would run in the plan.
+type SimulatedAction struct {
+	Action ActionFunc
+	DryRun ActionFunc
+}
+
+func (sa SimulatedAction) Execute(rctx *RuleCtx) error {
+
+	return sa.Action(rctx)
+}
+
+func (sa SimulatedAction) Simulate(rctx *RuleCtx) error {
+
+	return sa.DryRun(rctx)
+}
+
 type ConditionFunc func(rctx *RuleCtx) (bool, error)
 
 func (cf ConditionFunc) Check(rctx *RuleCtx) (bool, error) {
-	return cf(rctx)
+	return rctx.traceCheck("condition", funcName(cf), func() (bool, error) { return cf(rctx) })
 }
 
 type Rule struct {
@@ -290,6 +327,10 @@ type Rule struct {
 	Description string
 	Condition   Conditional
 	Actions     []Action
+	// Priority orders the rules loaded by the engine, higher first
+	Priority int
+	// Exclusive rules stop the engine from evaluating the rules loaded after them once they match
+	Exclusive bool
 }
 
 func (r *Rule) String() string {
@@ -312,7 +353,7 @@ func (r *Rule) Apply(rctx *RuleCtx) error {
 
 	for _, action := range r.Actions {
 
-		err := action.Execute(rctx)
+		err := rctx.traceAction(action)
 		if err != nil {
 			return err
 		}
@@ -321,12 +362,22 @@ func (r *Rule) Apply(rctx *RuleCtx) error {
 	return nil
 }
 
+// DryRun simulates the actions of the rule, the actions that can't be simulated are skipped
 func (r *Rule) DryRun(rctx *RuleCtx) error {
 
 	rctx.DryRun = true
+	// a rule can be reached several times through nested rule chains
+	if rctx.Plan != nil && !slices.Contains(rctx.Plan.AppliedRules, r.Name) {
+		rctx.Plan.AppliedRules = append(rctx.Plan.AppliedRules, r.Name)
+	}
 	for _, action := range r.Actions {
 
-		err := action.Execute(rctx)
+		simulator, ok := action.(Simulator)
+		if !ok {
+			klog.Infof("Skipping action %s of rule %q in dry run mode", funcName(action), r.Name)
+			continue
+		}
+		err := rctx.traceRun(action, simulator.Simulate)
 		if err != nil {
 			return err
 		}
@@ -337,6 +388,11 @@ func (r *Rule) DryRun(rctx *RuleCtx) error {
 
 func (r *Rule) Check(rctx *RuleCtx) (bool, error) {
 
+	return rctx.traceCheck("rule", r.Name, func() (bool, error) { return r.check(rctx) })
+}
+
+func (r *Rule) check(rctx *RuleCtx) (bool, error) {
+
 	ok, err := r.Eval(rctx)
 	if err != nil {
 		return false, err
@@ -451,6 +507,10 @@ type RuleCtx struct {
 	TektonEventType               string
 	RequiresMultiPlatformTests    bool
 	RequiresSprayProxyRegistering bool
+	// Trace records the evaluation of the rules when set
+	Trace *EvalTrace
+	// Plan collects the outcome of the actions instead of executing them when set
+	Plan *ExecutionPlan
 }
 
 func NewRuleCtx() *RuleCtx {
@@ -480,7 +540,9 @@ func NewRuleCtx() *RuleCtx {
 		0,
 		"",
 		false,
-		false}
+		false,
+		nil,
+		nil}
 
 	//init defaults we've used so far
 	t, _ := time.ParseDuration("90m")
@@ -509,3 +571,25 @@ func (gca *RuleCtx) GetRuleData(key string) any {
 	return nil
 
 }
+
+// AddLabelToLabelFilter ensures the given label is added to the LabelFilter
+func (gca *RuleCtx) AddLabelToLabelFilter(label string) {
+	if !strings.Contains(gca.LabelFilter, label) {
+		if gca.LabelFilter == "" {
+			gca.LabelFilter = label
+		} else {
+			gca.LabelFilter = fmt.Sprintf("%s,%s", gca.LabelFilter, label)
+		}
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -1,9 +1,12 @@
 package common
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/konflux-ci/e2e-tests/pkg/clients/git"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/gitea"
 	"github.com/konflux-ci/e2e-tests/pkg/clients/github"
 	"github.com/konflux-ci/e2e-tests/pkg/clients/gitlab"
 	kubeCl "github.com/konflux-ci/e2e-tests/pkg/clients/kubernetes"
@@ -20,6 +23,8 @@ type SuiteController struct {
 	// Github client to interact with GH apis
 	Github *github.Github
 	Gitlab *gitlab.GitlabClient
+	// Gitea client is nil unless GITEA_API_URL env var points to a Gitea/Forgejo instance
+	Gitea *gitea.GiteaClient
 }
 
 /*
@@ -27,7 +32,7 @@ Create controller for the common kubernetes API crud operations. This controller
 Check if a github organization env var is set, if not use by default the redhat-appstudio-qe org. See: https://github.com/redhat-appstudio-qe
 */
 func NewSuiteController(kubeC *kubeCl.CustomClient) (*SuiteController, error) {
-	gh, err := github.NewGithubClient(utils.GetEnv(constants.GITHUB_TOKEN_ENV, ""), utils.GetEnv(constants.GITHUB_E2E_ORGANIZATION_ENV, "redhat-appstudio-qe"))
+	gh, err := github.NewGithubClientFromEnv(utils.GetEnv(constants.GITHUB_E2E_ORGANIZATION_ENV, "redhat-appstudio-qe"))
 	if err != nil {
 		return nil, err
 	}
@@ -37,9 +42,29 @@ func NewSuiteController(kubeC *kubeCl.CustomClient) (*SuiteController, error) {
 		return nil, fmt.Errorf("failed to authenticate with GitLab: %w", err)
 	}
 
+	var gt *gitea.GiteaClient
+	if giteaURL := utils.GetEnv(constants.GITEA_API_URL_ENV, ""); giteaURL != "" {
+		insecureSkipVerify, err := strconv.ParseBool(utils.GetEnv(constants.GITEA_INSECURE_SKIP_VERIFY_ENV, "false"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of %s env var: %w", constants.GITEA_INSECURE_SKIP_VERIFY_ENV, err)
+		}
+		gt, err = gitea.NewGiteaClient(utils.GetEnv(constants.GITEA_TOKEN_ENV, ""), giteaURL, utils.GetEnv(constants.GITEA_ORG_ENV, ""), insecureSkipVerify)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Gitea client: %w", err)
+		}
+	}
+
 	return &SuiteController{
 		CustomClient: kubeC,
 		Github:       gh,
 		Gitlab:       gl,
+		Gitea:        gt,
 	}, nil
 }
+
+// WithContext returns a copy of the controller whose API calls and waits are bound to ctx
+func (s *SuiteController) WithContext(ctx context.Context) *SuiteController {
+	scoped := *s
+	scoped.CustomClient = s.CustomClient.WithContext(ctx)
+	return &scoped
+}
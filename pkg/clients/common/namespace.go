@@ -1,7 +1,6 @@
 package common
 
 import (
-	"context"
 	"fmt"
 	"maps"
 	"time"
@@ -20,20 +19,20 @@ import (
 
 // DeleteNamespace deletes the give namespace.
 func (s *SuiteController) DeleteNamespace(namespace string) error {
-	_, err := s.KubeInterface().CoreV1().Namespaces().Get(context.Background(), namespace, metav1.GetOptions{})
+	_, err := s.KubeInterface().CoreV1().Namespaces().Get(s.Context(), namespace, metav1.GetOptions{})
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return fmt.Errorf("could not check for namespace '%s' existence: %v", namespace, err)
 	}
 
-	if err := s.KubeInterface().CoreV1().Namespaces().Delete(context.Background(), namespace, metav1.DeleteOptions{}); err != nil {
+	if err := s.KubeInterface().CoreV1().Namespaces().Delete(s.Context(), namespace, metav1.DeleteOptions{}); err != nil {
 		return fmt.Errorf("unable to delete namespace '%s': %v", namespace, err)
 	}
 
 	// Wait for the namespace to no longer exist. The namespace may remain stuck in 'Terminating' state
 	// if it contains with finalizers that are not handled. We detect this case here, and report any resources still
 	// in the Namespace.
-	if err := utils.WaitUntil(s.namespaceDoesNotExist(namespace), time.Minute*10); err != nil {
+	if err := utils.WaitUntilContext(s.Context(), s.namespaceDoesNotExist(namespace), time.Minute*10); err != nil {
 
 		// On failure to delete, list all namespace-scoped resources still in the namespace.
 		resourcesInNamespace := s.ListNamespaceScopedResourcesAsString(namespace, s.KubeInterface(), s.DynamicClient())
@@ -90,7 +89,7 @@ func (s *SuiteController) ListNamespaceScopedResourcesAsString(namespace string,
 				Resource: apiResource.Name,
 			}
 
-			unstructuredList, err := dynamicInterface.Resource(gvr).Namespace(namespace).List(context.Background(), metav1.ListOptions{})
+			unstructuredList, err := dynamicInterface.Resource(gvr).Namespace(namespace).List(s.Context(), metav1.ListOptions{})
 			if err != nil {
 				// Ignore errors: this function is for diagnostic purposes only.
 				continue
@@ -113,7 +112,7 @@ func (s *SuiteController) ListNamespaceScopedResourcesAsString(namespace string,
 // CreateTestNamespace creates a namespace where Application and Component CR will be created
 func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, error) {
 	// Check if the E2E test namespace already exists
-	ns, err := s.KubeInterface().CoreV1().Namespaces().Get(context.Background(), name, metav1.GetOptions{})
+	ns, err := s.KubeInterface().CoreV1().Namespaces().Get(s.Context(), name, metav1.GetOptions{})
 	requiredLabels := map[string]string{
 		constants.ArgoCDLabelKey:    constants.ArgoCDLabelValue,
 		constants.TenantLabelKey:    constants.TenantLabelValue,
@@ -128,7 +127,7 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 					Name:   name,
 					Labels: requiredLabels,
 				}}
-			ns, err = s.KubeInterface().CoreV1().Namespaces().Create(context.Background(), &nsTemplate, metav1.CreateOptions{})
+			ns, err = s.KubeInterface().CoreV1().Namespaces().Create(s.Context(), &nsTemplate, metav1.CreateOptions{})
 			if err != nil {
 				return nil, fmt.Errorf("error when creating %s namespace: %v", name, err)
 			}
@@ -146,7 +145,7 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 	}
 
 	// Create ServiceAccount which is used by Pipelines but created by Toolchain host operator
-	_, err = s.KubeInterface().CoreV1().ServiceAccounts(name).Get(context.Background(), constants.DefaultPipelineServiceAccount, metav1.GetOptions{})
+	_, err = s.KubeInterface().CoreV1().ServiceAccounts(name).Get(s.Context(), constants.DefaultPipelineServiceAccount, metav1.GetOptions{})
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			saTemplate := corev1.ServiceAccount{
@@ -154,7 +153,7 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 					Name: constants.DefaultPipelineServiceAccount,
 				},
 			}
-			_, err = s.KubeInterface().CoreV1().ServiceAccounts(name).Create(context.Background(), &saTemplate, metav1.CreateOptions{})
+			_, err = s.KubeInterface().CoreV1().ServiceAccounts(name).Create(s.Context(), &saTemplate, metav1.CreateOptions{})
 			if err != nil {
 				return nil, fmt.Errorf("error when creating %s serviceaccount: %v", constants.DefaultPipelineServiceAccount, err)
 			}
@@ -163,7 +162,7 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 		}
 	}
 
-	_, err = s.KubeInterface().RbacV1().RoleBindings(name).Get(context.Background(), constants.DefaultPipelineServiceAccountRoleBinding, metav1.GetOptions{})
+	_, err = s.KubeInterface().RbacV1().RoleBindings(name).Get(s.Context(), constants.DefaultPipelineServiceAccountRoleBinding, metav1.GetOptions{})
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			roleBindingTemplate := rbacv1.RoleBinding{
@@ -181,7 +180,7 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 					Name: constants.DefaultPipelineServiceAccountClusterRole,
 				},
 			}
-			_, err = s.KubeInterface().RbacV1().RoleBindings(name).Create(context.Background(), &roleBindingTemplate, metav1.CreateOptions{})
+			_, err = s.KubeInterface().RbacV1().RoleBindings(name).Create(s.Context(), &roleBindingTemplate, metav1.CreateOptions{})
 			if err != nil {
 				return nil, fmt.Errorf("error when creating %s roleBinding: %v", constants.DefaultPipelineServiceAccountRoleBinding, err)
 			}
@@ -196,7 +195,7 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 func (s *SuiteController) namespaceDoesNotExist(namespace string) wait.ConditionFunc {
 	return func() (bool, error) {
 
-		_, err := s.KubeInterface().CoreV1().Namespaces().Get(context.Background(), namespace, metav1.GetOptions{})
+		_, err := s.KubeInterface().CoreV1().Namespaces().Get(s.Context(), namespace, metav1.GetOptions{})
 
 		return err != nil && k8sErrors.IsNotFound(err), nil
 	}
@@ -204,7 +203,7 @@ func (s *SuiteController) namespaceDoesNotExist(namespace string) wait.Condition
 
 // GetNamespace returns the requested Namespace object
 func (s *SuiteController) GetNamespace(namespace string) (*corev1.Namespace, error) {
-	return s.KubeInterface().CoreV1().Namespaces().Get(context.Background(), namespace, metav1.GetOptions{})
+	return s.KubeInterface().CoreV1().Namespaces().Get(s.Context(), namespace, metav1.GetOptions{})
 }
 
 // Ensure that the labels provided in `requiredLabels` (including their values) exists on namespace `ns`
@@ -221,7 +220,7 @@ func (s *SuiteController) ensureLabelsExist(ns *corev1.Namespace, requiredLabels
 
 	maps.Copy(ns.Labels, requiredLabels)
 
-	ns, err := s.KubeInterface().CoreV1().Namespaces().Update(context.Background(), ns, metav1.UpdateOptions{})
+	ns, err := s.KubeInterface().CoreV1().Namespaces().Update(s.Context(), ns, metav1.UpdateOptions{})
 	if err != nil {
 		return false, fmt.Errorf("error when updating labels in '%s' namespace: %v", ns.Name, err)
 	}
@@ -1,7 +1,6 @@
 package common
 
 import (
-	"context"
 	"fmt"
 	"time"
 
@@ -19,7 +18,7 @@ func (s *SuiteController) CreateProxyPlugin(proxyPluginName, proxyPluginNamespac
 	// Create the ProxyPlugin object
 	proxyPlugin := common.NewProxyPlugin(proxyPluginName, proxyPluginNamespace, routeName, routeNamespace)
 
-	if err := s.KubeRest().Create(context.Background(), proxyPlugin); err != nil {
+	if err := s.KubeRest().Create(s.Context(), proxyPlugin); err != nil {
 		return nil, fmt.Errorf("unable to create proxy plugin due to %v", err)
 	}
 	return proxyPlugin, nil
@@ -34,11 +33,11 @@ func (s *SuiteController) DeleteProxyPlugin(proxyPluginName, proxyPluginNamespac
 		},
 	}
 
-	if err := s.KubeRest().Delete(context.Background(), proxyPlugin); err != nil {
+	if err := s.KubeRest().Delete(s.Context(), proxyPlugin); err != nil {
 		return false, err
 	}
-	err := utils.WaitUntil(func() (done bool, err error) {
-		err = s.KubeRest().Get(context.Background(), types.NamespacedName{
+	err := utils.WaitUntilContext(s.Context(), func() (done bool, err error) {
+		err = s.KubeRest().Get(s.Context(), types.NamespacedName{
 			Namespace: proxyPluginNamespace,
 			Name:      proxyPluginName,
 		}, proxyPlugin)
@@ -1,7 +1,6 @@
 package common
 
 import (
-	"context"
 	"crypto/tls"
 	"fmt"
 	"net/http"
@@ -20,7 +19,7 @@ func (h *SuiteController) GetOpenshiftRoute(routeName string, routeNamespace str
 	}
 
 	route := &routev1.Route{}
-	err := h.KubeRest().Get(context.Background(), namespacedName, route)
+	err := h.KubeRest().Get(h.Context(), namespacedName, route)
 	if err != nil {
 		return &routev1.Route{}, err
 	}
@@ -33,7 +32,7 @@ func (h *SuiteController) GetOpenshiftRouteByComponentName(componentName string,
 	listOptions := metav1.ListOptions{
 		LabelSelector: fmt.Sprintf("app.kubernetes.io/name=%s", componentName),
 	}
-	routeList, err := h.CustomClient.RouteClient().RouteV1().Routes(componentNamespace).List(context.Background(), listOptions)
+	routeList, err := h.CustomClient.RouteClient().RouteV1().Routes(componentNamespace).List(h.Context(), listOptions)
 	if err != nil {
 		return &routev1.Route{}, err
 	}
@@ -50,7 +49,7 @@ func (h *SuiteController) RouteHostnameIsAccessible(routeName string, namespace
 			Namespace: namespace,
 		}
 		route := &routev1.Route{}
-		if err := h.KubeRest().Get(context.Background(), namespacedName, route); err != nil {
+		if err := h.KubeRest().Get(h.Context(), namespacedName, route); err != nil {
 			return false, nil
 		}
 
@@ -1,8 +1,6 @@
 package common
 
 import (
-	"context"
-
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -15,7 +13,7 @@ func (h *SuiteController) GetServiceByName(serviceName string, serviceNamespace
 	}
 
 	service := &corev1.Service{}
-	err := h.KubeRest().Get(context.Background(), namespacedName, service)
+	err := h.KubeRest().Get(h.Context(), namespacedName, service)
 	if err != nil {
 		return &corev1.Service{}, err
 	}
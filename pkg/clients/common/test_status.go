@@ -1,8 +1,6 @@
 package common
 
 import (
-	"context"
-
 	appstudioApi "github.com/konflux-ci/application-api/api/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,7 +25,7 @@ func (s *SuiteController) MarkTestsSucceeded(snapshot *appstudioApi.Snapshot) (*
 		Reason:  "Passed",
 		Message: "Snapshot Passed",
 	})
-	err := s.KubeRest().Status().Patch(context.Background(), snapshot, patch)
+	err := s.KubeRest().Status().Patch(s.Context(), snapshot, patch)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,688 @@
+// Package fake provides an in-memory implementation of git.Client, meant for offline unit tests
+// of code which works with git providers, e.g. PaC helpers.
+package fake
+
+import (
+	"crypto/sha1" // #nosec G505 -- used only to generate git-like commit IDs
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/konflux-ci/e2e-tests/pkg/clients/git"
+)
+
+// ErrNotFound is wrapped by errors about missing repositories, branches, commits, files or pull requests.
+// Its message mimics the one of real providers, so callers checking for "404" behave the same way.
+var ErrNotFound = errors.New("404 Not Found")
+
+// Call is a record of a single git.Client method call
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// PullRequestState is the state of a fake pull request
+type PullRequestState string
+
+const (
+	PullRequestOpen   PullRequestState = "open"
+	PullRequestClosed PullRequestState = "closed"
+	PullRequestMerged PullRequestState = "merged"
+)
+
+// Commit is a commit of a fake repository, it holds the whole tree of the repository
+type Commit struct {
+	SHA     string
+	Parents []string
+	Message string
+	Files   map[string]string
+}
+
+// PullRequest is a fake pull request together with its state
+type PullRequest struct {
+	git.PullRequest
+	Title string
+	Body  string
+	State PullRequestState
+}
+
+// Webhook is a webhook registered in a fake repository
+type Webhook struct {
+	ID  int64
+	URL string
+}
+
+type repository struct {
+	defaultBranch string
+	branches      map[string]string
+	commits       map[string]*Commit
+	pullRequests  map[int]*PullRequest
+	nextPRNumber  int
+	comments      map[int][]*git.PullRequestComment
+	statuses      map[string][]*git.CommitStatus
+	webhooks      []*Webhook
+}
+
+// Client is an in-memory git.Client. Repositories have to be created with CreateRepository first.
+// All methods are safe for concurrent use.
+type Client struct {
+	mu            sync.Mutex
+	repositories  map[string]*repository
+	calls         []Call
+	errors        map[string]error
+	commitCounter int
+	commentID     int64
+	webhookID     int64
+}
+
+var _ git.Client = &Client{}
+
+// NewClient creates fake client without any repositories
+func NewClient() *Client {
+	return &Client{
+		repositories: map[string]*repository{},
+		errors:       map[string]error{},
+	}
+}
+
+// CreateRepository creates repository with defaultBranch pointing to an initial commit with given files
+// and returns SHA of that commit
+func (c *Client) CreateRepository(name, defaultBranch string, files map[string]string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	repo := &repository{
+		defaultBranch: defaultBranch,
+		branches:      map[string]string{},
+		commits:       map[string]*Commit{},
+		pullRequests:  map[int]*PullRequest{},
+		nextPRNumber:  1,
+		comments:      map[int][]*git.PullRequestComment{},
+		statuses:      map[string][]*git.CommitStatus{},
+	}
+	c.repositories[name] = repo
+	commit := c.commit(repo, nil, "Initial commit", copyFiles(files))
+	repo.branches[defaultBranch] = commit.SHA
+	return commit.SHA
+}
+
+// InjectError makes every following call of given git.Client method (e.g. "MergePullRequest") fail with err.
+// Passing nil err removes the injected error.
+func (c *Client) InjectError(method string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err == nil {
+		delete(c.errors, method)
+		return
+	}
+	c.errors[method] = err
+}
+
+// Calls returns recorded calls of given git.Client method, or of all methods when it is empty
+func (c *Client) Calls(method string) []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	calls := []Call{}
+	for _, call := range c.calls {
+		if method == "" || call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// ResetCalls forgets recorded calls
+func (c *Client) ResetCalls() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = nil
+}
+
+// BranchHead returns SHA of the commit the branch points to
+func (c *Client) BranchHead(repositoryName, branchName string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return "", err
+	}
+	return repo.head(repositoryName, branchName)
+}
+
+// Commit returns commit with given SHA
+func (c *Client) Commit(repositoryName, sha string) (*Commit, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	commit, ok := repo.commits[sha]
+	if !ok {
+		return nil, fmt.Errorf("%w: commit %s does not exist in repository %s", ErrNotFound, sha, repositoryName)
+	}
+	return copyCommit(commit), nil
+}
+
+// GetPullRequest returns pull request including its state
+func (c *Client) GetPullRequest(repositoryName string, prNumber int) (*PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := repo.pullRequest(repositoryName, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	result := *pr
+	return &result, nil
+}
+
+// AddPullRequestComment adds comment to the pull request, e.g. as if it was reported by PaC or integration service
+func (c *Client) AddPullRequestComment(repositoryName string, prNumber int, author, body string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := repo.pullRequest(repositoryName, prNumber); err != nil {
+		return 0, err
+	}
+	c.commentID++
+	repo.comments[prNumber] = append(repo.comments[prNumber], &git.PullRequestComment{
+		ID:        c.commentID,
+		Author:    author,
+		Body:      body,
+		CreatedAt: time.Now(),
+	})
+	return c.commentID, nil
+}
+
+// SetCommitStatus reports status for the commit, replacing the previous status with the same name
+func (c *Client) SetCommitStatus(repositoryName, sha string, status git.CommitStatus) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return err
+	}
+	if _, ok := repo.commits[sha]; !ok {
+		return fmt.Errorf("%w: commit %s does not exist in repository %s", ErrNotFound, sha, repositoryName)
+	}
+	for i, existing := range repo.statuses[sha] {
+		if existing.Name == status.Name {
+			repo.statuses[sha][i] = &status
+			return nil
+		}
+	}
+	repo.statuses[sha] = append(repo.statuses[sha], &status)
+	return nil
+}
+
+// AddWebhook registers webhook sending events to hookURL and returns its ID
+func (c *Client) AddWebhook(repositoryName, hookURL string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return 0, err
+	}
+	c.webhookID++
+	repo.webhooks = append(repo.webhooks, &Webhook{ID: c.webhookID, URL: hookURL})
+	return c.webhookID, nil
+}
+
+// Webhooks returns webhooks registered in the repository
+func (c *Client) Webhooks(repositoryName string) ([]Webhook, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	webhooks := []Webhook{}
+	for _, hook := range repo.webhooks {
+		webhooks = append(webhooks, *hook)
+	}
+	return webhooks, nil
+}
+
+// CreateBranch creates branch from revision or, if it is empty, from the head of the base branch
+func (c *Client) CreateBranch(repositoryName, baseBranchName, revision, branchName string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("CreateBranch", repositoryName, baseBranchName, revision, branchName); err != nil {
+		return err
+	}
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return err
+	}
+	if _, ok := repo.branches[branchName]; ok {
+		return fmt.Errorf("422 Reference already exists: branch %s in repository %s", branchName, repositoryName)
+	}
+	sha := revision
+	if sha == "" {
+		if sha, err = repo.head(repositoryName, baseBranchName); err != nil {
+			return err
+		}
+	} else if _, ok := repo.commits[sha]; !ok {
+		return fmt.Errorf("%w: commit %s does not exist in repository %s", ErrNotFound, sha, repositoryName)
+	}
+	repo.branches[branchName] = sha
+	return nil
+}
+
+func (c *Client) DeleteBranch(repositoryName, branchName string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("DeleteBranch", repositoryName, branchName); err != nil {
+		return err
+	}
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return err
+	}
+	if _, err := repo.head(repositoryName, branchName); err != nil {
+		return err
+	}
+	delete(repo.branches, branchName)
+	return nil
+}
+
+func (c *Client) BranchExists(repositoryName, branchName string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("BranchExists", repositoryName, branchName); err != nil {
+		return false, err
+	}
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return false, err
+	}
+	_, ok := repo.branches[branchName]
+	return ok, nil
+}
+
+// ListPullRequests lists open pull requests ordered by their number
+func (c *Client) ListPullRequests(repositoryName string) ([]*git.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("ListPullRequests", repositoryName); err != nil {
+		return nil, err
+	}
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	var pullRequests []*git.PullRequest
+	for _, pr := range repo.pullRequests {
+		if pr.State == PullRequestOpen {
+			result := pr.PullRequest
+			pullRequests = append(pullRequests, &result)
+		}
+	}
+	sort.Slice(pullRequests, func(i, j int) bool { return pullRequests[i].Number < pullRequests[j].Number })
+	return pullRequests, nil
+}
+
+// CreateFile commits new file to the branch, open pull requests from that branch get the new head SHA
+func (c *Client) CreateFile(repositoryName, pathToFile, content, branchName string) (*git.RepositoryFile, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("CreateFile", repositoryName, pathToFile, content, branchName); err != nil {
+		return nil, err
+	}
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	head, err := repo.head(repositoryName, branchName)
+	if err != nil {
+		return nil, err
+	}
+	files := copyFiles(repo.commits[head].Files)
+	if _, ok := files[pathToFile]; ok {
+		return nil, fmt.Errorf("422 file %s already exists on branch %s of repository %s", pathToFile, branchName, repositoryName)
+	}
+	files[pathToFile] = content
+	commit := c.commit(repo, []string{head}, "Create "+pathToFile, files)
+	repo.moveBranch(branchName, commit.SHA)
+	return &git.RepositoryFile{CommitSHA: commit.SHA, Content: content}, nil
+}
+
+// GetFile returns file from the head of the branch, CommitSHA is the SHA of that head
+func (c *Client) GetFile(repositoryName, pathToFile, branchName string) (*git.RepositoryFile, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("GetFile", repositoryName, pathToFile, branchName); err != nil {
+		return nil, err
+	}
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	head, err := repo.head(repositoryName, branchName)
+	if err != nil {
+		return nil, err
+	}
+	content, ok := repo.commits[head].Files[pathToFile]
+	if !ok {
+		return nil, fmt.Errorf("%w: file %s does not exist on branch %s of repository %s", ErrNotFound, pathToFile, branchName, repositoryName)
+	}
+	return &git.RepositoryFile{CommitSHA: head, Content: content}, nil
+}
+
+// UpdateFile commits new content of existing file to the branch
+func (c *Client) UpdateFile(repositoryName, pathToFile, content, branchName string) (*git.RepositoryFile, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("UpdateFile", repositoryName, pathToFile, content, branchName); err != nil {
+		return nil, err
+	}
+	repo, files, err := c.branchFiles(repositoryName, pathToFile, branchName)
+	if err != nil {
+		return nil, err
+	}
+	files[pathToFile] = content
+	commit := c.commit(repo, []string{repo.branches[branchName]}, "Update "+pathToFile, files)
+	repo.moveBranch(branchName, commit.SHA)
+	return &git.RepositoryFile{CommitSHA: commit.SHA, Content: content}, nil
+}
+
+// DeleteFile commits removal of existing file to the branch
+func (c *Client) DeleteFile(repositoryName, pathToFile, branchName string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("DeleteFile", repositoryName, pathToFile, branchName); err != nil {
+		return err
+	}
+	repo, files, err := c.branchFiles(repositoryName, pathToFile, branchName)
+	if err != nil {
+		return err
+	}
+	delete(files, pathToFile)
+	commit := c.commit(repo, []string{repo.branches[branchName]}, "Delete "+pathToFile, files)
+	repo.moveBranch(branchName, commit.SHA)
+	return nil
+}
+
+func (c *Client) CreatePullRequest(repositoryName, title, body, head, base string) (*git.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("CreatePullRequest", repositoryName, title, body, head, base); err != nil {
+		return nil, err
+	}
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	headSHA, err := repo.head(repositoryName, head)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := repo.head(repositoryName, base); err != nil {
+		return nil, err
+	}
+	pr := &PullRequest{
+		PullRequest: git.PullRequest{
+			Number:       repo.nextPRNumber,
+			SourceBranch: head,
+			TargetBranch: base,
+			HeadSHA:      headSHA,
+		},
+		Title: title,
+		Body:  body,
+		State: PullRequestOpen,
+	}
+	repo.pullRequests[pr.Number] = pr
+	repo.nextPRNumber++
+	result := pr.PullRequest
+	return &result, nil
+}
+
+// MergePullRequest creates merge commit on the target branch. Changes the source branch made since
+// its merge base with the target branch are applied, on conflicts files of the source branch win.
+func (c *Client) MergePullRequest(repositoryName string, prNumber int) (*git.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("MergePullRequest", repositoryName, prNumber); err != nil {
+		return nil, err
+	}
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := repo.pullRequest(repositoryName, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	if pr.State != PullRequestOpen {
+		return nil, fmt.Errorf("405 pull request %d of repository %s is %s", prNumber, repositoryName, pr.State)
+	}
+	baseSHA, err := repo.head(repositoryName, pr.TargetBranch)
+	if err != nil {
+		return nil, err
+	}
+	files := copyFiles(repo.commits[baseSHA].Files)
+	mergeBaseFiles := map[string]string{}
+	if mergeBase := repo.mergeBase(baseSHA, pr.HeadSHA); mergeBase != "" {
+		mergeBaseFiles = repo.commits[mergeBase].Files
+	}
+	headFiles := repo.commits[pr.HeadSHA].Files
+	for path, content := range headFiles {
+		if original, ok := mergeBaseFiles[path]; !ok || original != content {
+			files[path] = content
+		}
+	}
+	for path := range mergeBaseFiles {
+		if _, ok := headFiles[path]; !ok {
+			delete(files, path)
+		}
+	}
+	commit := c.commit(repo, []string{baseSHA, pr.HeadSHA}, fmt.Sprintf("Merge pull request #%d from %s", prNumber, pr.SourceBranch), files)
+	repo.moveBranch(pr.TargetBranch, commit.SHA)
+
+	pr.MergeCommitSHA = commit.SHA
+	pr.State = PullRequestMerged
+	result := pr.PullRequest
+	return &result, nil
+}
+
+func (c *Client) DeleteBranchAndClosePullRequest(repositoryName string, prNumber int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("DeleteBranchAndClosePullRequest", repositoryName, prNumber); err != nil {
+		return err
+	}
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return err
+	}
+	pr, err := repo.pullRequest(repositoryName, prNumber)
+	if err != nil {
+		return err
+	}
+	delete(repo.branches, pr.SourceBranch)
+	if pr.State == PullRequestOpen {
+		pr.State = PullRequestClosed
+	}
+	return nil
+}
+
+// ListPullRequestComments lists comments added by AddPullRequestComment, oldest first
+func (c *Client) ListPullRequestComments(repositoryName string, prNumber int) ([]*git.PullRequestComment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("ListPullRequestComments", repositoryName, prNumber); err != nil {
+		return nil, err
+	}
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := repo.pullRequest(repositoryName, prNumber); err != nil {
+		return nil, err
+	}
+	var comments []*git.PullRequestComment
+	for _, comment := range repo.comments[prNumber] {
+		copied := *comment
+		comments = append(comments, &copied)
+	}
+	return comments, nil
+}
+
+// ListCommitStatuses lists statuses set by SetCommitStatus, ref has to be a commit SHA
+func (c *Client) ListCommitStatuses(repositoryName, ref string) ([]*git.CommitStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("ListCommitStatuses", repositoryName, ref); err != nil {
+		return nil, err
+	}
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []*git.CommitStatus
+	for _, status := range repo.statuses[ref] {
+		copied := *status
+		statuses = append(statuses, &copied)
+	}
+	return statuses, nil
+}
+
+// CleanupWebhooks deletes webhooks whose URL contains clusterAppDomain
+func (c *Client) CleanupWebhooks(repositoryName, clusterAppDomain string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record("CleanupWebhooks", repositoryName, clusterAppDomain); err != nil {
+		return err
+	}
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return err
+	}
+	kept := []*Webhook{}
+	for _, hook := range repo.webhooks {
+		if !strings.Contains(hook.URL, clusterAppDomain) {
+			kept = append(kept, hook)
+		}
+	}
+	repo.webhooks = kept
+	return nil
+}
+
+// record stores the call and returns error injected for the method, must be called with the lock held
+func (c *Client) record(method string, args ...interface{}) error {
+	c.calls = append(c.calls, Call{Method: method, Args: args})
+	return c.errors[method]
+}
+
+func (c *Client) repository(name string) (*repository, error) {
+	repo, ok := c.repositories[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: repository %s does not exist", ErrNotFound, name)
+	}
+	return repo, nil
+}
+
+// branchFiles returns copy of files at the head of the branch, which has to contain pathToFile
+func (c *Client) branchFiles(repositoryName, pathToFile, branchName string) (*repository, map[string]string, error) {
+	repo, err := c.repository(repositoryName)
+	if err != nil {
+		return nil, nil, err
+	}
+	head, err := repo.head(repositoryName, branchName)
+	if err != nil {
+		return nil, nil, err
+	}
+	files := copyFiles(repo.commits[head].Files)
+	if _, ok := files[pathToFile]; !ok {
+		return nil, nil, fmt.Errorf("%w: file %s does not exist on branch %s of repository %s", ErrNotFound, pathToFile, branchName, repositoryName)
+	}
+	return repo, files, nil
+}
+
+func (c *Client) commit(repo *repository, parents []string, message string, files map[string]string) *Commit {
+	c.commitCounter++
+	hash := sha1.New() // #nosec G401
+	fmt.Fprintf(hash, "%d\n%s\n%s\n", c.commitCounter, strings.Join(parents, ","), message)
+	commit := &Commit{
+		SHA:     hex.EncodeToString(hash.Sum(nil)),
+		Parents: parents,
+		Message: message,
+		Files:   files,
+	}
+	repo.commits[commit.SHA] = commit
+	return commit
+}
+
+func (r *repository) head(repositoryName, branchName string) (string, error) {
+	sha, ok := r.branches[branchName]
+	if !ok {
+		return "", fmt.Errorf("%w: Reference does not exist: branch %s in repository %s", ErrNotFound, branchName, repositoryName)
+	}
+	return sha, nil
+}
+
+// mergeBase returns the nearest common ancestor of two commits, empty if they have none
+func (r *repository) mergeBase(sha, otherSHA string) string {
+	ancestors := map[string]bool{}
+	for queue := []string{sha}; len(queue) > 0; queue = queue[1:] {
+		if !ancestors[queue[0]] {
+			ancestors[queue[0]] = true
+			queue = append(queue, r.commits[queue[0]].Parents...)
+		}
+	}
+	visited := map[string]bool{}
+	for queue := []string{otherSHA}; len(queue) > 0; queue = queue[1:] {
+		if ancestors[queue[0]] {
+			return queue[0]
+		}
+		if !visited[queue[0]] {
+			visited[queue[0]] = true
+			queue = append(queue, r.commits[queue[0]].Parents...)
+		}
+	}
+	return ""
+}
+
+func (r *repository) pullRequest(repositoryName string, prNumber int) (*PullRequest, error) {
+	pr, ok := r.pullRequests[prNumber]
+	if !ok {
+		return nil, fmt.Errorf("%w: pull request %d does not exist in repository %s", ErrNotFound, prNumber, repositoryName)
+	}
+	return pr, nil
+}
+
+// moveBranch points the branch to sha and updates head of open pull requests from the branch
+func (r *repository) moveBranch(branchName, sha string) {
+	r.branches[branchName] = sha
+	for _, pr := range r.pullRequests {
+		if pr.State == PullRequestOpen && pr.SourceBranch == branchName {
+			pr.HeadSHA = sha
+		}
+	}
+}
+
+func copyFiles(files map[string]string) map[string]string {
+	copied := make(map[string]string, len(files))
+	for path, content := range files {
+		copied[path] = content
+	}
+	return copied
+}
+
+func copyCommit(commit *Commit) *Commit {
+	copied := *commit
+	copied.Parents = append([]string{}, commit.Parents...)
+	copied.Files = copyFiles(commit.Files)
+	return &copied
+}
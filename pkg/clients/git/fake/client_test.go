@@ -0,0 +1,180 @@
+package fake
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/konflux-ci/e2e-tests/pkg/clients/git"
+)
+
+func TestPullRequestFlow(t *testing.T) {
+	c := NewClient()
+	initial := c.CreateRepository("repo", "main", map[string]string{"README.md": "hello"})
+
+	assert.NoError(t, c.CreateBranch("repo", "main", "", "feature"))
+	exists, err := c.BranchExists("repo", "feature")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	pr, err := c.CreatePullRequest("repo", "title", "body", "feature", "main")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pr.Number)
+	assert.Equal(t, initial, pr.HeadSHA)
+
+	file, err := c.CreateFile("repo", ".tekton/pr.yaml", "kind: PipelineRun", "feature")
+	assert.NoError(t, err)
+	prs, err := c.ListPullRequests("repo")
+	assert.NoError(t, err)
+	assert.Len(t, prs, 1)
+	assert.Equal(t, file.CommitSHA, prs[0].HeadSHA)
+
+	_, err = c.GetFile("repo", ".tekton/pr.yaml", "main")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	merged, err := c.MergePullRequest("repo", pr.Number)
+	assert.NoError(t, err)
+	head, err := c.BranchHead("repo", "main")
+	assert.NoError(t, err)
+	assert.Equal(t, head, merged.MergeCommitSHA)
+
+	commit, err := c.Commit("repo", head)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{initial, file.CommitSHA}, commit.Parents)
+	assert.Equal(t, map[string]string{"README.md": "hello", ".tekton/pr.yaml": "kind: PipelineRun"}, commit.Files)
+
+	_, err = c.MergePullRequest("repo", pr.Number)
+	assert.ErrorContains(t, err, "is merged")
+
+	prs, err = c.ListPullRequests("repo")
+	assert.NoError(t, err)
+	assert.Empty(t, prs)
+}
+
+func TestMergePullRequestAppliesOnlyBranchChanges(t *testing.T) {
+	c := NewClient()
+	c.CreateRepository("repo", "main", map[string]string{"a": "1", "removed": "x", "untouched": "u"})
+	assert.NoError(t, c.CreateBranch("repo", "main", "", "feature"))
+
+	// target branch moves on after the branch point
+	_, err := c.UpdateFile("repo", "a", "2", "main")
+	assert.NoError(t, err)
+	_, err = c.CreateFile("repo", "c", "3", "main")
+	assert.NoError(t, err)
+
+	_, err = c.CreateFile("repo", "b", "added", "feature")
+	assert.NoError(t, err)
+	assert.NoError(t, c.DeleteFile("repo", "removed", "feature"))
+
+	pr, err := c.CreatePullRequest("repo", "title", "body", "feature", "main")
+	assert.NoError(t, err)
+	merged, err := c.MergePullRequest("repo", pr.Number)
+	assert.NoError(t, err)
+
+	commit, err := c.Commit("repo", merged.MergeCommitSHA)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "2", "b": "added", "c": "3", "untouched": "u"}, commit.Files)
+}
+
+func TestCreateBranchFromRevision(t *testing.T) {
+	c := NewClient()
+	initial := c.CreateRepository("repo", "main", nil)
+	_, err := c.CreateFile("repo", "a.txt", "a", "main")
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.CreateBranch("repo", "main", initial, "old"))
+	_, err = c.GetFile("repo", "a.txt", "old")
+	assert.ErrorContains(t, err, "404")
+
+	assert.ErrorContains(t, c.CreateBranch("repo", "main", "", "old"), "already exists")
+	assert.ErrorContains(t, c.CreateBranch("repo", "main", "unknown", "new"), "404")
+	assert.ErrorContains(t, c.DeleteBranch("repo", "missing"), "Reference does not exist")
+}
+
+func TestDeleteBranchAndClosePullRequest(t *testing.T) {
+	c := NewClient()
+	c.CreateRepository("repo", "main", nil)
+	assert.NoError(t, c.CreateBranch("repo", "main", "", "feature"))
+	pr, err := c.CreatePullRequest("repo", "title", "", "feature", "main")
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.DeleteBranchAndClosePullRequest("repo", pr.Number))
+	closed, err := c.GetPullRequest("repo", pr.Number)
+	assert.NoError(t, err)
+	assert.Equal(t, PullRequestClosed, closed.State)
+	exists, err := c.BranchExists("repo", "feature")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestCleanupWebhooks(t *testing.T) {
+	c := NewClient()
+	c.CreateRepository("repo", "main", nil)
+	_, err := c.AddWebhook("repo", "https://pac.apps.cluster-a.example.com")
+	assert.NoError(t, err)
+	kept, err := c.AddWebhook("repo", "https://pac.apps.cluster-b.example.com")
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.CleanupWebhooks("repo", "cluster-a.example.com"))
+	hooks, err := c.Webhooks("repo")
+	assert.NoError(t, err)
+	assert.Equal(t, []Webhook{{ID: kept, URL: "https://pac.apps.cluster-b.example.com"}}, hooks)
+}
+
+func TestInjectErrorAndCalls(t *testing.T) {
+	c := NewClient()
+	c.CreateRepository("repo", "main", nil)
+
+	injected := errors.New("rate limited")
+	c.InjectError("CreateBranch", injected)
+	assert.Equal(t, injected, c.CreateBranch("repo", "main", "", "feature"))
+	exists, err := c.BranchExists("repo", "feature")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	c.InjectError("CreateBranch", nil)
+	assert.NoError(t, c.CreateBranch("repo", "main", "", "feature"))
+
+	assert.Equal(t, []Call{
+		{Method: "CreateBranch", Args: []interface{}{"repo", "main", "", "feature"}},
+		{Method: "CreateBranch", Args: []interface{}{"repo", "main", "", "feature"}},
+	}, c.Calls("CreateBranch"))
+	assert.Len(t, c.Calls(""), 3)
+
+	c.ResetCalls()
+	assert.Empty(t, c.Calls(""))
+}
+
+func TestFileUpdatesCommentsAndStatuses(t *testing.T) {
+	c := NewClient()
+	c.CreateRepository("repo", "main", map[string]string{"a.txt": "a"})
+
+	updated, err := c.UpdateFile("repo", "a.txt", "b", "main")
+	assert.NoError(t, err)
+	file, err := c.GetFile("repo", "a.txt", "main")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", file.Content)
+	assert.Equal(t, updated.CommitSHA, file.CommitSHA)
+
+	assert.NoError(t, c.DeleteFile("repo", "a.txt", "main"))
+	assert.ErrorContains(t, c.DeleteFile("repo", "a.txt", "main"), "404")
+	_, err = c.UpdateFile("repo", "a.txt", "c", "main")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	assert.NoError(t, c.CreateBranch("repo", "main", "", "feature"))
+	pr, err := c.CreatePullRequest("repo", "title", "", "feature", "main")
+	assert.NoError(t, err)
+	_, err = c.AddPullRequestComment("repo", pr.Number, "bot", "passed")
+	assert.NoError(t, err)
+	comments, err := c.ListPullRequestComments("repo", pr.Number)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "bot", comments[0].Author)
+
+	assert.NoError(t, c.SetCommitStatus("repo", pr.HeadSHA, git.CommitStatus{Name: "test", State: git.CommitStatusRunning}))
+	assert.NoError(t, c.SetCommitStatus("repo", pr.HeadSHA, git.CommitStatus{Name: "test", State: git.CommitStatusSuccess}))
+	statuses, err := c.ListCommitStatuses("repo", pr.HeadSHA)
+	assert.NoError(t, err)
+	assert.Equal(t, []*git.CommitStatus{{Name: "test", State: git.CommitStatusSuccess}}, statuses)
+}
@@ -1,11 +1,14 @@
 package git
 
+import "time"
+
 // GitProvider is an enum representing possible Git providers
 type GitProvider int
 
 const (
 	GitHubProvider GitProvider = iota
 	GitLabProvider
+	GiteaProvider
 )
 
 // PullRequest represents a generic provider-agnostic pull/merge request
@@ -29,6 +32,45 @@ type RepositoryFile struct {
 	Content string
 }
 
+// PullRequestComment represents a generic provider-agnostic comment (GitLab note) of a pull/merge request
+type PullRequestComment struct {
+	ID int64
+	// Author is the login of the user who created the comment
+	Author    string
+	Body      string
+	CreatedAt time.Time
+}
+
+// CommitStatusState is a provider-agnostic state of a GitHub CheckRun or GitLab/Gitea commit status
+type CommitStatusState string
+
+const (
+	CommitStatusPending   CommitStatusState = "pending"
+	CommitStatusRunning   CommitStatusState = "running"
+	CommitStatusSuccess   CommitStatusState = "success"
+	CommitStatusFailure   CommitStatusState = "failure"
+	CommitStatusCancelled CommitStatusState = "cancelled"
+	CommitStatusSkipped   CommitStatusState = "skipped"
+)
+
+// IsFinal returns true if the state will not change anymore
+func (s CommitStatusState) IsFinal() bool {
+	return s != CommitStatusPending && s != CommitStatusRunning
+}
+
+// CommitStatus represents a generic provider-agnostic status reported for a commit,
+// i.e. a CheckRun on GitHub or a commit status on GitLab and Gitea
+type CommitStatus struct {
+	// Name is the name of the CheckRun or the name (context) of the commit status
+	Name  string
+	State CommitStatusState
+	// Description is the title of the CheckRun output or description of the commit status
+	Description string
+	// Text is the detailed text of the CheckRun output, it is empty for other providers
+	Text      string
+	TargetURL string
+}
+
 type Client interface {
 	CreateBranch(repository, baseBranchName, revision, branchName string) error
 	DeleteBranch(repository, branchName string) error
@@ -36,8 +78,13 @@ type Client interface {
 	ListPullRequests(repository string) ([]*PullRequest, error)
 	CreateFile(repository, pathToFile, content, branchName string) (*RepositoryFile, error)
 	GetFile(repository, pathToFile, branchName string) (*RepositoryFile, error)
+	UpdateFile(repository, pathToFile, content, branchName string) (*RepositoryFile, error)
+	DeleteFile(repository, pathToFile, branchName string) error
 	CreatePullRequest(repository, title, body, head, base string) (*PullRequest, error)
 	MergePullRequest(repository string, prNumber int) (*PullRequest, error)
 	DeleteBranchAndClosePullRequest(repository string, prNumber int) error
 	CleanupWebhooks(repository, clusterAppDomain string) error
+	ListPullRequestComments(repository string, prNumber int) ([]*PullRequestComment, error)
+	// ListCommitStatuses lists the latest status of each CheckRun/commit status reported for the ref (a commit SHA)
+	ListCommitStatuses(repository, ref string) ([]*CommitStatus, error)
 }
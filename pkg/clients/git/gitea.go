@@ -0,0 +1,173 @@
+package git
+
+import (
+	"fmt"
+
+	"github.com/konflux-ci/e2e-tests/pkg/clients/gitea"
+)
+
+type GiteaClient struct {
+	*gitea.GiteaClient
+}
+
+func NewGiteaClient(gt *gitea.GiteaClient) *GiteaClient {
+	return &GiteaClient{gt}
+}
+
+func (g *GiteaClient) CreateBranch(repository, baseBranchName, revision, branchName string) error {
+	return g.GiteaClient.CreateBranch(repository, branchName, baseBranchName, revision)
+}
+
+func (g *GiteaClient) BranchExists(repository, branchName string) (bool, error) {
+	return g.ExistsBranch(repository, branchName)
+}
+
+func (g *GiteaClient) ListPullRequests(repository string) ([]*PullRequest, error) {
+	prs, err := g.GiteaClient.ListPullRequests(repository)
+	if err != nil {
+		return nil, err
+	}
+	var pullRequests []*PullRequest
+	for _, pr := range prs {
+		pullRequests = append(pullRequests, giteaPullRequest(pr))
+	}
+	return pullRequests, nil
+}
+
+func (g *GiteaClient) CreateFile(repository, pathToFile, content, branchName string) (*RepositoryFile, error) {
+	file, err := g.GiteaClient.CreateFile(repository, pathToFile, content, branchName)
+	if err != nil {
+		return nil, err
+	}
+	return &RepositoryFile{
+		CommitSHA: file.Commit.SHA,
+		Content:   content,
+	}, nil
+}
+
+func (g *GiteaClient) GetFile(repository, pathToFile, branchName string) (*RepositoryFile, error) {
+	file, err := g.GiteaClient.GetFile(repository, pathToFile, branchName)
+	if err != nil {
+		return nil, err
+	}
+	content, err := file.DecodeContent()
+	if err != nil {
+		return nil, err
+	}
+	commitSHA := file.LastCommitSHA
+	// older Gitea versions do not report the last commit of the file, the branch head contains it too
+	if commitSHA == "" {
+		branch, err := g.GetBranch(repository, branchName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve commit of file %s in repository %s: %v", pathToFile, repository, err)
+		}
+		if branch.Commit.ID == "" {
+			return nil, fmt.Errorf("failed to resolve commit of file %s in repository %s: branch %s has no commit", pathToFile, repository, branchName)
+		}
+		commitSHA = branch.Commit.ID
+	}
+	return &RepositoryFile{
+		CommitSHA: commitSHA,
+		Content:   content,
+	}, nil
+}
+
+func (g *GiteaClient) CreatePullRequest(repository, title, body, head, base string) (*PullRequest, error) {
+	pr, err := g.GiteaClient.CreatePullRequest(repository, title, body, head, base)
+	if err != nil {
+		return nil, err
+	}
+	return giteaPullRequest(pr), nil
+}
+
+func (g *GiteaClient) MergePullRequest(repository string, prNumber int) (*PullRequest, error) {
+	pr, err := g.GiteaClient.MergePullRequest(repository, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	return giteaPullRequest(pr), nil
+}
+
+func (g *GiteaClient) DeleteBranchAndClosePullRequest(repository string, prNumber int) error {
+	pr, err := g.GetPullRequest(repository, prNumber)
+	if err != nil {
+		return err
+	}
+	err = g.DeleteBranch(repository, pr.Head.Ref)
+	if err != nil {
+		return err
+	}
+	return g.ClosePullRequest(repository, prNumber)
+}
+
+func (g *GiteaClient) CleanupWebhooks(repository, clusterAppDomain string) error {
+	return g.DeleteWebhooks(repository, clusterAppDomain)
+}
+
+func giteaPullRequest(pr *gitea.PullRequest) *PullRequest {
+	return &PullRequest{
+		Number:         pr.Number,
+		SourceBranch:   pr.Head.Ref,
+		TargetBranch:   pr.Base.Ref,
+		HeadSHA:        pr.Head.SHA,
+		MergeCommitSHA: pr.MergeCommitSHA,
+	}
+}
+
+func (g *GiteaClient) UpdateFile(repository, pathToFile, content, branchName string) (*RepositoryFile, error) {
+	file, err := g.GiteaClient.UpdateFile(repository, pathToFile, content, branchName)
+	if err != nil {
+		return nil, err
+	}
+	return &RepositoryFile{
+		CommitSHA: file.Commit.SHA,
+		Content:   content,
+	}, nil
+}
+
+func (g *GiteaClient) ListPullRequestComments(repository string, prNumber int) ([]*PullRequestComment, error) {
+	comments, err := g.GiteaClient.ListPullRequestComments(repository, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	var result []*PullRequestComment
+	for _, comment := range comments {
+		result = append(result, &PullRequestComment{
+			ID:        comment.ID,
+			Author:    comment.User.Login,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+func (g *GiteaClient) ListCommitStatuses(repository, ref string) ([]*CommitStatus, error) {
+	combined, err := g.GetCombinedStatus(repository, ref)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []*CommitStatus
+	for _, cs := range combined.Statuses {
+		statuses = append(statuses, &CommitStatus{
+			Name:        cs.Context,
+			State:       giteaCommitStatusState(cs.Status),
+			Description: cs.Description,
+			TargetURL:   cs.TargetURL,
+		})
+	}
+	return statuses, nil
+}
+
+// giteaCommitStatusState maps Gitea commit status to CommitStatusState
+func giteaCommitStatusState(status string) CommitStatusState {
+	switch status {
+	case "pending":
+		return CommitStatusPending
+	case "success":
+		return CommitStatusSuccess
+	default:
+		// error, failure, warning
+		return CommitStatusFailure
+	}
+}
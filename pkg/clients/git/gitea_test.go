@@ -0,0 +1,38 @@
+package git
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/konflux-ci/e2e-tests/pkg/clients/gitea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGiteaGetFileWithoutLastCommit(t *testing.T) {
+	branchHead := "head"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/repos/qe/repo/contents/file.yaml":
+			// older Gitea versions report only the blob SHA
+			_, _ = w.Write([]byte(`{"path":"file.yaml","sha":"blob","encoding":"base64","content":"aGVsbG8="}`))
+		case "/api/v1/repos/qe/repo/branches/main":
+			_, _ = w.Write([]byte(`{"name":"main","commit":{"id":"` + branchHead + `"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	gt, err := gitea.NewGiteaClient("", server.URL, "qe", false)
+	assert.NoError(t, err)
+	client := NewGiteaClient(gt)
+
+	file, err := client.GetFile("repo", "file.yaml", "main")
+	assert.NoError(t, err)
+	assert.Equal(t, "head", file.CommitSHA)
+	assert.Equal(t, "hello", file.Content)
+
+	branchHead = ""
+	_, err = client.GetFile("repo", "file.yaml", "main")
+	assert.EqualError(t, err, "failed to resolve commit of file file.yaml in repository repo: branch main has no commit")
+}
@@ -3,6 +3,7 @@ package git
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/konflux-ci/e2e-tests/pkg/clients/github"
 )
@@ -125,3 +126,79 @@ func (g *GitHubClient) DeleteBranchAndClosePullRequest(repository string, prNumb
 	}
 	return err
 }
+
+func (g *GitHubClient) UpdateFile(repository, pathToFile, content, branchName string) (*RepositoryFile, error) {
+	contents, err := g.Github.GetFile(repository, pathToFile, branchName)
+	if err != nil {
+		return nil, err
+	}
+	file, err := g.Github.UpdateFile(repository, pathToFile, content, branchName, contents.GetSHA())
+	if err != nil {
+		return nil, err
+	}
+	resultFile := &RepositoryFile{
+		CommitSHA: file.GetSHA(),
+		Content:   content,
+	}
+	return resultFile, nil
+}
+
+func (g *GitHubClient) DeleteFile(repository, pathToFile, branchName string) error {
+	return g.Github.DeleteFile(repository, pathToFile, branchName)
+}
+
+func (g *GitHubClient) ListPullRequestComments(repository string, prNumber int) ([]*PullRequestComment, error) {
+	comments, err := g.Github.ListPullRequestCommentsSince(repository, prNumber, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	var result []*PullRequestComment
+	for _, comment := range comments {
+		result = append(result, &PullRequestComment{
+			ID:        comment.GetID(),
+			Author:    comment.GetUser().GetLogin(),
+			Body:      comment.GetBody(),
+			CreatedAt: comment.GetCreatedAt(),
+		})
+	}
+	return result, nil
+}
+
+func (g *GitHubClient) ListCommitStatuses(repository, ref string) ([]*CommitStatus, error) {
+	checkRuns, err := g.Github.ListCheckRuns(repository, ref)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []*CommitStatus
+	for _, cr := range checkRuns {
+		statuses = append(statuses, &CommitStatus{
+			Name:        cr.GetName(),
+			State:       checkRunState(cr.GetStatus(), cr.GetConclusion()),
+			Description: cr.GetOutput().GetTitle(),
+			Text:        cr.GetOutput().GetText(),
+			TargetURL:   cr.GetDetailsURL(),
+		})
+	}
+	return statuses, nil
+}
+
+// checkRunState maps status and conclusion of GitHub CheckRun to CommitStatusState
+func checkRunState(status, conclusion string) CommitStatusState {
+	switch status {
+	case "queued", "pending", "requested", "waiting":
+		return CommitStatusPending
+	case "in_progress":
+		return CommitStatusRunning
+	}
+	switch conclusion {
+	case "success":
+		return CommitStatusSuccess
+	case "cancelled", "stale":
+		return CommitStatusCancelled
+	case "neutral", "skipped":
+		return CommitStatusSkipped
+	default:
+		// failure, timed_out, action_required
+		return CommitStatusFailure
+	}
+}
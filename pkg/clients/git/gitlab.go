@@ -124,3 +124,78 @@ func (g *GitLabClient) DeleteBranchAndClosePullRequest(repository string, prNumb
 	}
 	return g.CloseMergeRequest(repository, prNumber)
 }
+
+func (g *GitLabClient) UpdateFile(repository, pathToFile, content, branchName string) (*RepositoryFile, error) {
+	commitID, err := g.GitlabClient.UpdateFile(repository, pathToFile, content, branchName)
+	if err != nil {
+		return nil, err
+	}
+	resultFile := &RepositoryFile{
+		CommitSHA: commitID,
+		Content:   content,
+	}
+	return resultFile, nil
+}
+
+func (g *GitLabClient) DeleteFile(repository, pathToFile, branchName string) error {
+	return g.GitlabClient.DeleteFile(repository, pathToFile, branchName)
+}
+
+// ListPullRequestComments lists notes of the merge request, notes generated by GitLab itself are skipped
+func (g *GitLabClient) ListPullRequestComments(repository string, prNumber int) ([]*PullRequestComment, error) {
+	notes, err := g.GetMergeRequestNotes(repository, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	var comments []*PullRequestComment
+	for _, note := range notes {
+		if note.System {
+			continue
+		}
+		comment := &PullRequestComment{
+			ID:     int64(note.ID),
+			Author: note.Author.Username,
+			Body:   note.Body,
+		}
+		if note.CreatedAt != nil {
+			comment.CreatedAt = *note.CreatedAt
+		}
+		comments = append(comments, comment)
+	}
+	return comments, nil
+}
+
+func (g *GitLabClient) ListCommitStatuses(repository, ref string) ([]*CommitStatus, error) {
+	commitStatuses, err := g.GetCommitStatuses(repository, ref)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []*CommitStatus
+	for _, cs := range commitStatuses {
+		statuses = append(statuses, &CommitStatus{
+			Name:        cs.Name,
+			State:       gitlabCommitStatusState(cs.Status),
+			Description: cs.Description,
+			TargetURL:   cs.TargetURL,
+		})
+	}
+	return statuses, nil
+}
+
+// gitlabCommitStatusState maps GitLab commit status to CommitStatusState
+func gitlabCommitStatusState(status string) CommitStatusState {
+	switch status {
+	case "created", "pending", "manual", "scheduled", "waiting_for_resource", "preparing":
+		return CommitStatusPending
+	case "running":
+		return CommitStatusRunning
+	case "success":
+		return CommitStatusSuccess
+	case "canceled":
+		return CommitStatusCancelled
+	case "skipped":
+		return CommitStatusSkipped
+	default:
+		return CommitStatusFailure
+	}
+}
@@ -0,0 +1,59 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/konflux-ci/e2e-tests/pkg/utils"
+)
+
+// WaitForCommitStatus waits until a CheckRun/commit status whose name contains statusName is reported
+// for the ref and reaches a final state, and returns it
+func WaitForCommitStatus(c Client, repository, ref, statusName string, timeout time.Duration) (*CommitStatus, error) {
+	var found *CommitStatus
+	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
+		statuses, err := c.ListCommitStatuses(repository, ref)
+		if err != nil {
+			fmt.Printf("got error when listing commit statuses of %s in %s: %+v\n", ref, repository, err)
+			return false, nil
+		}
+		for _, status := range statuses {
+			if strings.Contains(status.Name, statusName) {
+				found = status
+				return status.State.IsFinal(), nil
+			}
+		}
+		return false, nil
+	}, time.Second*5, timeout)
+	if err != nil {
+		if found != nil {
+			return found, fmt.Errorf("timed out when waiting for commit status %s of %s in %s to finish, last state: %s", statusName, ref, repository, found.State)
+		}
+		return nil, fmt.Errorf("timed out when waiting for commit status %s to be reported for %s in %s", statusName, ref, repository)
+	}
+	return found, nil
+}
+
+// WaitForPullRequestComment waits until a comment containing expectedText is added to the pull request and returns it
+func WaitForPullRequestComment(c Client, repository string, prNumber int, expectedText string, timeout time.Duration) (*PullRequestComment, error) {
+	var found *PullRequestComment
+	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
+		comments, err := c.ListPullRequestComments(repository, prNumber)
+		if err != nil {
+			fmt.Printf("got error when listing comments of pull request %d in %s: %+v\n", prNumber, repository, err)
+			return false, nil
+		}
+		for _, comment := range comments {
+			if strings.Contains(comment.Body, expectedText) {
+				found = comment
+				return true, nil
+			}
+		}
+		return false, nil
+	}, time.Second*2, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("timed out when waiting for comment '%s' in pull request %d of %s", expectedText, prNumber, repository)
+	}
+	return found, nil
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// statusClient implements only the methods needed by WaitForCommitStatus and WaitForPullRequestComment
+type statusClient struct {
+	Client
+	statuses []*CommitStatus
+	comments []*PullRequestComment
+}
+
+func (c *statusClient) ListCommitStatuses(string, string) ([]*CommitStatus, error) {
+	return c.statuses, nil
+}
+
+func (c *statusClient) ListPullRequestComments(string, int) ([]*PullRequestComment, error) {
+	return c.comments, nil
+}
+
+func TestCommitStatusStates(t *testing.T) {
+	assert.Equal(t, CommitStatusPending, checkRunState("queued", ""))
+	assert.Equal(t, CommitStatusRunning, checkRunState("in_progress", ""))
+	assert.Equal(t, CommitStatusSuccess, checkRunState("completed", "success"))
+	assert.Equal(t, CommitStatusFailure, checkRunState("completed", "timed_out"))
+	assert.Equal(t, CommitStatusSkipped, checkRunState("completed", "neutral"))
+
+	assert.Equal(t, CommitStatusPending, gitlabCommitStatusState("created"))
+	assert.Equal(t, CommitStatusFailure, gitlabCommitStatusState("failed"))
+	assert.Equal(t, CommitStatusCancelled, gitlabCommitStatusState("canceled"))
+
+	assert.Equal(t, CommitStatusFailure, giteaCommitStatusState("error"))
+
+	assert.False(t, CommitStatusRunning.IsFinal())
+	assert.True(t, CommitStatusCancelled.IsFinal())
+}
+
+func TestWaitForCommitStatus(t *testing.T) {
+	c := &statusClient{statuses: []*CommitStatus{
+		{Name: "Red Hat Konflux / app-pass / comp", State: CommitStatusSuccess},
+		{Name: "Red Hat Konflux / app-fail / comp", State: CommitStatusRunning},
+	}}
+
+	status, err := WaitForCommitStatus(c, "repo", "sha", "app-pass", time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, CommitStatusSuccess, status.State)
+
+	status, err = WaitForCommitStatus(c, "repo", "sha", "app-fail", time.Millisecond*100)
+	assert.ErrorContains(t, err, "last state: running")
+	assert.Equal(t, CommitStatusRunning, status.State)
+
+	_, err = WaitForCommitStatus(c, "repo", "sha", "missing", time.Millisecond*100)
+	assert.ErrorContains(t, err, "to be reported")
+}
+
+func TestWaitForPullRequestComment(t *testing.T) {
+	c := &statusClient{comments: []*PullRequestComment{{ID: 1, Body: "Integration test for snapshot s1 and scenario app-pass has passed"}}}
+
+	comment, err := WaitForPullRequestComment(c, "repo", 1, "app-pass has passed", time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), comment.ID)
+
+	_, err = WaitForPullRequestComment(c, "repo", 1, "app-fail has failed", time.Millisecond*100)
+	assert.Error(t, err)
+}
@@ -0,0 +1,131 @@
+package gitea
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GiteaClient talks to Gitea (or its fork Forgejo) REST API, e.g. of a git server running
+// inside the test cluster. Repositories are referenced as "owner/name", plain "name"
+// refers to repository of the client organization.
+type GiteaClient struct {
+	baseURL      string
+	token        string
+	organization string
+	httpClient   *http.Client
+}
+
+// APIError is returned when Gitea API responds with unexpected status
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gitea API %s %s returned status %d: %s", e.Method, e.URL, e.StatusCode, e.Body)
+}
+
+// IsNotFound checks if error is Gitea API error with status 404
+func IsNotFound(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// NewGiteaClient creates client for Gitea instance at baseUrl (e.g. http://gitea.gitea.svc:3000).
+// TLS verification can be skipped for self-hosted instances using self-signed certificates.
+func NewGiteaClient(accessToken, baseUrl, organization string, insecureSkipVerify bool) (*GiteaClient, error) {
+	if baseUrl == "" {
+		return nil, fmt.Errorf("gitea base URL cannot be empty")
+	}
+	if _, err := url.Parse(baseUrl); err != nil {
+		return nil, fmt.Errorf("invalid gitea base URL %s: %v", baseUrl, err)
+	}
+	return &GiteaClient{
+		baseURL:      strings.TrimSuffix(baseUrl, "/"),
+		token:        accessToken,
+		organization: organization,
+		httpClient: &http.Client{
+			Timeout: time.Minute,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					// #nosec G402 -- only skipped when explicitly requested
+					InsecureSkipVerify: insecureSkipVerify,
+				},
+			},
+		},
+	}, nil
+}
+
+// BaseURL returns URL of the Gitea instance
+func (gc *GiteaClient) BaseURL() string {
+	return gc.baseURL
+}
+
+// Organization returns organization repositories without owner belong to
+func (gc *GiteaClient) Organization() string {
+	return gc.organization
+}
+
+// repoPath returns API path of given repository, "name" is expanded to "organization/name"
+func (gc *GiteaClient) repoPath(repository string) string {
+	if !strings.Contains(repository, "/") {
+		repository = gc.organization + "/" + repository
+	}
+	return "/repos/" + repository
+}
+
+// do sends request to Gitea API and decodes JSON response to result (unless it is nil)
+func (gc *GiteaClient) do(method, path string, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	requestURL := gc.baseURL + "/api/v1" + path
+	req, err := http.NewRequest(method, requestURL, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if gc.token != "" {
+		req.Header.Set("Authorization", "token "+gc.token)
+	}
+
+	res, err := gc.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response of %s %s: %v", method, requestURL, err)
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return &APIError{Method: method, URL: requestURL, StatusCode: res.StatusCode, Body: string(data)}
+	}
+
+	if result == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("failed to decode response of %s %s: %v", method, requestURL, err)
+	}
+	return nil
+}
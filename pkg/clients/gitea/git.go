@@ -0,0 +1,344 @@
+package gitea
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type Commit struct {
+	SHA string `json:"sha"`
+}
+
+type Branch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+type PullRequestBranch struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+type PullRequest struct {
+	Number         int               `json:"number"`
+	Title          string            `json:"title"`
+	State          string            `json:"state"`
+	Merged         bool              `json:"merged"`
+	MergeCommitSHA string            `json:"merge_commit_sha"`
+	Head           PullRequestBranch `json:"head"`
+	Base           PullRequestBranch `json:"base"`
+}
+
+type ContentsResponse struct {
+	Path          string `json:"path"`
+	SHA           string `json:"sha"`
+	LastCommitSHA string `json:"last_commit_sha"`
+	Content       string `json:"content"`
+	Encoding      string `json:"encoding"`
+}
+
+type FileResponse struct {
+	Content ContentsResponse `json:"content"`
+	Commit  Commit           `json:"commit"`
+}
+
+type User struct {
+	Login string `json:"login"`
+}
+
+type Comment struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	User      User      `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CommitStatus struct {
+	Context     string `json:"context"`
+	Status      string `json:"status"`
+	Description string `json:"description"`
+	TargetURL   string `json:"target_url"`
+}
+
+type CombinedStatus struct {
+	State    string          `json:"state"`
+	SHA      string          `json:"sha"`
+	Statuses []*CommitStatus `json:"statuses"`
+}
+
+type Hook struct {
+	ID     int64             `json:"id"`
+	Type   string            `json:"type"`
+	Active bool              `json:"active"`
+	Events []string          `json:"events"`
+	Config map[string]string `json:"config"`
+}
+
+// CreateBranch creates branch from given revision or, if it is empty, from the base branch
+func (gc *GiteaClient) CreateBranch(repository, newBranchName, baseBranch, revision string) error {
+	body := map[string]string{"new_branch_name": newBranchName}
+	if revision != "" {
+		body["old_ref_name"] = revision
+	} else {
+		body["old_branch_name"] = baseBranch
+	}
+	if err := gc.do(http.MethodPost, gc.repoPath(repository)+"/branches", body, nil); err != nil {
+		return fmt.Errorf("failed to create branch %s in repository %s: %v", newBranchName, repository, err)
+	}
+	return nil
+}
+
+func (gc *GiteaClient) GetBranch(repository, branchName string) (*Branch, error) {
+	branch := &Branch{}
+	if err := gc.do(http.MethodGet, gc.repoPath(repository)+"/branches/"+url.PathEscape(branchName), nil, branch); err != nil {
+		return nil, err
+	}
+	return branch, nil
+}
+
+func (gc *GiteaClient) ExistsBranch(repository, branchName string) (bool, error) {
+	_, err := gc.GetBranch(repository, branchName)
+	if IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (gc *GiteaClient) DeleteBranch(repository, branchName string) error {
+	err := gc.do(http.MethodDelete, gc.repoPath(repository)+"/branches/"+url.PathEscape(branchName), nil, nil)
+	if err != nil && !IsNotFound(err) {
+		return fmt.Errorf("failed to delete branch %s in repository %s: %v", branchName, repository, err)
+	}
+	return nil
+}
+
+// Page size of listings, the default maximum page size of Gitea and Forgejo
+const pageLimit = 50
+
+// ListPullRequests lists open pull requests
+func (gc *GiteaClient) ListPullRequests(repository string) ([]*PullRequest, error) {
+	prs := []*PullRequest{}
+	for page := 1; ; page++ {
+		batch := []*PullRequest{}
+		if err := gc.do(http.MethodGet, fmt.Sprintf("%s/pulls?state=open&limit=%d&page=%d", gc.repoPath(repository), pageLimit, page), nil, &batch); err != nil {
+			return nil, fmt.Errorf("failed to list pull requests in repository %s: %v", repository, err)
+		}
+		prs = append(prs, batch...)
+		if len(batch) < pageLimit {
+			return prs, nil
+		}
+	}
+}
+
+func (gc *GiteaClient) GetPullRequest(repository string, prNumber int) (*PullRequest, error) {
+	pr := &PullRequest{}
+	if err := gc.do(http.MethodGet, fmt.Sprintf("%s/pulls/%d", gc.repoPath(repository), prNumber), nil, pr); err != nil {
+		return nil, fmt.Errorf("failed to get pull request %d in repository %s: %v", prNumber, repository, err)
+	}
+	return pr, nil
+}
+
+func (gc *GiteaClient) CreatePullRequest(repository, title, body, head, base string) (*PullRequest, error) {
+	pr := &PullRequest{}
+	request := map[string]string{"title": title, "body": body, "head": head, "base": base}
+	if err := gc.do(http.MethodPost, gc.repoPath(repository)+"/pulls", request, pr); err != nil {
+		return nil, fmt.Errorf("failed to create pull request from %s to %s in repository %s: %v", head, base, repository, err)
+	}
+	return pr, nil
+}
+
+// MergePullRequest merges pull request with merge commit and returns it as merged
+func (gc *GiteaClient) MergePullRequest(repository string, prNumber int) (*PullRequest, error) {
+	request := map[string]string{"Do": "merge"}
+	if err := gc.do(http.MethodPost, fmt.Sprintf("%s/pulls/%d/merge", gc.repoPath(repository), prNumber), request, nil); err != nil {
+		return nil, fmt.Errorf("failed to merge pull request %d in repository %s: %v", prNumber, repository, err)
+	}
+	return gc.GetPullRequest(repository, prNumber)
+}
+
+func (gc *GiteaClient) ClosePullRequest(repository string, prNumber int) error {
+	request := map[string]string{"state": "closed"}
+	if err := gc.do(http.MethodPatch, fmt.Sprintf("%s/pulls/%d", gc.repoPath(repository), prNumber), request, nil); err != nil {
+		return fmt.Errorf("failed to close pull request %d in repository %s: %v", prNumber, repository, err)
+	}
+	return nil
+}
+
+func (gc *GiteaClient) CreateFile(repository, pathToFile, content, branchName string) (*FileResponse, error) {
+	file := &FileResponse{}
+	request := map[string]string{
+		"content": base64.StdEncoding.EncodeToString([]byte(content)),
+		"branch":  branchName,
+		"message": "e2e test commit message",
+	}
+	if err := gc.do(http.MethodPost, gc.repoPath(repository)+"/contents/"+escapePath(pathToFile), request, file); err != nil {
+		return nil, fmt.Errorf("failed to create file %s in repository %s: %v", pathToFile, repository, err)
+	}
+	return file, nil
+}
+
+// UpdateFile replaces content of existing file, sha of its current version is looked up
+func (gc *GiteaClient) UpdateFile(repository, pathToFile, content, branchName string) (*FileResponse, error) {
+	current, err := gc.GetFile(repository, pathToFile, branchName)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &FileResponse{}
+	request := map[string]string{
+		"content": base64.StdEncoding.EncodeToString([]byte(content)),
+		"branch":  branchName,
+		"sha":     current.SHA,
+		"message": "e2e test commit message",
+	}
+	if err := gc.do(http.MethodPut, gc.repoPath(repository)+"/contents/"+escapePath(pathToFile), request, file); err != nil {
+		return nil, fmt.Errorf("failed to update file %s in repository %s: %v", pathToFile, repository, err)
+	}
+	return file, nil
+}
+
+func (gc *GiteaClient) DeleteFile(repository, pathToFile, branchName string) error {
+	current, err := gc.GetFile(repository, pathToFile, branchName)
+	if err != nil {
+		return err
+	}
+
+	request := map[string]string{
+		"branch":  branchName,
+		"sha":     current.SHA,
+		"message": "delete test files",
+	}
+	if err := gc.do(http.MethodDelete, gc.repoPath(repository)+"/contents/"+escapePath(pathToFile), request, nil); err != nil {
+		return fmt.Errorf("failed to delete file %s in repository %s: %v", pathToFile, repository, err)
+	}
+	return nil
+}
+
+// GetFile returns file with its content base64 encoded, see DecodeContent
+func (gc *GiteaClient) GetFile(repository, pathToFile, branchName string) (*ContentsResponse, error) {
+	file := &ContentsResponse{}
+	path := gc.repoPath(repository) + "/contents/" + escapePath(pathToFile) + "?ref=" + url.QueryEscape(branchName)
+	if err := gc.do(http.MethodGet, path, nil, file); err != nil {
+		return nil, fmt.Errorf("failed to get file %s from branch %s of repository %s: %v", pathToFile, branchName, repository, err)
+	}
+	return file, nil
+}
+
+// DecodeContent returns decoded content of the file
+func (c *ContentsResponse) DecodeContent() (string, error) {
+	if c.Encoding != "" && c.Encoding != "base64" {
+		return c.Content, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(c.Content)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode content of file %s: %v", c.Path, err)
+	}
+	return string(decoded), nil
+}
+
+// ListPullRequestComments lists comments of the pull request, oldest first
+func (gc *GiteaClient) ListPullRequestComments(repository string, prNumber int) ([]*Comment, error) {
+	comments := []*Comment{}
+	seen := map[int64]bool{}
+	for page := 1; ; page++ {
+		batch := []*Comment{}
+		if err := gc.do(http.MethodGet, fmt.Sprintf("%s/issues/%d/comments?limit=%d&page=%d", gc.repoPath(repository), prNumber, pageLimit, page), nil, &batch); err != nil {
+			return nil, fmt.Errorf("failed to list comments of pull request %d in repository %s: %v", prNumber, repository, err)
+		}
+		// servers not paginating the comments return all of them on every page
+		if len(batch) != 0 && seen[batch[0].ID] {
+			return comments, nil
+		}
+		for _, comment := range batch {
+			seen[comment.ID] = true
+		}
+		comments = append(comments, batch...)
+		if len(batch) < pageLimit {
+			return comments, nil
+		}
+	}
+}
+
+// GetCombinedStatus returns the latest status of each context reported for the ref
+func (gc *GiteaClient) GetCombinedStatus(repository, ref string) (*CombinedStatus, error) {
+	status := &CombinedStatus{Statuses: []*CommitStatus{}}
+	for page := 1; ; page++ {
+		batch := &CombinedStatus{}
+		if err := gc.do(http.MethodGet, fmt.Sprintf("%s/commits/%s/status?limit=%d&page=%d", gc.repoPath(repository), url.PathEscape(ref), pageLimit, page), nil, batch); err != nil {
+			return nil, fmt.Errorf("failed to get status of ref %s in repository %s: %v", ref, repository, err)
+		}
+		if page == 1 {
+			status.State, status.SHA = batch.State, batch.SHA
+		}
+		status.Statuses = append(status.Statuses, batch.Statuses...)
+		if len(batch.Statuses) < pageLimit {
+			return status, nil
+		}
+	}
+}
+
+func (gc *GiteaClient) ListWebhooks(repository string) ([]*Hook, error) {
+	hooks := []*Hook{}
+	for page := 1; ; page++ {
+		batch := []*Hook{}
+		if err := gc.do(http.MethodGet, fmt.Sprintf("%s/hooks?limit=%d&page=%d", gc.repoPath(repository), pageLimit, page), nil, &batch); err != nil {
+			return nil, fmt.Errorf("failed to list webhooks of repository %s: %v", repository, err)
+		}
+		hooks = append(hooks, batch...)
+		if len(batch) < pageLimit {
+			return hooks, nil
+		}
+	}
+}
+
+// CreateWebhook creates webhook sending push and pull request events to given URL, e.g. of PaC controller
+func (gc *GiteaClient) CreateWebhook(repository, hookURL, secret string) (*Hook, error) {
+	hook := &Hook{}
+	request := map[string]interface{}{
+		"type":   "gitea",
+		"active": true,
+		"events": []string{"push", "pull_request", "pull_request_sync", "issue_comment"},
+		"config": map[string]string{
+			"url":          hookURL,
+			"content_type": "json",
+			"secret":       secret,
+		},
+	}
+	if err := gc.do(http.MethodPost, gc.repoPath(repository)+"/hooks", request, hook); err != nil {
+		return nil, fmt.Errorf("failed to create webhook %s in repository %s: %v", hookURL, repository, err)
+	}
+	return hook, nil
+}
+
+// DeleteWebhooks deletes webhooks whose URL contains clusterAppDomain
+func (gc *GiteaClient) DeleteWebhooks(repository, clusterAppDomain string) error {
+	hooks, err := gc.ListWebhooks(repository)
+	if err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		if strings.Contains(hook.Config["url"], clusterAppDomain) {
+			if err := gc.do(http.MethodDelete, fmt.Sprintf("%s/hooks/%d", gc.repoPath(repository), hook.ID), nil, nil); err != nil && !IsNotFound(err) {
+				return fmt.Errorf("failed to delete webhook %d of repository %s: %v", hook.ID, repository, err)
+			}
+		}
+	}
+	return nil
+}
+
+func escapePath(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,214 @@
+package gitea
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) *GiteaClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	gc, err := NewGiteaClient("secret-token", server.URL+"/", "qe", false)
+	assert.NoError(t, err)
+	return gc
+}
+
+func TestGiteaClientBranches(t *testing.T) {
+	requests := []string{}
+	gc := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token secret-token", r.Header.Get("Authorization"))
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodPost:
+			body := map[string]string{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]string{"new_branch_name": "feature", "old_ref_name": "abc123"}, body)
+			w.WriteHeader(http.StatusCreated)
+		case r.URL.Path == "/api/v1/repos/qe/repo/branches/feature":
+			_, _ = w.Write([]byte(`{"name":"feature"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	assert.NoError(t, gc.CreateBranch("repo", "feature", "main", "abc123"))
+
+	exists, err := gc.ExistsBranch("qe/repo", "feature")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = gc.ExistsBranch("other/repo", "missing")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.NoError(t, gc.DeleteBranch("repo", "gone"))
+	assert.Equal(t, []string{
+		"POST /api/v1/repos/qe/repo/branches",
+		"GET /api/v1/repos/qe/repo/branches/feature",
+		"GET /api/v1/repos/other/repo/branches/missing",
+		"DELETE /api/v1/repos/qe/repo/branches/gone",
+	}, requests)
+}
+
+func TestGiteaClientFiles(t *testing.T) {
+	gc := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/repos/qe/repo/contents/dir/file.yaml", r.URL.Path)
+		switch r.Method {
+		case http.MethodPost:
+			body := map[string]string{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("hello")), body["content"])
+			assert.Equal(t, "feature", body["branch"])
+			_, _ = w.Write([]byte(`{"commit":{"sha":"c1"}}`))
+		case http.MethodGet:
+			assert.Equal(t, "feature", r.URL.Query().Get("ref"))
+			_, _ = w.Write([]byte(`{"path":"dir/file.yaml","sha":"blob","last_commit_sha":"c1","encoding":"base64","content":"aGVsbG8="}`))
+		}
+	})
+
+	created, err := gc.CreateFile("repo", "dir/file.yaml", "hello", "feature")
+	assert.NoError(t, err)
+	assert.Equal(t, "c1", created.Commit.SHA)
+
+	file, err := gc.GetFile("repo", "dir/file.yaml", "feature")
+	assert.NoError(t, err)
+	assert.Equal(t, "c1", file.LastCommitSHA)
+	content, err := file.DecodeContent()
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", content)
+}
+
+func TestGiteaClientPullRequests(t *testing.T) {
+	merged := false
+	gc := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/v1/repos/qe/repo/pulls/7/merge":
+			merged = true
+		case "GET /api/v1/repos/qe/repo/pulls/7":
+			if merged {
+				_, _ = w.Write([]byte(`{"number":7,"merged":true,"merge_commit_sha":"m1","head":{"ref":"feature","sha":"h1"},"base":{"ref":"main"}}`))
+			} else {
+				_, _ = w.Write([]byte(`{"number":7,"head":{"ref":"feature","sha":"h1"},"base":{"ref":"main"}}`))
+			}
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("unexpected"))
+		}
+	})
+
+	pr, err := gc.MergePullRequest("repo", 7)
+	assert.NoError(t, err)
+	assert.True(t, pr.Merged)
+	assert.Equal(t, "m1", pr.MergeCommitSHA)
+	assert.Equal(t, "feature", pr.Head.Ref)
+
+	err = gc.ClosePullRequest("repo", 7)
+	assert.ErrorContains(t, err, "returned status 500: unexpected")
+}
+
+func TestGiteaClientDeleteWebhooks(t *testing.T) {
+	deleted := []string{}
+	gc := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":1,"config":{"url":"https://pac.apps.cluster.example.com"}},{"id":2,"config":{"url":"https://ci.example.org"}}]`))
+	})
+
+	assert.NoError(t, gc.DeleteWebhooks("repo", "apps.cluster.example.com"))
+	assert.Equal(t, []string{"/api/v1/repos/qe/repo/hooks/1"}, deleted)
+}
+
+func TestGiteaClientCommentsAndStatuses(t *testing.T) {
+	gc := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/repos/qe/repo/issues/3/comments":
+			_, _ = w.Write([]byte(`[{"id":10,"body":"passed","user":{"login":"bot"},"created_at":"2024-05-01T10:00:00Z"}]`))
+		case "/api/v1/repos/qe/repo/commits/abc/status":
+			_, _ = w.Write([]byte(`{"state":"pending","statuses":[{"context":"konflux / test","status":"pending","target_url":"https://konflux"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	comments, err := gc.ListPullRequestComments("repo", 3)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "bot", comments[0].User.Login)
+	assert.Equal(t, 2024, comments[0].CreatedAt.Year())
+
+	status, err := gc.GetCombinedStatus("repo", "abc")
+	assert.NoError(t, err)
+	assert.Len(t, status.Statuses, 1)
+	assert.Equal(t, "konflux / test", status.Statuses[0].Context)
+}
+
+func TestGiteaClientPagination(t *testing.T) {
+	// returns count items in pages of the requested size, each item built by item from its index
+	page := func(r *http.Request, count int, item func(i int) map[string]interface{}) []map[string]interface{} {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		number, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		items := []map[string]interface{}{}
+		for i := (number - 1) * limit; i < count && i < number*limit; i++ {
+			items = append(items, item(i))
+		}
+		return items
+	}
+	paginateComments := true
+	gc := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body interface{}
+		switch r.URL.Path {
+		case "/api/v1/repos/qe/repo/issues/3/comments":
+			item := func(i int) map[string]interface{} { return map[string]interface{}{"id": i + 1} }
+			if paginateComments {
+				body = page(r, pageLimit+1, item)
+			} else {
+				all := []map[string]interface{}{}
+				for i := 0; i < pageLimit+1; i++ {
+					all = append(all, item(i))
+				}
+				body = all
+			}
+		case "/api/v1/repos/qe/repo/commits/abc/status":
+			body = map[string]interface{}{"state": "success", "sha": "abc", "statuses": page(r, pageLimit+1, func(i int) map[string]interface{} {
+				return map[string]interface{}{"context": fmt.Sprintf("check-%d", i)}
+			})}
+		case "/api/v1/repos/qe/repo/hooks":
+			body = page(r, pageLimit*2, func(i int) map[string]interface{} { return map[string]interface{}{"id": i + 1} })
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(body))
+	})
+
+	comments, err := gc.ListPullRequestComments("repo", 3)
+	assert.NoError(t, err)
+	assert.Len(t, comments, pageLimit+1)
+	assert.Equal(t, int64(pageLimit+1), comments[pageLimit].ID)
+
+	// servers ignoring the page parameter of comments are read once
+	paginateComments = false
+	comments, err = gc.ListPullRequestComments("repo", 3)
+	assert.NoError(t, err)
+	assert.Len(t, comments, pageLimit+1)
+
+	status, err := gc.GetCombinedStatus("repo", "abc")
+	assert.NoError(t, err)
+	assert.Equal(t, "success", status.State)
+	assert.Len(t, status.Statuses, pageLimit+1)
+	assert.Equal(t, fmt.Sprintf("check-%d", pageLimit), status.Statuses[pageLimit].Context)
+
+	hooks, err := gc.ListWebhooks("repo")
+	assert.NoError(t, err)
+	assert.Len(t, hooks, pageLimit*2)
+}
@@ -0,0 +1,163 @@
+package github
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/go-github/v44/github"
+	"golang.org/x/oauth2"
+
+	"github.com/konflux-ci/e2e-tests/pkg/constants"
+	"github.com/konflux-ci/e2e-tests/pkg/utils"
+)
+
+const (
+	// installation tokens are valid for an hour, refresh them a bit sooner to not use expired one in a long request
+	appTokenEarlyExpiry = 5 * time.Minute
+	// GitHub accepts JWTs valid for at most 10 minutes
+	appJWTExpiry = 9 * time.Minute
+)
+
+// NewGithubClientFromEnv creates client authenticated as GitHub App installation when GITHUB_AUTH_MODE env var is
+// set to "app", otherwise it uses token from GITHUB_TOKEN env var
+func NewGithubClientFromEnv(organization string) (*Github, error) {
+	if utils.GetEnv(constants.GITHUB_AUTH_MODE_ENV, "") != constants.GithubAuthModeApp {
+		return NewGithubClient(utils.GetEnv(constants.GITHUB_TOKEN_ENV, ""), organization)
+	}
+
+	appID, err := utils.GetGithubAppID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App ID: %v", err)
+	}
+	privateKey := utils.GetEnv(constants.PAC_GITHUB_APP_PRIVATE_KEY_ENV, "")
+	if privateKey == "" {
+		return nil, fmt.Errorf("%s env var has to be set to authenticate as GitHub App", constants.PAC_GITHUB_APP_PRIVATE_KEY_ENV)
+	}
+	// the key is usually stored base64 encoded, so it fits in a single line
+	if !strings.Contains(privateKey, "-----BEGIN") {
+		decoded, err := base64.StdEncoding.DecodeString(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode GitHub App private key: %v", err)
+		}
+		privateKey = string(decoded)
+	}
+	return NewGithubAppClient(appID, []byte(privateKey), organization)
+}
+
+// NewGithubAppClient creates client authenticated with installation token of the GitHub App in the organization
+// (or user account). Tokens are created on demand and refreshed before they expire, when the organization
+// is changed by UpdateGithubOrg the installation in the new organization is used.
+func NewGithubAppClient(appID int64, privateKey []byte, organization string) (*Github, error) {
+	return newGithubAppClient(appID, privateKey, organization, nil)
+}
+
+func newGithubAppClient(appID int64, privateKey []byte, organization string, baseURL *url.URL) (*Github, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %v", err)
+	}
+
+	apps := github.NewClient(&http.Client{Transport: &appJWTTransport{appID: appID, key: key, base: http.DefaultTransport}})
+	if baseURL != nil {
+		apps.BaseURL = baseURL
+	}
+	auth := &appInstallationAuth{apps: apps, sources: map[string]oauth2.TokenSource{}}
+
+	g := &Github{organization: organization}
+	transport := &oauth2.Transport{
+		Source: oauth2TokenSourceFunc(func() (*oauth2.Token, error) { return auth.token(g.org()) }),
+		Base:   http.DefaultTransport,
+	}
+	if err := g.initClient(transport); err != nil {
+		return nil, err
+	}
+	if baseURL != nil {
+		g.client.BaseURL = baseURL
+	}
+	return g, nil
+}
+
+// appJWTTransport authenticates requests as the GitHub App itself, which is needed to create installation tokens
+type appJWTTransport struct {
+	appID int64
+	key   *rsa.PrivateKey
+	base  http.RoundTripper
+}
+
+func (t *appJWTTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	now := time.Now()
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{
+		// GitHub recommends to backdate the token a bit to allow for clock drift
+		IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(appJWTExpiry)),
+		Issuer:    strconv.FormatInt(t.appID, 10),
+	}).SignedString(t.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign GitHub App JWT: %v", err)
+	}
+
+	authenticated := req.Clone(req.Context())
+	authenticated.Header.Set("Authorization", "Bearer "+signed)
+	return t.base.RoundTrip(authenticated)
+}
+
+// appInstallationAuth caches installation token source of each organization
+type appInstallationAuth struct {
+	apps    *github.Client
+	mu      sync.Mutex
+	sources map[string]oauth2.TokenSource
+}
+
+func (a *appInstallationAuth) token(organization string) (*oauth2.Token, error) {
+	a.mu.Lock()
+	source, ok := a.sources[organization]
+	if !ok {
+		source = oauth2.ReuseTokenSourceWithExpiry(nil, &installationTokenSource{apps: a.apps, organization: organization}, appTokenEarlyExpiry)
+		a.sources[organization] = source
+	}
+	a.mu.Unlock()
+	return source.Token()
+}
+
+// installationTokenSource creates new installation token each time it is called, the installation is looked up once
+type installationTokenSource struct {
+	apps           *github.Client
+	organization   string
+	installationID int64
+}
+
+func (s *installationTokenSource) Token() (*oauth2.Token, error) {
+	ctx := context.Background()
+	if s.installationID == 0 {
+		installation, resp, err := s.apps.Apps.FindOrganizationInstallation(ctx, s.organization)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// the "organization" can be a user account as well
+			installation, _, err = s.apps.Apps.FindUserInstallation(ctx, s.organization)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error when looking up GitHub App installation in %s: %v", s.organization, err)
+		}
+		s.installationID = installation.GetID()
+	}
+
+	token, _, err := s.apps.Apps.CreateInstallationToken(ctx, s.installationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error when creating GitHub App installation token for %s: %v", s.organization, err)
+	}
+	return &oauth2.Token{AccessToken: token.GetToken(), TokenType: "token", Expiry: token.GetExpiresAt()}, nil
+}
+
+type oauth2TokenSourceFunc func() (*oauth2.Token, error)
+
+func (f oauth2TokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
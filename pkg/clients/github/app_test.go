@@ -0,0 +1,127 @@
+package github
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeGithubApps struct {
+	mu             sync.Mutex
+	key            *rsa.PrivateKey
+	tokenLifetime  time.Duration
+	tokensCreated  int
+	authorizations []string
+}
+
+func (f *fakeGithubApps) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case r.URL.Path == "/orgs/qe/installation" || r.URL.Path == "/users/someone/installation":
+		f.validateJWT(w, r)
+		fmt.Fprintf(w, `{"id": %d}`, map[bool]int{true: 1, false: 2}[strings.Contains(r.URL.Path, "qe")])
+	case strings.HasPrefix(r.URL.Path, "/orgs/"):
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Not Found"}`)
+	case strings.HasPrefix(r.URL.Path, "/app/installations/"):
+		f.validateJWT(w, r)
+		f.tokensCreated++
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token": "%s-token-%d", "expires_at": "%s"}`, strings.Split(r.URL.Path, "/")[3], f.tokensCreated, time.Now().Add(f.tokenLifetime).UTC().Format(time.RFC3339))
+	default:
+		f.authorizations = append(f.authorizations, r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"number": 1}`)
+	}
+}
+
+func (f *fakeGithubApps) validateJWT(w http.ResponseWriter, r *http.Request) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), claims, func(*jwt.Token) (interface{}, error) {
+		return &f.key.PublicKey, nil
+	})
+	if err != nil || claims.Issuer != "123" {
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+}
+
+func newFakeGithubApps(t *testing.T, tokenLifetime time.Duration) (*fakeGithubApps, []byte, *url.URL) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	fake := &fakeGithubApps{key: key, tokenLifetime: tokenLifetime}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	baseURL, err := url.Parse(server.URL + "/")
+	assert.NoError(t, err)
+	return fake, keyPEM, baseURL
+}
+
+func TestGithubAppClientReusesToken(t *testing.T) {
+	fake, key, baseURL := newFakeGithubApps(t, time.Hour)
+	g, err := newGithubAppClient(123, key, "qe", baseURL)
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = g.GetPullRequest("repo", 1)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, fake.tokensCreated)
+
+	g.UpdateGithubOrg("someone")
+	_, err = g.GetPullRequest("repo", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"token 1-token-1", "token 1-token-1", "token 2-token-2"}, fake.authorizations)
+}
+
+func TestGithubAppClientConcurrentOrgUpdate(t *testing.T) {
+	_, key, baseURL := newFakeGithubApps(t, time.Hour)
+	g, err := newGithubAppClient(123, key, "qe", baseURL)
+	assert.NoError(t, err)
+
+	// Organization can be changed while requests, which read it for the installation token, are running
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			g.UpdateGithubOrg([]string{"qe", "someone"}[i%2])
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		_, err = g.GetPullRequest("repo", 1)
+		assert.NoError(t, err)
+	}
+	wg.Wait()
+}
+
+func TestGithubAppClientRefreshesExpiringToken(t *testing.T) {
+	fake, key, baseURL := newFakeGithubApps(t, appTokenEarlyExpiry-time.Minute)
+	g, err := newGithubAppClient(123, key, "qe", baseURL)
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = g.GetPullRequest("repo", 1)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, fake.tokensCreated)
+	assert.Equal(t, []string{"token 1-token-1", "token 1-token-2"}, fake.authorizations)
+}
+
+func TestGithubAppClientInvalidKey(t *testing.T) {
+	_, err := NewGithubAppClient(123, []byte("not a key"), "qe")
+	assert.ErrorContains(t, err, "failed to parse GitHub App private key")
+}
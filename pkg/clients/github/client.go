@@ -2,6 +2,8 @@ package github
 
 import (
 	"context"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gofri/go-github-ratelimit/github_ratelimit"
@@ -14,23 +16,31 @@ const (
 )
 
 type Github struct {
-	client       *github.Client
+	client *github.Client
+	// organization is changed by UpdateGithubOrg while it is read by requests (and App token source), so it is guarded
+	mu           sync.RWMutex
 	organization string
 }
 
 func NewGithubClient(token, organization string) (*Github, error) {
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
 	tc := oauth2.NewClient(context.Background(), ts)
-	// https://docs.github.com/en/rest/guides/best-practices-for-integrators?apiVersion=2022-11-28#dealing-with-secondary-rate-limits
-	rateLimiter, err := github_ratelimit.NewRateLimitWaiterClient(tc.Transport, github_ratelimit.WithSingleSleepLimit(time.Minute, nil))
-	if err != nil {
-		return &Github{}, err
-	}
-	client := github.NewClient(rateLimiter)
 	githubClient := &Github{
-		client:       client,
 		organization: organization,
 	}
+	if err := githubClient.initClient(tc.Transport); err != nil {
+		return &Github{}, err
+	}
 
 	return githubClient, nil
 }
+
+func (g *Github) initClient(transport http.RoundTripper) error {
+	// https://docs.github.com/en/rest/guides/best-practices-for-integrators?apiVersion=2022-11-28#dealing-with-secondary-rate-limits
+	rateLimiter, err := github_ratelimit.NewRateLimitWaiterClient(transport, github_ratelimit.WithSingleSleepLimit(time.Minute, nil))
+	if err != nil {
+		return err
+	}
+	g.client = github.NewClient(rateLimiter)
+	return nil
+}
@@ -11,7 +11,7 @@ import (
 )
 
 func (g *Github) DeleteRef(repository, branchName string) error {
-	_, err := g.client.Git.DeleteRef(context.Background(), g.organization, repository, fmt.Sprintf(HEADS, branchName))
+	_, err := g.client.Git.DeleteRef(context.Background(), g.org(), repository, fmt.Sprintf(HEADS, branchName))
 	if err != nil {
 		return err
 	}
@@ -23,7 +23,7 @@ func (g *Github) DeleteRef(repository, branchName string) error {
 // the latest commit from base branch will be used.
 func (g *Github) CreateRef(repository, baseBranchName, sha, newBranchName string) error {
 	ctx := context.Background()
-	ref, _, err := g.client.Git.GetRef(ctx, g.organization, repository, fmt.Sprintf(HEADS, baseBranchName))
+	ref, _, err := g.client.Git.GetRef(ctx, g.org(), repository, fmt.Sprintf(HEADS, baseBranchName))
 	if err != nil {
 		return fmt.Errorf("error when getting the base branch name '%s' for the repo '%s': %+v", baseBranchName, repository, err)
 	}
@@ -34,7 +34,7 @@ func (g *Github) CreateRef(repository, baseBranchName, sha, newBranchName string
 		ref.Object.SHA = &sha
 	}
 
-	_, _, err = g.client.Git.CreateRef(ctx, g.organization, repository, ref)
+	_, _, err = g.client.Git.CreateRef(ctx, g.org(), repository, ref)
 	if err != nil {
 		return fmt.Errorf("error when creating a new branch '%s' for the repo '%s': %+v", newBranchName, repository, err)
 	}
@@ -55,7 +55,7 @@ func (g *Github) CreateRef(repository, baseBranchName, sha, newBranchName string
 }
 
 func (g *Github) ExistsRef(repository, branchName string) (bool, error) {
-	_, _, err := g.client.Git.GetRef(context.Background(), g.organization, repository, fmt.Sprintf(HEADS, branchName))
+	_, _, err := g.client.Git.GetRef(context.Background(), g.org(), repository, fmt.Sprintf(HEADS, branchName))
 	if err != nil {
 		if strings.Contains(err.Error(), "404 Not Found") {
 			return false, nil
@@ -66,6 +66,16 @@ func (g *Github) ExistsRef(repository, branchName string) (bool, error) {
 	return true, nil
 }
 
+// UpdateGithubOrg changes the organization repositories belong to, it is safe to call while requests are in flight
 func (g *Github) UpdateGithubOrg(githubOrg string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
 	g.organization = githubOrg
 }
+
+// org returns the current organization
+func (g *Github) org() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.organization
+}
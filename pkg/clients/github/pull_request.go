@@ -13,7 +13,7 @@ import (
 )
 
 func (g *Github) GetPullRequest(repository string, id int) (*github.PullRequest, error) {
-	pr, _, err := g.client.PullRequests.Get(context.Background(), g.organization, repository, id)
+	pr, _, err := g.client.PullRequests.Get(context.Background(), g.org(), repository, id)
 	if err != nil {
 		return nil, err
 	}
@@ -27,7 +27,7 @@ func (g *Github) CreatePullRequest(repository, title, body, head, base string) (
 		Head:  &head,
 		Base:  &base,
 	}
-	pr, _, err := g.client.PullRequests.Create(context.Background(), g.organization, repository, newPR)
+	pr, _, err := g.client.PullRequests.Create(context.Background(), g.org(), repository, newPR)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +35,7 @@ func (g *Github) CreatePullRequest(repository, title, body, head, base string) (
 }
 
 func (g *Github) ListPullRequests(repository string) ([]*github.PullRequest, error) {
-	prs, _, err := g.client.PullRequests.List(context.Background(), g.organization, repository, &github.PullRequestListOptions{})
+	prs, _, err := g.client.PullRequests.List(context.Background(), g.org(), repository, &github.PullRequestListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("error when listing pull requests for the repo %s: %v", repository, err)
 	}
@@ -43,21 +43,32 @@ func (g *Github) ListPullRequests(repository string) ([]*github.PullRequest, err
 	return prs, nil
 }
 
+// ListPullRequestCommentsSince lists all comments of the pull request updated since given time, oldest first
 func (g *Github) ListPullRequestCommentsSince(repository string, prNumber int, since time.Time) ([]*github.IssueComment, error) {
-	comments, _, err := g.client.Issues.ListComments(context.Background(), g.organization, repository, prNumber, &github.IssueListCommentsOptions{
-		Since:     &since,
-		Sort:      github.String("created"),
-		Direction: github.String("asc"),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error when listing pull requests comments for the repo %s: %v", repository, err)
+	opt := &github.IssueListCommentsOptions{
+		Since:       &since,
+		Sort:        github.String("created"),
+		Direction:   github.String("asc"),
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	var allComments []*github.IssueComment
+	for {
+		comments, resp, err := g.client.Issues.ListComments(context.Background(), g.org(), repository, prNumber, opt)
+		if err != nil {
+			return nil, fmt.Errorf("error when listing pull requests comments for the repo %s: %v", repository, err)
+		}
+		allComments = append(allComments, comments...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
 	}
 
-	return comments, nil
+	return allComments, nil
 }
 
 func (g *Github) MergePullRequest(repository string, prNumber int) (*github.PullRequestMergeResult, error) {
-	mergeResult, _, err := g.client.PullRequests.Merge(context.Background(), g.organization, repository, prNumber, "", &github.PullRequestOptions{})
+	mergeResult, _, err := g.client.PullRequests.Merge(context.Background(), g.org(), repository, prNumber, "", &github.PullRequestOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("error when merging pull request number %d for the repo %s: %v", prNumber, repository, err)
 	}
@@ -66,15 +77,24 @@ func (g *Github) MergePullRequest(repository string, prNumber int) (*github.Pull
 }
 
 func (g *Github) ListCheckRuns(repository string, ref string) ([]*github.CheckRun, error) {
-	checkRunResults, _, err := g.client.Checks.ListCheckRunsForRef(context.Background(), g.organization, repository, ref, &github.ListCheckRunsOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("error when listing check runs for the repo %s and ref %s: %v", repository, ref, err)
+	opt := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var allCheckRuns []*github.CheckRun
+	for {
+		checkRunResults, resp, err := g.client.Checks.ListCheckRunsForRef(context.Background(), g.org(), repository, ref, opt)
+		if err != nil {
+			return nil, fmt.Errorf("error when listing check runs for the repo %s and ref %s: %v", repository, ref, err)
+		}
+		allCheckRuns = append(allCheckRuns, checkRunResults.CheckRuns...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
 	}
-	return checkRunResults.CheckRuns, nil
+	return allCheckRuns, nil
 }
 
 func (g *Github) GetCheckRun(repository string, id int64) (*github.CheckRun, error) {
-	checkRun, _, err := g.client.Checks.GetCheckRun(context.Background(), g.organization, repository, id)
+	checkRun, _, err := g.client.Checks.GetCheckRun(context.Background(), g.org(), repository, id)
 	if err != nil {
 		return nil, fmt.Errorf("error when getting check run with id %d for the repo %s: %v", id, repository, err)
 	}
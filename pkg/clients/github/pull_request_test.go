@@ -0,0 +1,37 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListPullRequestCommentsSinceReadsAllPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/qe/repo/issues/1/comments", r.URL.Path)
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		if page == "1" {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=2>; rel="next"`, r.Host, r.URL.Path))
+		}
+		fmt.Fprintf(w, `[{"id": %s}]`, page)
+	}))
+	t.Cleanup(server.Close)
+	g := &Github{organization: "qe"}
+	assert.NoError(t, g.initClient(http.DefaultTransport))
+	g.client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	comments, err := g.ListPullRequestCommentsSince("repo", 1, time.Time{})
+
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, int64(2), comments[1].GetID())
+}
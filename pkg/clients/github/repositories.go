@@ -41,7 +41,7 @@ func (g *Github) DeleteRelease(owner, repositoryName, releaseURL string) bool {
 }
 
 func (g *Github) CheckIfRepositoryExist(repository string) bool {
-	_, resp, err := g.client.Repositories.Get(context.Background(), g.organization, repository)
+	_, resp, err := g.client.Repositories.Get(context.Background(), g.org(), repository)
 	if err != nil {
 		GinkgoWriter.Printf("error when sending request to Github API: %v\n", err)
 		return false
@@ -57,7 +57,7 @@ func (g *Github) CreateFile(repository, pathToFile, fileContent, branchName stri
 		Branch:  github.String(branchName),
 	}
 
-	file, _, err := g.client.Repositories.CreateFile(context.Background(), g.organization, repository, pathToFile, opts)
+	file, _, err := g.client.Repositories.CreateFile(context.Background(), g.org(), repository, pathToFile, opts)
 	if err != nil {
 		return nil, fmt.Errorf("error when creating file contents: %v", err)
 	}
@@ -70,7 +70,7 @@ func (g *Github) GetFile(repository, pathToFile, branchName string) (*github.Rep
 	if branchName != "" {
 		opts.Ref = fmt.Sprintf(HEADS, branchName)
 	}
-	file, _, _, err := g.client.Repositories.GetContents(context.Background(), g.organization, repository, pathToFile, opts)
+	file, _, _, err := g.client.Repositories.GetContents(context.Background(), g.org(), repository, pathToFile, opts)
 	if err != nil {
 		return nil, fmt.Errorf("error when listing file contents: %v", err)
 	}
@@ -89,7 +89,7 @@ func (g *Github) UpdateFile(repository, pathToFile, newContent, branchName, file
 		Content: []byte(newContent),
 		Branch:  github.String(branchName),
 	}
-	updatedFile, _, err := g.client.Repositories.UpdateFile(context.Background(), g.organization, repository, pathToFile, newFileContent)
+	updatedFile, _, err := g.client.Repositories.UpdateFile(context.Background(), g.org(), repository, pathToFile, newFileContent)
 	if err != nil {
 		return nil, fmt.Errorf("error when updating a file on github: %v", err)
 	}
@@ -105,17 +105,15 @@ func (g *Github) DeleteFile(repository, pathToFile, branchName string) error {
 		getOpts.Ref = fmt.Sprintf(HEADS, branchName)
 		deleteOpts.Branch = github.String(branchName)
 	}
-	file, _, _, err := g.client.Repositories.GetContents(context.Background(), g.organization, repository, pathToFile, getOpts)
+	file, _, _, err := g.client.Repositories.GetContents(context.Background(), g.org(), repository, pathToFile, getOpts)
 	if err != nil {
 		return fmt.Errorf("error when listing file contents on github: %v", err)
 	}
 
-	deleteOpts = &github.RepositoryContentFileOptions{
-		Message: github.String("delete test files"),
-		SHA:     github.String(file.GetSHA()),
-	}
+	deleteOpts.Message = github.String("delete test files")
+	deleteOpts.SHA = github.String(file.GetSHA())
 
-	_, _, err = g.client.Repositories.DeleteFile(context.Background(), g.organization, repository, pathToFile, deleteOpts)
+	_, _, err = g.client.Repositories.DeleteFile(context.Background(), g.org(), repository, pathToFile, deleteOpts)
 	if err != nil {
 		return fmt.Errorf("error when deleting file on github: %v", err)
 	}
@@ -131,7 +129,7 @@ func (g *Github) GetAllRepositories() ([]*github.Repository, error) {
 	}
 	var allRepos []*github.Repository
 	for {
-		repos, resp, err := g.client.Repositories.ListByOrg(context.Background(), g.organization, opt)
+		repos, resp, err := g.client.Repositories.ListByOrg(context.Background(), g.org(), opt)
 		if err != nil {
 			return nil, err
 		}
@@ -146,7 +144,7 @@ func (g *Github) GetAllRepositories() ([]*github.Repository, error) {
 
 func (g *Github) DeleteRepository(repository *github.Repository) error {
 	GinkgoWriter.Printf("Deleting repository %s\n", *repository.Name)
-	_, err := g.client.Repositories.Delete(context.Background(), g.organization, *repository.Name)
+	_, err := g.client.Repositories.Delete(context.Background(), g.org(), *repository.Name)
 	if err != nil {
 		return err
 	}
@@ -156,15 +154,15 @@ func (g *Github) DeleteRepository(repository *github.Repository) error {
 func (g *Github) DeleteRepositoryIfExists(name string) error {
 	ctx := context.Background()
 
-	_, resp, err := g.client.Repositories.Get(ctx, g.organization, name)
+	_, resp, err := g.client.Repositories.Get(ctx, g.org(), name)
 	if err != nil {
 		if resp.StatusCode != 404 {
-			return fmt.Errorf("Error checking repository %s/%s: %v\n", g.organization, name, err)
+			return fmt.Errorf("Error checking repository %s/%s: %v\n", g.org(), name, err)
 		}
 	} else {
-		_, deleteErr := g.client.Repositories.Delete(ctx, g.organization, name)
+		_, deleteErr := g.client.Repositories.Delete(ctx, g.org(), name)
 		if deleteErr != nil {
-			return fmt.Errorf("Error deleting repository %s/%s: %v\n", g.organization, name, deleteErr)
+			return fmt.Errorf("Error deleting repository %s/%s: %v\n", g.org(), name, deleteErr)
 		}
 	}
 
@@ -179,11 +177,11 @@ func (g *Github) ForkRepository(sourceName, targetName string) (*github.Reposito
 	ctx := context.Background()
 
 	forkOptions := &github.RepositoryCreateForkOptions{
-		Organization: g.organization,
+		Organization: g.org(),
 	}
 
 	err1 := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		fork, resp, err = g.client.Repositories.CreateFork(ctx, g.organization, sourceName, forkOptions)
+		fork, resp, err = g.client.Repositories.CreateFork(ctx, g.org(), sourceName, forkOptions)
 		if err != nil {
 			if _, ok := err.(*github.AcceptedError); ok && resp.StatusCode == 202 {
 				// This meens forking is happening asynchronously
@@ -200,25 +198,25 @@ func (g *Github) ForkRepository(sourceName, targetName string) (*github.Reposito
 				fmt.Printf("Warning, got 500: %s", resp.Body)
 				return false, nil
 			}
-			return false, fmt.Errorf("Error forking %s/%s: %v", g.organization, sourceName, err)
+			return false, fmt.Errorf("Error forking %s/%s: %v", g.org(), sourceName, err)
 		}
 		return true, nil
 	}, time.Second * 10, time.Minute * 30)
 	if err1 != nil {
-		return nil, fmt.Errorf("Failed waiting for fork %s/%s: %v", g.organization, sourceName, err1)
+		return nil, fmt.Errorf("Failed waiting for fork %s/%s: %v", g.org(), sourceName, err1)
 	}
 
 	err2 := utils.WaitUntilWithInterval(func() (done bool, err error) {
 		// Using this to detect repo is created and populated with content
 		// https://stackoverflow.com/questions/33666838/determine-if-a-fork-is-ready
-		_, _, err = g.client.Repositories.ListCommits(ctx, g.organization, fork.GetName(), &github.CommitsListOptions{})
+		_, _, err = g.client.Repositories.ListCommits(ctx, g.org(), fork.GetName(), &github.CommitsListOptions{})
 		if err != nil {
 			return false, nil
 		}
 		return true, nil
 	}, time.Second * 10, time.Minute * 10)
 	if err2 != nil {
-		return nil, fmt.Errorf("Failed waiting for commits %s/%s: %v", g.organization, sourceName, err2)
+		return nil, fmt.Errorf("Failed waiting for commits %s/%s: %v", g.org(), sourceName, err2)
 	}
 
 	editedRepo := &github.Repository{
@@ -226,19 +224,19 @@ func (g *Github) ForkRepository(sourceName, targetName string) (*github.Reposito
 	}
 
 	err3 := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		repo, resp, err = g.client.Repositories.Edit(ctx, g.organization, fork.GetName(), editedRepo)
+		repo, resp, err = g.client.Repositories.Edit(ctx, g.org(), fork.GetName(), editedRepo)
 		if err != nil {
 			if resp.StatusCode == 422 {
 				// This started to happen recently. Docs says 422 is "Validation failed, or the endpoint has been spammed." so we need to be patient.
 				// Error we are getting: "422 Validation Failed [{Resource:Repository Field:name Code:custom Message:name a repository operation is already in progress}]"
 				return false, nil
 			}
-			return false, fmt.Errorf("Error renaming %s/%s to %s: %v\n", g.organization, fork.GetName(), targetName, err)
+			return false, fmt.Errorf("Error renaming %s/%s to %s: %v\n", g.org(), fork.GetName(), targetName, err)
 		}
 		return true, nil
 	}, time.Second * 10, time.Minute * 10)
 	if err3 != nil {
-		return nil, fmt.Errorf("Failed waiting for renaming %s/%s: %v", g.organization, targetName, err3)
+		return nil, fmt.Errorf("Failed waiting for renaming %s/%s: %v", g.org(), targetName, err3)
 	}
 
 	return repo, nil
@@ -12,7 +12,7 @@ type Webhook struct {
 }
 
 func (g *Github) ListRepoWebhooks(repository string) ([]*github.Hook, error) {
-	hooks, _, err := g.client.Repositories.ListHooks(context.Background(), g.organization, repository, &github.ListOptions{})
+	hooks, _, err := g.client.Repositories.ListHooks(context.Background(), g.org(), repository, &github.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("error when listing webhooks: %v", err)
 	}
@@ -29,7 +29,7 @@ func (g *Github) CreateWebhook(repository, url string) (int64, error) {
 		},
 	}
 
-	hook, _, err := g.client.Repositories.CreateHook(context.Background(), g.organization, repository, newWebhook)
+	hook, _, err := g.client.Repositories.CreateHook(context.Background(), g.org(), repository, newWebhook)
 	if err != nil {
 		return 0, fmt.Errorf("error when creating a webhook: %v", err)
 	}
@@ -37,7 +37,7 @@ func (g *Github) CreateWebhook(repository, url string) (int64, error) {
 }
 
 func (g *Github) DeleteWebhook(repository string, ID int64) error {
-	_, err := g.client.Repositories.DeleteHook(context.Background(), g.organization, repository, ID)
+	_, err := g.client.Repositories.DeleteHook(context.Background(), g.org(), repository, ID)
 	if err != nil {
 		return fmt.Errorf("error when deleting webhook: %v", err)
 	}
@@ -231,3 +231,56 @@ func (gc *GitlabClient) ValidateNoteInMergeRequestComment(projectID, expectedNot
 		return false
 	}, timeout, interval).Should(BeTrue(), fmt.Sprintf("timed out waiting to validate merge request note ('%s') be reported in mergerequest %d's notes", expectedNote, mergeRequestID))
 }
+
+func (gc *GitlabClient) DeleteFile(projectID, pathToFile, branchName string) error {
+	deleteOptions := &gitlab.DeleteFileOptions{
+		Branch:        gitlab.Ptr(branchName),
+		CommitMessage: gitlab.Ptr("delete test files"),
+	}
+
+	_, err := gc.client.RepositoryFiles.DeleteFile(projectID, pathToFile, deleteOptions)
+	if err != nil {
+		return fmt.Errorf("failed to delete file %s: %v", pathToFile, err)
+	}
+	return nil
+}
+
+// GetMergeRequestNotes returns notes of the merge request, oldest first
+func (gc *GitlabClient) GetMergeRequestNotes(projectID string, mergeRequestID int) ([]*gitlab.Note, error) {
+	opts := &gitlab.ListMergeRequestNotesOptions{
+		ListOptions: gitlab.ListOptions{PerPage: 100},
+		OrderBy:     gitlab.Ptr("created_at"),
+		Sort:        gitlab.Ptr("asc"),
+	}
+	var allNotes []*gitlab.Note
+	for {
+		notes, resp, err := gc.client.Notes.ListMergeRequestNotes(projectID, mergeRequestID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list notes of merge request %d: %v", mergeRequestID, err)
+		}
+		allNotes = append(allNotes, notes...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return allNotes, nil
+}
+
+// GetCommitStatuses returns the latest commit status of each name reported for the commit
+func (gc *GitlabClient) GetCommitStatuses(projectID, sha string) ([]*gitlab.CommitStatus, error) {
+	opts := &gitlab.GetCommitStatusesOptions{ListOptions: gitlab.ListOptions{PerPage: 100}}
+	var allStatuses []*gitlab.CommitStatus
+	for {
+		statuses, resp, err := gc.client.Commits.GetCommitStatuses(projectID, sha, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get statuses of commit %s: %v", sha, err)
+		}
+		allStatuses = append(allStatuses, statuses...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return allStatuses, nil
+}
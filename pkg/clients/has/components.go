@@ -35,7 +35,7 @@ const (
 // GetComponent return a component object from kubernetes cluster
 func (h *HasController) GetComponent(name string, namespace string) (*appservice.Component, error) {
 	component := &appservice.Component{}
-	if err := h.KubeRest().Get(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, component); err != nil {
+	if err := h.KubeRest().Get(h.Context(), types.NamespacedName{Name: name, Namespace: namespace}, component); err != nil {
 		return nil, err
 	}
 
@@ -48,7 +48,7 @@ func (h *HasController) GetComponentByApplicationName(applicationName string, na
 	opts := []rclient.ListOption{
 		rclient.InNamespace(namespace),
 	}
-	err := h.KubeRest().List(context.Background(), components, opts...)
+	err := h.KubeRest().List(h.Context(), components, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +89,7 @@ func (h *HasController) GetComponentPipelineRunsWithType(componentName string, a
 	}
 
 	list := &pipeline.PipelineRunList{}
-	err := h.KubeRest().List(context.Background(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(pipelineRunLabels), Namespace: namespace})
+	err := h.KubeRest().List(h.Context(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(pipelineRunLabels), Namespace: namespace})
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return nil, fmt.Errorf("error listing pipelineruns in %s namespace: %v", namespace, err)
@@ -112,7 +112,7 @@ func (h *HasController) GetAllPipelineRunsForApplication(applicationName, namesp
 	pipelineRunLabels := map[string]string{"appstudio.openshift.io/application": applicationName}
 
 	list := &pipeline.PipelineRunList{}
-	err := h.KubeRest().List(context.Background(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(pipelineRunLabels), Namespace: namespace})
+	err := h.KubeRest().List(h.Context(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(pipelineRunLabels), Namespace: namespace})
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return nil, fmt.Errorf("error listing pipelineruns in %s namespace: %v", namespace, err)
@@ -130,7 +130,7 @@ func (h *HasController) GetAllGroupSnapshotsForApplication(applicationName, name
 	snapshotLabels := map[string]string{"appstudio.openshift.io/application": applicationName, "test.appstudio.openshift.io/type": "group"}
 
 	list := &appservice.SnapshotList{}
-	err := h.KubeRest().List(context.Background(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(snapshotLabels), Namespace: namespace})
+	err := h.KubeRest().List(h.Context(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(snapshotLabels), Namespace: namespace})
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return nil, fmt.Errorf("error listing snapshots in %s namespace: %v", namespace, err)
@@ -148,7 +148,7 @@ func (h *HasController) GetAllComponentSnapshotsForApplicationAndComponent(appli
 	snapshotLabels := map[string]string{"appstudio.openshift.io/application": applicationName, "test.appstudio.openshift.io/type": "component", "appstudio.openshift.io/component": componentName}
 
 	list := &appservice.SnapshotList{}
-	err := h.KubeRest().List(context.Background(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(snapshotLabels), Namespace: namespace})
+	err := h.KubeRest().List(h.Context(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(snapshotLabels), Namespace: namespace})
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return nil, fmt.Errorf("error listing snapshots in %s namespace: %v", namespace, err)
@@ -185,7 +185,7 @@ func (h *HasController) WaitForComponentPipelineToBeFinished(component *appservi
 	pr := &pipeline.PipelineRun{}
 
 	for {
-		err := wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 30*time.Minute, true, func(ctx context.Context) (done bool, err error) {
+		err := wait.PollUntilContextTimeout(h.Context(), constants.PipelineRunPollingInterval, 30*time.Minute, true, func(ctx context.Context) (done bool, err error) {
 			pr, err = h.GetComponentPipelineRun(component.GetName(), app, component.GetNamespace(), sha)
 
 			if err != nil {
@@ -226,7 +226,7 @@ func (h *HasController) WaitForComponentPipelineToBeFinished(component *appservi
 			if err = t.RemoveFinalizerFromPipelineRun(pr, constants.E2ETestFinalizerName); err != nil {
 				return fmt.Errorf("failed to remove the finalizer from pipelinerun %s:%s in order to retrigger it: %+v", pr.GetNamespace(), pr.GetName(), err)
 			}
-			if err = h.PipelineClient().TektonV1().PipelineRuns(pr.GetNamespace()).Delete(context.Background(), pr.GetName(), metav1.DeleteOptions{}); err != nil {
+			if err = h.PipelineClient().TektonV1().PipelineRuns(pr.GetNamespace()).Delete(h.Context(), pr.GetName(), metav1.DeleteOptions{}); err != nil {
 				return fmt.Errorf("failed to delete PipelineRun %q from %q namespace with error: %v", pr.GetName(), pr.GetNamespace(), err)
 			}
 			if sha, err = h.RetriggerComponentPipelineRun(component, pr); err != nil {
@@ -276,13 +276,13 @@ func (h *HasController) CreateComponent(componentSpec appservice.ComponentSpec,
 		componentObject.Annotations = utils.MergeMaps(componentObject.Annotations, constants.ImageControllerAnnotationRequestPublicRepo)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*1)
+	ctx, cancel := context.WithTimeout(h.Context(), time.Minute*1)
 	defer cancel()
 	if err := h.KubeRest().Create(ctx, componentObject); err != nil {
 		return nil, err
 	}
 	// Decrease the timeout to 5 mins, when the issue https://issues.redhat.com/browse/STONEBLD-3552 is fixed
-	if utils.WaitUntil(h.CheckImageRepositoryExists(namespace, componentSpec.ComponentName), time.Minute*15) != nil {
+	if utils.WaitUntilContext(h.Context(), h.CheckImageRepositoryExists(namespace, componentSpec.ComponentName), time.Minute*15) != nil {
 		return nil, fmt.Errorf("timed out when waiting for image-controller annotations to be updated on component %s in namespace %s. component: %s", componentSpec.ComponentName, namespace, utils.ToPrettyJSONString(componentObject))
 	}
 	return componentObject, nil
@@ -313,7 +313,7 @@ func (h *HasController) CreateComponentWithDockerSource(applicationName, compone
 			Route:          "",
 		},
 	}
-	err := h.KubeRest().Create(context.Background(), component)
+	err := h.KubeRest().Create(h.Context(), component)
 	if err != nil {
 		return nil, err
 	}
@@ -324,7 +324,7 @@ func (h *HasController) CreateComponentWithDockerSource(applicationName, compone
 func (h *HasController) ScaleComponentReplicas(component *appservice.Component, replicas *int) (*appservice.Component, error) {
 	component.Spec.Replicas = replicas
 
-	err := h.KubeRest().Update(context.Background(), component, &rclient.UpdateOptions{})
+	err := h.KubeRest().Update(h.Context(), component, &rclient.UpdateOptions{})
 	if err != nil {
 		return &appservice.Component{}, err
 	}
@@ -343,14 +343,14 @@ func (h *HasController) DeleteComponent(name string, namespace string, reportErr
 			Namespace: namespace,
 		},
 	}
-	if err := h.KubeRest().Delete(context.Background(), &component); err != nil {
+	if err := h.KubeRest().Delete(h.Context(), &component); err != nil {
 		if !k8sErrors.IsNotFound(err) || (k8sErrors.IsNotFound(err) && reportErrorOnNotFound) {
 			return fmt.Errorf("error deleting a component: %+v", err)
 		}
 	}
 
 	// RHTAPBUGS-978: temporary timeout to 15min
-	err := utils.WaitUntil(h.ComponentDeleted(&component), 15*time.Minute)
+	err := utils.WaitUntilContext(h.Context(), h.ComponentDeleted(&component), 15*time.Minute)
 
 	// temporary logs
 	deletionTime := time.Since(start).Minutes()
@@ -365,14 +365,14 @@ func (h *HasController) DeleteAllComponentsInASpecificNamespace(namespace string
 	start := time.Now()
 	GinkgoWriter.Printf("Start to delete all components in namespace '%s' at %s\n", namespace, start.String())
 
-	if err := h.KubeRest().DeleteAllOf(context.Background(), &appservice.Component{}, rclient.InNamespace(namespace)); err != nil {
+	if err := h.KubeRest().DeleteAllOf(h.Context(), &appservice.Component{}, rclient.InNamespace(namespace)); err != nil {
 		return fmt.Errorf("error deleting components from the namespace %s: %+v", namespace, err)
 	}
 
 	componentList := &appservice.ComponentList{}
 
-	err := utils.WaitUntil(func() (done bool, err error) {
-		if err := h.KubeRest().List(context.Background(), componentList, &rclient.ListOptions{Namespace: namespace}); err != nil {
+	err := utils.WaitUntilContext(h.Context(), func() (done bool, err error) {
+		if err := h.KubeRest().List(h.Context(), componentList, &rclient.ListOptions{Namespace: namespace}); err != nil {
 			return false, nil
 		}
 		return len(componentList.Items) == 0, nil
@@ -474,7 +474,7 @@ func (h *HasController) RetriggerComponentPipelineRun(component *appservice.Comp
 				return fmt.Errorf("failed to get component for PipelineRun %q in %q namespace: %+v", pr.GetName(), pr.GetNamespace(), err)
 			}
 			component.Annotations = utils.MergeMaps(component.Annotations, constants.ComponentTriggerSimpleBuildAnnotation)
-			if err = h.KubeRest().Update(context.Background(), component); err != nil {
+			if err = h.KubeRest().Update(h.Context(), component); err != nil {
 				return fmt.Errorf("failed to update Component %q in %q namespace", component.GetName(), component.GetNamespace())
 			}
 			return err
@@ -484,7 +484,7 @@ func (h *HasController) RetriggerComponentPipelineRun(component *appservice.Comp
 			return "", err
 		}
 	}
-	watch, err := h.PipelineClient().TektonV1().PipelineRuns(component.GetNamespace()).Watch(context.Background(), metav1.ListOptions{})
+	watch, err := h.PipelineClient().TektonV1().PipelineRuns(component.GetNamespace()).Watch(h.Context(), metav1.ListOptions{})
 	if err != nil {
 		return "", fmt.Errorf("error when initiating watch for new PipelineRun after retriggering it for component %s:%s", component.GetNamespace(), component.GetName())
 	}
@@ -517,7 +517,7 @@ func (h *HasController) CheckImageRepositoryExists(namespace, componentName stri
 	return func() (bool, error) {
 		imageRepositoryList := &imagecontroller.ImageRepositoryList{}
 		imageRepoLabels := map[string]string{"appstudio.redhat.com/component": componentName}
-		err := h.KubeRest().List(context.Background(), imageRepositoryList, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(imageRepoLabels), Namespace: namespace})
+		err := h.KubeRest().List(h.Context(), imageRepositoryList, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(imageRepoLabels), Namespace: namespace})
 		if err != nil {
 			return false, err
 		}
@@ -543,7 +543,7 @@ func (h *HasController) SetComponentAnnotation(componentName, annotationKey, ann
 	newAnnotations := component.GetAnnotations()
 	newAnnotations[annotationKey] = annotationValue
 	component.SetAnnotations(newAnnotations)
-	err = h.KubeRest().Update(context.Background(), component)
+	err = h.KubeRest().Update(h.Context(), component)
 	if err != nil {
 		return fmt.Errorf("error when updating component: %+v", err)
 	}
@@ -576,7 +576,7 @@ func (h *HasController) StoreComponent(component *appservice.Component) error {
 // StoreAllComponents stores all Components in a given namespace.
 func (h *HasController) StoreAllComponents(namespace string) error {
 	componentList := &appservice.ComponentList{}
-	if err := h.KubeRest().List(context.Background(), componentList, &rclient.ListOptions{Namespace: namespace}); err != nil {
+	if err := h.KubeRest().List(h.Context(), componentList, &rclient.ListOptions{Namespace: namespace}); err != nil {
 		return err
 	}
 
@@ -590,7 +590,7 @@ func (h *HasController) StoreAllComponents(namespace string) error {
 
 // UpdateComponent updates a component
 func (h *HasController) UpdateComponent(component *appservice.Component) error {
-	err := h.KubeRest().Update(context.Background(), component, &rclient.UpdateOptions{})
+	err := h.KubeRest().Update(h.Context(), component, &rclient.UpdateOptions{})
 
 	if err != nil {
 		return err
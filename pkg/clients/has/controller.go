@@ -1,6 +1,8 @@
 package has
 
 import (
+	"context"
+
 	"github.com/konflux-ci/e2e-tests/pkg/constants"
 	"github.com/konflux-ci/e2e-tests/pkg/utils"
 
@@ -23,8 +25,7 @@ type HasController struct {
 
 // Initializes all the clients and return interface to operate with application-service controller.
 func NewSuiteController(kube *kubeCl.CustomClient) (*HasController, error) {
-	gh, err := github.NewGithubClient(utils.GetEnv(constants.GITHUB_TOKEN_ENV, ""),
-		utils.GetEnv(constants.GITHUB_E2E_ORGANIZATION_ENV, "redhat-appstudio-qe"))
+	gh, err := github.NewGithubClientFromEnv(utils.GetEnv(constants.GITHUB_E2E_ORGANIZATION_ENV, "redhat-appstudio-qe"))
 	if err != nil {
 		return nil, err
 	}
@@ -41,3 +42,10 @@ func NewSuiteController(kube *kubeCl.CustomClient) (*HasController, error) {
 		kube,
 	}, nil
 }
+
+// WithContext returns a copy of the controller whose API calls and waits are bound to ctx
+func (h *HasController) WithContext(ctx context.Context) *HasController {
+	scoped := *h
+	scoped.CustomClient = h.CustomClient.WithContext(ctx)
+	return &scoped
+}
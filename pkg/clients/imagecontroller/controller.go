@@ -1,6 +1,8 @@
 package imagecontroller
 
 import (
+	"context"
+
 	kubeCl "github.com/konflux-ci/e2e-tests/pkg/clients/kubernetes"
 )
 
@@ -13,3 +15,10 @@ func NewSuiteController(kube *kubeCl.CustomClient) (*ImageController, error) {
 		kube,
 	}, nil
 }
+
+// WithContext returns a copy of the controller whose API calls and waits are bound to ctx
+func (i *ImageController) WithContext(ctx context.Context) *ImageController {
+	scoped := *i
+	scoped.CustomClient = i.CustomClient.WithContext(ctx)
+	return &scoped
+}
@@ -1,8 +1,6 @@
 package imagecontroller
 
 import (
-	"context"
-
 	"github.com/konflux-ci/image-controller/api/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -23,7 +21,7 @@ func (i *ImageController) CreateImageRepositoryCR(name, namespace, applicationNa
 		},
 	}
 
-	err := i.KubeRest().Create(context.Background(), imageRepository)
+	err := i.KubeRest().Create(i.Context(), imageRepository)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +37,7 @@ func (i *ImageController) GetImageRepositoryCR(name, namespace string) (*v1alpha
 
 	imageRepository := v1alpha1.ImageRepository{}
 
-	err := i.KubeRest().Get(context.Background(), namespacedName, &imageRepository)
+	err := i.KubeRest().Get(i.Context(), namespacedName, &imageRepository)
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +48,7 @@ func (i *ImageController) GetImageRepositoryCR(name, namespace string) (*v1alpha
 func (i *ImageController) ChangeVisibilityToPrivate(namespace, applicationName, componentName string) (*v1alpha1.ImageRepository, error) {
 	imageRepositoryList := &v1alpha1.ImageRepositoryList{}
 	imageRepoLabels := map[string]string{"appstudio.redhat.com/component": componentName}
-	err := i.KubeRest().List(context.Background(), imageRepositoryList, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(imageRepoLabels), Namespace: namespace})
+	err := i.KubeRest().List(i.Context(), imageRepositoryList, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(imageRepoLabels), Namespace: namespace})
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +56,7 @@ func (i *ImageController) ChangeVisibilityToPrivate(namespace, applicationName,
 	// update visibility to private
 	imageRepository.Spec.Image.Visibility = "private"
 
-	err = i.KubeRest().Update(context.Background(), imageRepository)
+	err = i.KubeRest().Update(i.Context(), imageRepository)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +67,7 @@ func (i *ImageController) ChangeVisibilityToPrivate(namespace, applicationName,
 func (i *ImageController) GetImageName(namespace, componentName string) (string, error) {
 	imageRepositoryList := &v1alpha1.ImageRepositoryList{}
 	imageRepoLabels := map[string]string{"appstudio.redhat.com/component": componentName}
-	err := i.KubeRest().List(context.Background(), imageRepositoryList, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(imageRepoLabels), Namespace: namespace})
+	err := i.KubeRest().List(i.Context(), imageRepositoryList, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(imageRepoLabels), Namespace: namespace})
 	if err != nil {
 		return "", err
 	}
@@ -80,7 +78,7 @@ func (i *ImageController) GetImageName(namespace, componentName string) (string,
 func (i *ImageController) GetRobotAccounts(namespace, componentName string) (string, string, error) {
 	imageRepositoryList := &v1alpha1.ImageRepositoryList{}
 	imageRepoLabels := map[string]string{"appstudio.redhat.com/component": componentName}
-	err := i.KubeRest().List(context.Background(), imageRepositoryList, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(imageRepoLabels), Namespace: namespace})
+	err := i.KubeRest().List(i.Context(), imageRepositoryList, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(imageRepoLabels), Namespace: namespace})
 	if err != nil {
 		return "", "", err
 	}
@@ -91,7 +89,7 @@ func (i *ImageController) GetRobotAccounts(namespace, componentName string) (str
 func (i *ImageController) IsVisibilityPublic(namespace, componentName string) (bool, error) {
 	imageRepositoryList := &v1alpha1.ImageRepositoryList{}
 	imageRepoLabels := map[string]string{"appstudio.redhat.com/component": componentName}
-	err := i.KubeRest().List(context.Background(), imageRepositoryList, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(imageRepoLabels), Namespace: namespace})
+	err := i.KubeRest().List(i.Context(), imageRepositoryList, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(imageRepoLabels), Namespace: namespace})
 	if err != nil {
 		return false, err
 	}
@@ -1,6 +1,8 @@
 package integration
 
 import (
+	"context"
+
 	kubeCl "github.com/konflux-ci/e2e-tests/pkg/clients/kubernetes"
 )
 
@@ -13,3 +15,10 @@ func NewSuiteController(kube *kubeCl.CustomClient) (*IntegrationController, erro
 		kube,
 	}, nil
 }
+
+// WithContext returns a copy of the controller whose API calls and waits are bound to ctx
+func (i *IntegrationController) WithContext(ctx context.Context) *IntegrationController {
+	scoped := *i
+	scoped.CustomClient = i.CustomClient.WithContext(ctx)
+	return &scoped
+}
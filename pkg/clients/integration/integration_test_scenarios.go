@@ -1,8 +1,6 @@
 package integration
 
 import (
-	"context"
-
 	"github.com/devfile/library/v2/pkg/util"
 	"github.com/konflux-ci/e2e-tests/pkg/constants"
 	integrationv1beta2 "github.com/konflux-ci/integration-service/api/v1beta2"
@@ -52,7 +50,7 @@ func (i *IntegrationController) CreateIntegrationTestScenario(itsName, applicati
 		}
 	}
 
-	err := i.KubeRest().Create(context.Background(), integrationTestScenario)
+	err := i.KubeRest().Create(i.Context(), integrationTestScenario)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +64,7 @@ func (i *IntegrationController) GetIntegrationTestScenarios(applicationName, nam
 	}
 
 	integrationTestScenarioList := &integrationv1beta2.IntegrationTestScenarioList{}
-	err := i.KubeRest().List(context.Background(), integrationTestScenarioList, opts...)
+	err := i.KubeRest().List(i.Context(), integrationTestScenarioList, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -82,6 +80,6 @@ func (i *IntegrationController) GetIntegrationTestScenarios(applicationName, nam
 
 // DeleteIntegrationTestScenario removes given testScenario from specified namespace.
 func (i *IntegrationController) DeleteIntegrationTestScenario(testScenario *integrationv1beta2.IntegrationTestScenario, namespace string) error {
-	err := i.KubeRest().Delete(context.Background(), testScenario)
+	err := i.KubeRest().Delete(i.Context(), testScenario)
 	return err
 }
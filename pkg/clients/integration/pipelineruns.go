@@ -52,7 +52,7 @@ func (i *IntegrationController) CreateIntegrationPipelineRun(snapshotName, names
 			},
 		},
 	}
-	err := i.KubeRest().Create(context.Background(), testpipelineRun)
+	err := i.KubeRest().Create(i.Context(), testpipelineRun)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +64,7 @@ func (i *IntegrationController) CreateIntegrationPipelineRun(snapshotName, names
 func (i *IntegrationController) GetBuildPipelineRun(componentName, applicationName, namespace string, pacBuild bool, sha string) (*tektonv1.PipelineRun, error) {
 	var pipelineRun *tektonv1.PipelineRun
 
-	err := wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 20*time.Minute, true, func(ctx context.Context) (done bool, err error) {
+	err := wait.PollUntilContextTimeout(i.Context(), constants.PipelineRunPollingInterval, 20*time.Minute, true, func(ctx context.Context) (done bool, err error) {
 		pipelineRunLabels := map[string]string{"appstudio.openshift.io/component": componentName, "appstudio.openshift.io/application": applicationName, "pipelines.appstudio.openshift.io/type": "build"}
 
 		if sha != "" {
@@ -72,7 +72,7 @@ func (i *IntegrationController) GetBuildPipelineRun(componentName, applicationNa
 		}
 
 		list := &tektonv1.PipelineRunList{}
-		err = i.KubeRest().List(context.Background(), list, &client.ListOptions{LabelSelector: labels.SelectorFromSet(pipelineRunLabels), Namespace: namespace})
+		err = i.KubeRest().List(i.Context(), list, &client.ListOptions{LabelSelector: labels.SelectorFromSet(pipelineRunLabels), Namespace: namespace})
 
 		if err != nil && !k8sErrors.IsNotFound(err) {
 			GinkgoWriter.Printf("error listing pipelineruns in %s namespace: %v", namespace, err)
@@ -110,7 +110,7 @@ func (i *IntegrationController) GetIntegrationPipelineRun(integrationTestScenari
 	}
 
 	list := &tektonv1.PipelineRunList{}
-	err := i.KubeRest().List(context.Background(), list, opts...)
+	err := i.KubeRest().List(i.Context(), list, opts...)
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return nil, fmt.Errorf("error listing pipelineruns in %s namespace", namespace)
@@ -128,7 +128,7 @@ func (i *IntegrationController) GetIntegrationPipelineRun(integrationTestScenari
 func (i *IntegrationController) WaitForIntegrationPipelineToGetStarted(testScenarioName, snapshotName, appNamespace string) (*tektonv1.PipelineRun, error) {
 	var testPipelinerun *tektonv1.PipelineRun
 
-	err := wait.PollUntilContextTimeout(context.Background(), time.Second*2, time.Minute*5, true, func(ctx context.Context) (done bool, err error) {
+	err := wait.PollUntilContextTimeout(i.Context(), time.Second*2, time.Minute*5, true, func(ctx context.Context) (done bool, err error) {
 		testPipelinerun, err = i.GetIntegrationPipelineRun(testScenarioName, snapshotName, appNamespace)
 		if err != nil {
 			GinkgoWriter.Println("PipelineRun has not been created yet for test scenario %s and snapshot %s/%s", testScenarioName, appNamespace, snapshotName)
@@ -147,7 +147,7 @@ func (i *IntegrationController) WaitForIntegrationPipelineToGetStarted(testScena
 // WaitForIntegrationPipelineToBeFinished wait for given integration pipeline to finish.
 // In case of failure, this function retries till it gets timed out.
 func (i *IntegrationController) WaitForIntegrationPipelineToBeFinished(testScenario *integrationv1beta2.IntegrationTestScenario, snapshot *appstudioApi.Snapshot, appNamespace string) error {
-	return wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 20*time.Minute, true, func(ctx context.Context) (done bool, err error) {
+	return wait.PollUntilContextTimeout(i.Context(), constants.PipelineRunPollingInterval, 20*time.Minute, true, func(ctx context.Context) (done bool, err error) {
 		pipelineRun, err := i.GetIntegrationPipelineRun(testScenario.Name, snapshot.Name, appNamespace)
 		if err != nil {
 			GinkgoWriter.Println("PipelineRun has not been created yet for test scenario %s and snapshot %s/%s", testScenario.GetName(), snapshot.GetNamespace(), snapshot.GetName())
@@ -225,7 +225,7 @@ func (i *IntegrationController) WaitForFinalizerToGetRemovedFromAllIntegrationPi
 // WaitForFinalizerToGetRemovedFromIntegrationPipeline waits for the
 // given finalizer to get removed from the given integration pipelinerun
 func (i *IntegrationController) WaitForFinalizerToGetRemovedFromIntegrationPipeline(testScenario *integrationv1beta2.IntegrationTestScenario, snapshot *appstudioApi.Snapshot, appNamespace string) error {
-	return wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 10*time.Minute, true, func(ctx context.Context) (done bool, err error) {
+	return wait.PollUntilContextTimeout(i.Context(), constants.PipelineRunPollingInterval, 10*time.Minute, true, func(ctx context.Context) (done bool, err error) {
 		pipelineRun, err := i.GetIntegrationPipelineRun(testScenario.Name, snapshot.Name, appNamespace)
 		if err != nil {
 			GinkgoWriter.Println("PipelineRun has not been created yet for test scenario %s and snapshot %s/%s", testScenario.GetName(), snapshot.GetNamespace(), snapshot.GetName())
@@ -252,7 +252,7 @@ func (i *IntegrationController) GetAnnotationIfExists(testNamespace, application
 // WaitForBuildPipelineRunToGetAnnotated waits for given build pipeline to get annotated with a specific annotation.
 // In case of failure, this function retries till it gets timed out.
 func (i *IntegrationController) WaitForBuildPipelineRunToGetAnnotated(testNamespace, applicationName, componentName, annotationKey string) error {
-	return wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 5*time.Minute, true, func(ctx context.Context) (done bool, err error) {
+	return wait.PollUntilContextTimeout(i.Context(), constants.PipelineRunPollingInterval, 5*time.Minute, true, func(ctx context.Context) (done bool, err error) {
 		pipelineRun, err := i.GetBuildPipelineRun(componentName, applicationName, testNamespace, false, "")
 		if err != nil {
 			GinkgoWriter.Printf("pipelinerun for Component %s/%s can't be gotten successfully. Error: %v", testNamespace, componentName, err)
@@ -272,7 +272,7 @@ func (i *IntegrationController) WaitForBuildPipelineRunToGetAnnotated(testNamesp
 // It exposes the error message from the failed task to the end user when the pipelineRun failed.
 func (i *IntegrationController) WaitForBuildPipelineToBeFinished(testNamespace, applicationName, componentName, sha string) (error, string) {
 	var logs string
-	return wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 30*time.Minute, true, func(ctx context.Context) (done bool, err error) {
+	return wait.PollUntilContextTimeout(i.Context(), constants.PipelineRunPollingInterval, 30*time.Minute, true, func(ctx context.Context) (done bool, err error) {
 		pipelineRun, err := i.GetBuildPipelineRun(componentName, applicationName, testNamespace, false, sha)
 		if err != nil {
 			GinkgoWriter.Println("Build pipelineRun has not been created yet for app %s/%s, and component %s", testNamespace, applicationName, componentName)
@@ -62,7 +62,7 @@ func (i *IntegrationController) CreateSnapshotWithComponents(snapshotName, compo
 			Components:  snapshotComponents,
 		},
 	}
-	return snapshot, i.KubeRest().Create(context.Background(), snapshot)
+	return snapshot, i.KubeRest().Create(i.Context(), snapshot)
 }
 
 // CreateSnapshotWithImage creates a snapshot using an image.
@@ -88,7 +88,7 @@ func (i *IntegrationController) GetSnapshotByComponent(namespace string) (*appst
 		},
 		client.InNamespace(namespace),
 	}
-	err := i.KubeRest().List(context.Background(), snapshot, opts...)
+	err := i.KubeRest().List(i.Context(), snapshot, opts...)
 
 	if err == nil && len(snapshot.Items) > 0 {
 		return &snapshot.Items[0], nil
@@ -100,7 +100,7 @@ func (i *IntegrationController) GetSnapshotByComponent(namespace string) (*appst
 // It will search for the Snapshot based on the Snapshot name, associated PipelineRun name or Component name
 // In the case the List operation fails, an error will be returned.
 func (i *IntegrationController) GetSnapshot(snapshotName, pipelineRunName, componentName, namespace string) (*appstudioApi.Snapshot, error) {
-	ctx := context.Background()
+	ctx := i.Context()
 	// If Snapshot name is provided, try to get the resource directly
 	if len(snapshotName) > 0 {
 		snapshot := &appstudioApi.Snapshot{}
@@ -138,24 +138,24 @@ func (i *IntegrationController) GetSnapshot(snapshotName, pipelineRunName, compo
 
 // DeleteSnapshot removes given snapshot from specified namespace.
 func (i *IntegrationController) DeleteSnapshot(hasSnapshot *appstudioApi.Snapshot, namespace string) error {
-	err := i.KubeRest().Delete(context.Background(), hasSnapshot)
+	err := i.KubeRest().Delete(i.Context(), hasSnapshot)
 	return err
 }
 
 // PatchSnapshot patches the given snapshot with the provided patch.
 func (i *IntegrationController) PatchSnapshot(oldSnapshot *appstudioApi.Snapshot, newSnapshot *appstudioApi.Snapshot) error {
 	patch := client.MergeFrom(oldSnapshot)
-	err := i.KubeRest().Patch(context.Background(), newSnapshot, patch)
+	err := i.KubeRest().Patch(i.Context(), newSnapshot, patch)
 	return err
 }
 
 // DeleteAllSnapshotsInASpecificNamespace removes all snapshots from a specific namespace. Useful when creating a lot of resources and want to remove all of them
 func (i *IntegrationController) DeleteAllSnapshotsInASpecificNamespace(namespace string, timeout time.Duration) error {
-	if err := i.KubeRest().DeleteAllOf(context.Background(), &appstudioApi.Snapshot{}, client.InNamespace(namespace)); err != nil {
+	if err := i.KubeRest().DeleteAllOf(i.Context(), &appstudioApi.Snapshot{}, client.InNamespace(namespace)); err != nil {
 		return fmt.Errorf("error deleting snapshots from the namespace %s: %+v", namespace, err)
 	}
 
-	return utils.WaitUntil(func() (done bool, err error) {
+	return utils.WaitUntilContext(i.Context(), func() (done bool, err error) {
 		snapshotList, err := i.ListAllSnapshots(namespace)
 		if err != nil {
 			return false, nil
@@ -168,7 +168,7 @@ func (i *IntegrationController) DeleteAllSnapshotsInASpecificNamespace(namespace
 func (i *IntegrationController) WaitForSnapshotToGetCreated(snapshotName, pipelinerunName, componentName, testNamespace string) (*appstudioApi.Snapshot, error) {
 	var snapshot *appstudioApi.Snapshot
 
-	err := wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 10*time.Minute, true, func(ctx context.Context) (done bool, err error) {
+	err := wait.PollUntilContextTimeout(i.Context(), constants.PipelineRunPollingInterval, 10*time.Minute, true, func(ctx context.Context) (done bool, err error) {
 		snapshot, err = i.GetSnapshot(snapshotName, pipelinerunName, componentName, testNamespace)
 		if err != nil {
 			GinkgoWriter.Printf("unable to get the Snapshot within the namespace %s. Error: %v", testNamespace, err)
@@ -184,7 +184,7 @@ func (i *IntegrationController) WaitForSnapshotToGetCreated(snapshotName, pipeli
 // ListAllSnapshots returns a list of all Snapshots in a given namespace.
 func (i *IntegrationController) ListAllSnapshots(namespace string) (*appstudioApi.SnapshotList, error) {
 	snapshotList := &appstudioApi.SnapshotList{}
-	err := i.KubeRest().List(context.Background(), snapshotList, &client.ListOptions{Namespace: namespace})
+	err := i.KubeRest().List(i.Context(), snapshotList, &client.ListOptions{Namespace: namespace})
 
 	return snapshotList, err
 }
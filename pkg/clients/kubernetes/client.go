@@ -48,6 +48,8 @@ type CustomClient struct {
 	dynamicClient         dynamic.Interface
 	jvmbuildserviceClient jvmbuildserviceclientset.Interface
 	routeClient           routeclientset.Interface
+	ctx                   context.Context
+	tracker               *ResourceTracker
 }
 
 type K8SClient struct {
@@ -80,6 +82,22 @@ func init() {
 	utilruntime.Must(pacv1alpha1.AddToScheme(scheme))
 }
 
+// Context returns the context API calls and waits of the client are bound to, context.Background() if none was set.
+func (c *CustomClient) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// WithContext returns a copy of the client which binds API calls and waits to the given context,
+// so e.g. cancelling Ginkgo's SpecContext stops them. Underlying clientsets are shared.
+func (c *CustomClient) WithContext(ctx context.Context) *CustomClient {
+	scoped := *c
+	scoped.ctx = ctx
+	return &scoped
+}
+
 // Kube returns the clientset for Kubernetes upstream.
 func (c *CustomClient) KubeInterface() kubernetes.Interface {
 	return c.kubeClient
@@ -87,9 +105,23 @@ func (c *CustomClient) KubeInterface() kubernetes.Interface {
 
 // Return a rest client to perform CRUD operations on Kubernetes objects
 func (c *CustomClient) KubeRest() crclient.Client {
+	if c.tracker != nil {
+		return &trackingClient{Client: c.crClient, tracker: c.tracker}
+	}
 	return c.crClient
 }
 
+// SetResourceTracker makes objects created through KubeRest() of the client (and of its copies
+// made afterwards) recorded to the tracker. Nil stops the tracking.
+func (c *CustomClient) SetResourceTracker(tracker *ResourceTracker) {
+	c.tracker = tracker
+}
+
+// ResourceTracker returns tracker objects created through the client are recorded to, nil if none
+func (c *CustomClient) ResourceTracker() *ResourceTracker {
+	return c.tracker
+}
+
 func (c *CustomClient) PipelineClient() pipelineclientset.Interface {
 	return c.pipelineClient
 }
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Kinds of tracked objects are deleted in this order, so e.g. Releases are deleted before ReleasePlans
+// and Components before Applications. Kinds not listed here are deleted last.
+var cleanupOrder = map[string]int{
+	"Release":                 0,
+	"PipelineRun":             0,
+	"TaskRun":                 0,
+	"Snapshot":                1,
+	"ReleasePlan":             2,
+	"ReleasePlanAdmission":    2,
+	"IntegrationTestScenario": 2,
+	"ImageRepository":         3,
+	"Component":               4,
+	"Application":             5,
+}
+
+const cleanupOrderDefault = 6
+
+// TrackedObject identifies object created through a client with resource tracker
+type TrackedObject struct {
+	GVK       schema.GroupVersionKind
+	Namespace string
+	Name      string
+}
+
+func (o TrackedObject) String() string {
+	if o.Namespace == "" {
+		return fmt.Sprintf("%s %s", o.GVK.Kind, o.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", o.GVK.Kind, o.Namespace, o.Name)
+}
+
+// ResourceTracker records objects created through KubeRest() of clients it is set on, so they can be cleaned up later
+type ResourceTracker struct {
+	mutex   sync.Mutex
+	objects []TrackedObject
+}
+
+func NewResourceTracker() *ResourceTracker {
+	return &ResourceTracker{}
+}
+
+// Track records the object, objects already tracked are ignored
+func (t *ResourceTracker) Track(obj TrackedObject) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for _, o := range t.objects {
+		if o == obj {
+			return
+		}
+	}
+	t.objects = append(t.objects, obj)
+}
+
+// Objects returns tracked objects in order they were created
+func (t *ResourceTracker) Objects() []TrackedObject {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return append([]TrackedObject{}, t.objects...)
+}
+
+// CleanupOrder returns tracked objects in order they should be deleted: grouped by kind according
+// to cleanupOrder and in reverse creation order within the group
+func (t *ResourceTracker) CleanupOrder() [][]TrackedObject {
+	objects := t.Objects()
+
+	groups := map[int][]TrackedObject{}
+	for i := len(objects) - 1; i >= 0; i-- {
+		rank, ok := cleanupOrder[objects[i].GVK.Kind]
+		if !ok {
+			rank = cleanupOrderDefault
+		}
+		groups[rank] = append(groups[rank], objects[i])
+	}
+
+	ranks := []int{}
+	for rank := range groups {
+		ranks = append(ranks, rank)
+	}
+	sort.Ints(ranks)
+
+	ordered := [][]TrackedObject{}
+	for _, rank := range ranks {
+		ordered = append(ordered, groups[rank])
+	}
+	return ordered
+}
+
+// Cleanup deletes tracked objects group by group (see CleanupOrder), waiting up to timeout for every
+// group to disappear before deleting the next one. Objects which do not exist anymore are fine.
+// Deleted objects are forgotten, so Cleanup can be called again to retry the failed ones.
+func (t *ResourceTracker) Cleanup(ctx context.Context, c crclient.Client, timeout time.Duration) error {
+	var errs []error
+
+	for _, group := range t.CleanupOrder() {
+		deleted := []TrackedObject{}
+		for _, obj := range group {
+			err := c.Delete(ctx, obj.unstructured(), crclient.PropagationPolicy(metav1.DeletePropagationBackground))
+			if err != nil && !k8sErrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("failed to delete %s: %+v", obj, err))
+				continue
+			}
+			deleted = append(deleted, obj)
+		}
+
+		err := wait.PollUntilContextTimeout(ctx, time.Second*2, timeout, true, func(ctx context.Context) (bool, error) {
+			for _, obj := range deleted {
+				err := c.Get(ctx, crclient.ObjectKey{Namespace: obj.Namespace, Name: obj.Name}, obj.unstructured())
+				if err == nil || !k8sErrors.IsNotFound(err) {
+					return false, nil
+				}
+			}
+			return true, nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%v were not deleted in time: %+v", deleted, err))
+			continue
+		}
+
+		t.forget(deleted)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (t *ResourceTracker) forget(objects []TrackedObject) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	remaining := []TrackedObject{}
+	for _, o := range t.objects {
+		found := false
+		for _, f := range objects {
+			if o == f {
+				found = true
+				break
+			}
+		}
+		if !found {
+			remaining = append(remaining, o)
+		}
+	}
+	t.objects = remaining
+}
+
+func (o TrackedObject) unstructured() *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(o.GVK)
+	u.SetNamespace(o.Namespace)
+	u.SetName(o.Name)
+	return u
+}
+
+// trackingClient records objects it creates to resource tracker
+type trackingClient struct {
+	crclient.Client
+	tracker *ResourceTracker
+}
+
+func (c *trackingClient) Create(ctx context.Context, obj crclient.Object, opts ...crclient.CreateOption) error {
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+
+	// Objects created with dry run do not exist
+	if len((&crclient.CreateOptions{}).ApplyOptions(opts).DryRun) > 0 {
+		return nil
+	}
+
+	// Objects of types unknown to the scheme can not be deleted later anyway
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return nil
+	}
+	c.tracker.Track(TrackedObject{GVK: gvk, Namespace: obj.GetNamespace(), Name: obj.GetName()})
+	return nil
+}
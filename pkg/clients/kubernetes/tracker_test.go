@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appstudioApi "github.com/konflux-ci/application-api/api/v1alpha1"
+	release "github.com/konflux-ci/release-service/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTrackedClient() (*CustomClient, *ResourceTracker) {
+	c := &CustomClient{crClient: fake.NewClientBuilder().WithScheme(scheme).Build()}
+	tracker := NewResourceTracker()
+	c.SetResourceTracker(tracker)
+	return c, tracker
+}
+
+func TestResourceTrackerCleanupOrder(t *testing.T) {
+	c, tracker := newTrackedClient()
+	ctx := context.Background()
+	meta := func(name string) metav1.ObjectMeta { return metav1.ObjectMeta{Name: name, Namespace: "ns"} }
+
+	assert.NoError(t, c.KubeRest().Create(ctx, &appstudioApi.Application{ObjectMeta: meta("app")}))
+	assert.NoError(t, c.KubeRest().Create(ctx, &appstudioApi.Component{ObjectMeta: meta("comp-1")}))
+	assert.NoError(t, c.KubeRest().Create(ctx, &release.ReleasePlan{ObjectMeta: meta("rp")}))
+	assert.NoError(t, c.KubeRest().Create(ctx, &appstudioApi.Component{ObjectMeta: meta("comp-2")}))
+	assert.NoError(t, c.KubeRest().Create(ctx, &release.Release{ObjectMeta: meta("rel")}))
+	assert.NoError(t, c.KubeRest().Create(ctx, &appstudioApi.Snapshot{ObjectMeta: meta("snap")}, crclient.DryRunAll))
+
+	order := []string{}
+	for _, group := range tracker.CleanupOrder() {
+		for _, obj := range group {
+			order = append(order, obj.String())
+		}
+	}
+	assert.Equal(t, []string{
+		"Release ns/rel",
+		"ReleasePlan ns/rp",
+		"Component ns/comp-2",
+		"Component ns/comp-1",
+		"Application ns/app",
+	}, order)
+
+	assert.NoError(t, tracker.Cleanup(ctx, c.KubeRest(), time.Second*10))
+	assert.Empty(t, tracker.Objects())
+
+	apps := &appstudioApi.ApplicationList{}
+	assert.NoError(t, c.KubeRest().List(ctx, apps))
+	assert.Empty(t, apps.Items)
+}
+
+func TestResourceTrackerCleanupAlreadyDeleted(t *testing.T) {
+	c, tracker := newTrackedClient()
+	ctx := context.Background()
+
+	app := &appstudioApi.Application{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"}}
+	assert.NoError(t, c.KubeRest().Create(ctx, app))
+	assert.NoError(t, c.KubeRest().Delete(ctx, app))
+
+	assert.NoError(t, tracker.Cleanup(ctx, c.KubeRest(), time.Second*10))
+	assert.Empty(t, tracker.Objects())
+}
+
+func TestCopiesKeepResourceTracker(t *testing.T) {
+	c, tracker := newTrackedClient()
+	scoped := c.WithContext(context.Background())
+
+	assert.Same(t, tracker, scoped.ResourceTracker())
+	assert.NoError(t, scoped.KubeRest().Create(context.Background(), &appstudioApi.Application{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"}}))
+	assert.Len(t, tracker.Objects(), 1)
+}
@@ -1,6 +1,10 @@
 package release
 
-import kubeCl "github.com/konflux-ci/e2e-tests/pkg/clients/kubernetes"
+import (
+	"context"
+
+	kubeCl "github.com/konflux-ci/e2e-tests/pkg/clients/kubernetes"
+)
 
 // Factory to initialize the comunication against different API like github or kubernetes.
 type ReleaseController struct {
@@ -14,3 +18,10 @@ func NewSuiteController(kube *kubeCl.CustomClient) (*ReleaseController, error) {
 		kube,
 	}, nil
 }
+
+// WithContext returns a copy of the controller whose API calls and waits are bound to ctx
+func (r *ReleaseController) WithContext(ctx context.Context) *ReleaseController {
+	scoped := *r
+	scoped.CustomClient = r.CustomClient.WithContext(ctx)
+	return &scoped
+}
@@ -1,7 +1,6 @@
 package release
 
 import (
-	"context"
 	"strconv"
 
 	tektonutils "github.com/konflux-ci/release-service/tekton/utils"
@@ -40,7 +39,7 @@ func (r *ReleaseController) CreateReleasePlan(name, namespace, application, targ
 		releasePlan.ObjectMeta.Labels[releaseMetadata.AutoReleaseLabel] = "false"
 	}
 
-	return releasePlan, r.KubeRest().Create(context.Background(), releasePlan)
+	return releasePlan, r.KubeRest().Create(r.Context(), releasePlan)
 }
 
 // CreateReleasePlanAdmission creates a new ReleasePlanAdmission using the given parameters.
@@ -66,14 +65,14 @@ func (r *ReleaseController) CreateReleasePlanAdmission(name, namespace, environm
 		},
 	}
 
-	return releasePlanAdmission, r.KubeRest().Create(context.Background(), releasePlanAdmission)
+	return releasePlanAdmission, r.KubeRest().Create(r.Context(), releasePlanAdmission)
 }
 
 // GetReleasePlan returns the ReleasePlan with the given name in the given namespace.
 func (r *ReleaseController) GetReleasePlan(name, namespace string) (*releaseApi.ReleasePlan, error) {
 	releasePlan := &releaseApi.ReleasePlan{}
 
-	err := r.KubeRest().Get(context.Background(), types.NamespacedName{
+	err := r.KubeRest().Get(r.Context(), types.NamespacedName{
 		Name:      name,
 		Namespace: namespace,
 	}, releasePlan)
@@ -85,7 +84,7 @@ func (r *ReleaseController) GetReleasePlan(name, namespace string) (*releaseApi.
 func (r *ReleaseController) GetReleasePlanAdmission(name, namespace string) (*releaseApi.ReleasePlanAdmission, error) {
 	releasePlanAdmission := &releaseApi.ReleasePlanAdmission{}
 
-	err := r.KubeRest().Get(context.Background(), types.NamespacedName{
+	err := r.KubeRest().Get(r.Context(), types.NamespacedName{
 		Name:      name,
 		Namespace: namespace,
 	}, releasePlanAdmission)
@@ -101,7 +100,7 @@ func (r *ReleaseController) DeleteReleasePlan(name, namespace string, failOnNotF
 			Namespace: namespace,
 		},
 	}
-	err := r.KubeRest().Delete(context.Background(), releasePlan)
+	err := r.KubeRest().Delete(r.Context(), releasePlan)
 	if err != nil && !failOnNotFound && k8sErrors.IsNotFound(err) {
 		err = nil
 	}
@@ -118,7 +117,7 @@ func (r *ReleaseController) DeleteReleasePlanAdmission(name, namespace string, f
 			Namespace: namespace,
 		},
 	}
-	err := r.KubeRest().Delete(context.Background(), &releasePlanAdmission)
+	err := r.KubeRest().Delete(r.Context(), &releasePlanAdmission)
 	if err != nil && !failOnNotFound && k8sErrors.IsNotFound(err) {
 		err = nil
 	}
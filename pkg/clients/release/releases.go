@@ -35,7 +35,7 @@ func (r *ReleaseController) CreateRelease(name, namespace, snapshot, releasePlan
 		},
 	}
 
-	return release, r.KubeRest().Create(context.Background(), release)
+	return release, r.KubeRest().Create(r.Context(), release)
 }
 
 // CreateReleasePipelineRoleBindingForServiceAccount creates a RoleBinding for the passed serviceAccount to enable
@@ -59,7 +59,7 @@ func (r *ReleaseController) CreateReleasePipelineRoleBindingForServiceAccount(na
 			},
 		},
 	}
-	err := r.KubeRest().Create(context.Background(), roleBinding)
+	err := r.KubeRest().Create(r.Context(), roleBinding)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +69,7 @@ func (r *ReleaseController) CreateReleasePipelineRoleBindingForServiceAccount(na
 // GetRelease returns the release with in the given namespace.
 // It can find a Release CR based on provided name or a name of an associated Snapshot
 func (r *ReleaseController) GetRelease(releaseName, snapshotName, namespace string) (*releaseApi.Release, error) {
-	ctx := context.Background()
+	ctx := r.Context()
 	if len(releaseName) > 0 {
 		release := &releaseApi.Release{}
 		err := r.KubeRest().Get(ctx, types.NamespacedName{Name: releaseName, Namespace: namespace}, release)
@@ -82,7 +82,7 @@ func (r *ReleaseController) GetRelease(releaseName, snapshotName, namespace stri
 	opts := []client.ListOption{
 		client.InNamespace(namespace),
 	}
-	if err := r.KubeRest().List(context.Background(), releaseList, opts...); err != nil {
+	if err := r.KubeRest().List(r.Context(), releaseList, opts...); err != nil {
 		return nil, err
 	}
 	for _, r := range releaseList.Items {
@@ -99,7 +99,7 @@ func (r *ReleaseController) GetReleases(namespace string) (*releaseApi.ReleaseLi
 	opts := []client.ListOption{
 		client.InNamespace(namespace),
 	}
-	err := r.KubeRest().List(context.Background(), releaseList, opts...)
+	err := r.KubeRest().List(r.Context(), releaseList, opts...)
 
 	return releaseList, err
 }
@@ -164,7 +164,7 @@ func (r *ReleaseController) GetPipelineRunInNamespace(namespace, releaseName, re
 		client.InNamespace(namespace),
 	}
 
-	err := r.KubeRest().List(context.Background(), pipelineRuns, opts...)
+	err := r.KubeRest().List(r.Context(), pipelineRuns, opts...)
 
 	if err == nil && len(pipelineRuns.Items) > 0 {
 		return &pipelineRuns.Items[0], nil
@@ -178,7 +178,7 @@ func (r *ReleaseController) GetPipelineRunInNamespace(namespace, releaseName, re
 func (r *ReleaseController) WaitForReleasePipelineToGetStarted(release *releaseApi.Release, managedNamespace string) (*pipeline.PipelineRun, error) {
 	var releasePipelinerun *pipeline.PipelineRun
 
-	err := wait.PollUntilContextTimeout(context.Background(), time.Second*2, time.Minute*5, true, func(ctx context.Context) (done bool, err error) {
+	err := wait.PollUntilContextTimeout(r.Context(), time.Second*2, time.Minute*5, true, func(ctx context.Context) (done bool, err error) {
 		releasePipelinerun, err = r.GetPipelineRunInNamespace(managedNamespace, release.GetName(), release.GetNamespace())
 		if err != nil {
 			GinkgoWriter.Println("PipelineRun has not been created yet for release %s/%s", release.GetNamespace(), release.GetName())
@@ -197,7 +197,7 @@ func (r *ReleaseController) WaitForReleasePipelineToGetStarted(release *releaseA
 // WaitForReleasePipelineToBeFinished wait for given release pipeline to finish.
 // It exposes the error message from the failed task to the end user when the pipelineRun failed.
 func (r *ReleaseController) WaitForReleasePipelineToBeFinished(release *releaseApi.Release, managedNamespace string) error {
-	return wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 30*time.Minute, true, func(ctx context.Context) (done bool, err error) {
+	return wait.PollUntilContextTimeout(r.Context(), constants.PipelineRunPollingInterval, 30*time.Minute, true, func(ctx context.Context) (done bool, err error) {
 		pipelineRun, err := r.GetPipelineRunInNamespace(managedNamespace, release.GetName(), release.GetNamespace())
 		if err != nil {
 			GinkgoWriter.Println("PipelineRun has not been created yet for release %s/%s", release.GetNamespace(), release.GetName())
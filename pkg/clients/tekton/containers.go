@@ -1,7 +1,6 @@
 package tekton
 
 import (
-	"context"
 	"io"
 
 	corev1 "k8s.io/api/core/v1"
@@ -11,7 +10,7 @@ import (
 func (t *TektonController) fetchContainerLog(podName, containerName, namespace string) (string, error) {
 	podClient := t.KubeInterface().CoreV1().Pods(namespace)
 	req := podClient.GetLogs(podName, &corev1.PodLogOptions{Container: containerName})
-	readCloser, err := req.Stream(context.Background())
+	readCloser, err := req.Stream(t.Context())
 	log := ""
 	if err != nil {
 		return log, err
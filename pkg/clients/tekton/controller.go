@@ -1,6 +1,8 @@
 package tekton
 
 import (
+	"context"
+
 	kubeCl "github.com/konflux-ci/e2e-tests/pkg/clients/kubernetes"
 )
 
@@ -15,3 +17,10 @@ func NewSuiteController(kube *kubeCl.CustomClient) *TektonController {
 		kube,
 	}
 }
+
+// WithContext returns a copy of the controller whose API calls and waits are bound to ctx
+func (t *TektonController) WithContext(ctx context.Context) *TektonController {
+	scoped := *t
+	scoped.CustomClient = t.CustomClient.WithContext(ctx)
+	return &scoped
+}
@@ -1,8 +1,6 @@
 package tekton
 
 import (
-	"context"
-
 	ecp "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,7 +16,7 @@ func (t *TektonController) CreateEnterpriseContractPolicy(name, namespace string
 		},
 		Spec: ecpolicy,
 	}
-	return ec, t.KubeRest().Create(context.Background(), ec)
+	return ec, t.KubeRest().Create(t.Context(), ec)
 }
 
 // CreateOrUpdatePolicyConfiguration creates new policy if it doesn't exist, otherwise updates the existing one, in a specified namespace.
@@ -31,7 +29,7 @@ func (t *TektonController) CreateOrUpdatePolicyConfiguration(namespace string, p
 	}
 
 	// fetch to see if it exists
-	err := t.KubeRest().Get(context.Background(), crclient.ObjectKey{
+	err := t.KubeRest().Get(t.Context(), crclient.ObjectKey{
 		Namespace: namespace,
 		Name:      "ec-policy",
 	}, &ecPolicy)
@@ -48,12 +46,12 @@ func (t *TektonController) CreateOrUpdatePolicyConfiguration(namespace string, p
 	ecPolicy.Spec = policy
 	if !exists {
 		// it doesn't, so create
-		if err := t.KubeRest().Create(context.Background(), &ecPolicy); err != nil {
+		if err := t.KubeRest().Create(t.Context(), &ecPolicy); err != nil {
 			return err
 		}
 	} else {
 		// it does, so update
-		if err := t.KubeRest().Update(context.Background(), &ecPolicy); err != nil {
+		if err := t.KubeRest().Update(t.Context(), &ecPolicy); err != nil {
 			return err
 		}
 	}
@@ -69,7 +67,7 @@ func (t *TektonController) GetEnterpriseContractPolicy(name, namespace string) (
 			Namespace: namespace,
 		},
 	}
-	err := t.KubeRest().Get(context.Background(), crclient.ObjectKey{
+	err := t.KubeRest().Get(t.Context(), crclient.ObjectKey{
 		Namespace: namespace,
 		Name:      name,
 	}, &defaultEcPolicy)
@@ -85,7 +83,7 @@ func (t *TektonController) DeleteEnterpriseContractPolicy(name string, namespace
 			Namespace: namespace,
 		},
 	}
-	err := t.KubeRest().Delete(context.Background(), &ecPolicy)
+	err := t.KubeRest().Delete(t.Context(), &ecPolicy)
 	if err != nil && !failOnNotFound && errors.IsNotFound(err) {
 		err = nil
 	}
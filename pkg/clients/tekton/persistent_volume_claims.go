@@ -1,8 +1,6 @@
 package tekton
 
 import (
-	"context"
-
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,7 +25,7 @@ func (t *TektonController) CreatePVCInAccessMode(name, namespace string, accessM
 		},
 	}
 
-	createdPVC, err := t.KubeInterface().CoreV1().PersistentVolumeClaims(namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
+	createdPVC, err := t.KubeInterface().CoreV1().PersistentVolumeClaims(namespace).Create(t.Context(), pvc, metav1.CreateOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -35,9 +33,9 @@ func (t *TektonController) CreatePVCInAccessMode(name, namespace string, accessM
 }
 
 func (t *TektonController) DeletePVC(name, namespace string) error {
-	return t.KubeInterface().CoreV1().PersistentVolumeClaims(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+	return t.KubeInterface().CoreV1().PersistentVolumeClaims(namespace).Delete(t.Context(), name, metav1.DeleteOptions{})
 }
 
 func (t *TektonController) GetPVC(name, namespace string) (*corev1.PersistentVolumeClaim, error) {
-	return t.KubeInterface().CoreV1().PersistentVolumeClaims(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	return t.KubeInterface().CoreV1().PersistentVolumeClaims(namespace).Get(t.Context(), name, metav1.GetOptions{})
 }
@@ -26,7 +26,7 @@ import (
 
 // CreatePipelineRun creates a tekton pipelineRun and returns the pipelineRun or error
 func (t *TektonController) CreatePipelineRun(pipelineRun *pipeline.PipelineRun, ns string) (*pipeline.PipelineRun, error) {
-	return t.PipelineClient().TektonV1().PipelineRuns(ns).Create(context.Background(), pipelineRun, metav1.CreateOptions{})
+	return t.PipelineClient().TektonV1().PipelineRuns(ns).Create(t.Context(), pipelineRun, metav1.CreateOptions{})
 }
 
 // createAndWait creates a pipelineRun and waits until it starts.
@@ -36,7 +36,7 @@ func (t *TektonController) createAndWait(pr *pipeline.PipelineRun, namespace str
 		return nil, err
 	}
 	g.GinkgoWriter.Printf("Creating Pipeline %q\n", pipelineRun.Name)
-	return pipelineRun, utils.WaitUntil(t.CheckPipelineRunStarted(pipelineRun.Name, namespace), time.Duration(taskTimeout)*time.Second)
+	return pipelineRun, utils.WaitUntilContext(t.Context(), t.CheckPipelineRunStarted(pipelineRun.Name, namespace), time.Duration(taskTimeout)*time.Second)
 }
 
 // RunPipeline creates a pipelineRun and waits for it to start.
@@ -49,7 +49,7 @@ func (t *TektonController) RunPipeline(g tekton.PipelineRunGenerator, namespace
 	for _, w := range pr.Spec.Workspaces {
 		if w.PersistentVolumeClaim != nil {
 			pvcName := w.PersistentVolumeClaim.ClaimName
-			if _, err := pvcs.Get(context.Background(), pvcName, metav1.GetOptions{}); err != nil {
+			if _, err := pvcs.Get(t.Context(), pvcName, metav1.GetOptions{}); err != nil {
 				if errors.IsNotFound(err) {
 					err := tekton.CreatePVC(pvcs, pvcName)
 					if err != nil {
@@ -67,13 +67,13 @@ func (t *TektonController) RunPipeline(g tekton.PipelineRunGenerator, namespace
 
 // GetPipelineRun returns a pipelineRun with a given name.
 func (t *TektonController) GetPipelineRun(pipelineRunName, namespace string) (*pipeline.PipelineRun, error) {
-	return t.PipelineClient().TektonV1().PipelineRuns(namespace).Get(context.Background(), pipelineRunName, metav1.GetOptions{})
+	return t.PipelineClient().TektonV1().PipelineRuns(namespace).Get(t.Context(), pipelineRunName, metav1.GetOptions{})
 }
 
 // GetPipelineRunLogs returns logs of a given pipelineRun.
 func (t *TektonController) GetPipelineRunLogs(prefix, pipelineRunName, namespace string) (string, error) {
 	podClient := t.KubeInterface().CoreV1().Pods(namespace)
-	podList, err := podClient.List(context.Background(), metav1.ListOptions{})
+	podList, err := podClient.List(t.Context(), metav1.ListOptions{})
 	if err != nil {
 		return "", err
 	}
@@ -112,13 +112,13 @@ func (t *TektonController) GetPipelineRunWatch(ctx context.Context, namespace st
 // WatchPipelineRun waits until pipelineRun finishes.
 func (t *TektonController) WatchPipelineRun(pipelineRunName, namespace string, taskTimeout int) error {
 	g.GinkgoWriter.Printf("Waiting for pipeline %q to finish\n", pipelineRunName)
-	return utils.WaitUntil(t.CheckPipelineRunFinished(pipelineRunName, namespace), time.Duration(taskTimeout)*time.Second)
+	return utils.WaitUntilContext(t.Context(), t.CheckPipelineRunFinished(pipelineRunName, namespace), time.Duration(taskTimeout)*time.Second)
 }
 
 // WatchPipelineRunSucceeded waits until the pipelineRun succeeds.
 func (t *TektonController) WatchPipelineRunSucceeded(pipelineRunName, namespace string, taskTimeout int) error {
 	g.GinkgoWriter.Printf("Waiting for pipeline %q to finish\n", pipelineRunName)
-	return utils.WaitUntil(t.CheckPipelineRunSucceeded(pipelineRunName, namespace), time.Duration(taskTimeout)*time.Second)
+	return utils.WaitUntilContext(t.Context(), t.CheckPipelineRunSucceeded(pipelineRunName, namespace), time.Duration(taskTimeout)*time.Second)
 }
 
 // CheckPipelineRunStarted checks if pipelineRUn started.
@@ -169,17 +169,17 @@ func (t *TektonController) CheckPipelineRunSucceeded(pipelineRunName, namespace
 
 // ListAllPipelineRuns returns a list of all pipelineRuns in a namespace.
 func (t *TektonController) ListAllPipelineRuns(ns string) (*pipeline.PipelineRunList, error) {
-	return t.PipelineClient().TektonV1().PipelineRuns(ns).List(context.Background(), metav1.ListOptions{})
+	return t.PipelineClient().TektonV1().PipelineRuns(ns).List(t.Context(), metav1.ListOptions{})
 }
 
 // DeletePipelineRun deletes a pipelineRun form a given namespace.
 func (t *TektonController) DeletePipelineRun(name, ns string) error {
-	return t.PipelineClient().TektonV1().PipelineRuns(ns).Delete(context.Background(), name, metav1.DeleteOptions{})
+	return t.PipelineClient().TektonV1().PipelineRuns(ns).Delete(t.Context(), name, metav1.DeleteOptions{})
 }
 
 // DeletePipelineRunIgnoreFinalizers deletes PipelineRun (removing the finalizers field, first)
 func (t *TektonController) DeletePipelineRunIgnoreFinalizers(ns, name string) error {
-	err := wait.PollUntilContextTimeout(context.Background(), time.Second, 30*time.Second, true, func(ctx context.Context) (done bool, err error) {
+	err := wait.PollUntilContextTimeout(t.Context(), time.Second, 30*time.Second, true, func(ctx context.Context) (done bool, err error) {
 		pipelineRunCR := pipeline.PipelineRun{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      name,
@@ -187,7 +187,7 @@ func (t *TektonController) DeletePipelineRunIgnoreFinalizers(ns, name string) er
 			},
 		}
 		patch := crclient.RawPatch(types.JSONPatchType, []byte(`[{"op":"remove","path":"/metadata/finalizers"}]`))
-		if err := t.KubeRest().Patch(context.Background(), &pipelineRunCR, patch); err != nil {
+		if err := t.KubeRest().Patch(t.Context(), &pipelineRunCR, patch); err != nil {
 			if errors.IsNotFound(err) {
 				// PipelinerRun CR is already removed
 				return true, nil
@@ -197,7 +197,7 @@ func (t *TektonController) DeletePipelineRunIgnoreFinalizers(ns, name string) er
 
 		}
 
-		if err := t.KubeRest().Delete(context.Background(), &pipelineRunCR); err != nil {
+		if err := t.KubeRest().Delete(t.Context(), &pipelineRunCR); err != nil {
 			g.GinkgoWriter.Printf("unable to delete PipelineRun '%s' in '%s': %v\n", pipelineRunCR.Name, pipelineRunCR.Namespace, err)
 			return false, nil
 		}
@@ -268,7 +268,7 @@ func (t *TektonController) StoreAllPipelineRuns(namespace string) error {
 }
 
 func (t *TektonController) AddFinalizerToPipelineRun(pipelineRun *pipeline.PipelineRun, finalizerName string) error {
-	ctx := context.Background()
+	ctx := t.Context()
 	kubeClient := t.KubeRest()
 	patch := crclient.MergeFrom(pipelineRun.DeepCopy())
 	if ok := controllerutil.AddFinalizer(pipelineRun, finalizerName); ok {
@@ -281,7 +281,7 @@ func (t *TektonController) AddFinalizerToPipelineRun(pipelineRun *pipeline.Pipel
 }
 
 func (t *TektonController) RemoveFinalizerFromPipelineRun(pipelineRun *pipeline.PipelineRun, finalizerName string) error {
-	ctx := context.Background()
+	ctx := t.Context()
 	kubeClient := t.KubeRest()
 	patch := client.MergeFrom(pipelineRun.DeepCopy())
 	if ok := controllerutil.RemoveFinalizer(pipelineRun, finalizerName); ok {
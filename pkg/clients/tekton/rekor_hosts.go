@@ -1,7 +1,6 @@
 package tekton
 
 import (
-	"context"
 	"os"
 
 	"github.com/konflux-ci/e2e-tests/pkg/constants"
@@ -16,7 +15,7 @@ func (t *TektonController) GetRekorHost() (rekorHost string, err error) {
 		tektonChainsNs = "tekton-pipelines"
 	}
 	api := t.KubeInterface().CoreV1().ConfigMaps(tektonChainsNs)
-	ctx := context.Background()
+	ctx := t.Context()
 
 	cm, err := api.Get(ctx, "chains-config", metav1.GetOptions{})
 	if err != nil {
@@ -1,7 +1,6 @@
 package tekton
 
 import (
-	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -66,7 +65,7 @@ func (t *TektonController) CreateTaskRunCopy(name, namespace, serviceAccountName
 		},
 	}
 
-	err := t.KubeRest().Create(context.Background(), &taskRun)
+	err := t.KubeRest().Create(t.Context(), &taskRun)
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +85,7 @@ func (t *TektonController) GetTaskRun(name, namespace string) (*pipeline.TaskRun
 			Namespace: namespace,
 		},
 	}
-	err := t.KubeRest().Get(context.Background(), namespacedName, &taskRun)
+	err := t.KubeRest().Get(t.Context(), namespacedName, &taskRun)
 	if err != nil {
 		return nil, err
 	}
@@ -114,7 +113,7 @@ func (t *TektonController) StoreTaskRunsForPipelineRun(c crclient.Client, pr *pi
 	for _, chr := range pr.Status.ChildReferences {
 		taskRun := &pipeline.TaskRun{}
 		taskRunKey := types.NamespacedName{Namespace: pr.Namespace, Name: chr.Name}
-		if err := c.Get(context.Background(), taskRunKey, taskRun); err != nil {
+		if err := c.Get(t.Context(), taskRunKey, taskRun); err != nil {
 			return err
 		}
 		if err := t.StoreTaskRun(taskRun.Name, taskRun); err != nil{
@@ -127,7 +126,7 @@ func (t *TektonController) StoreTaskRunsForPipelineRun(c crclient.Client, pr *pi
 // GetTaskRunLogs returns logs of a specified taskRun.
 func (t *TektonController) GetTaskRunLogs(pipelineRunName, pipelineTaskName, namespace string) (map[string]string, error) {
 	tektonClient := t.PipelineClient().TektonV1beta1().PipelineRuns(namespace)
-	pipelineRun, err := tektonClient.Get(context.Background(), pipelineRunName, metav1.GetOptions{})
+	pipelineRun, err := tektonClient.Get(t.Context(), pipelineRunName, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -137,7 +136,7 @@ func (t *TektonController) GetTaskRunLogs(pipelineRunName, pipelineTaskName, nam
 		if childStatusReference.PipelineTaskName == pipelineTaskName {
 			taskRun := &pipeline.TaskRun{}
 			taskRunKey := types.NamespacedName{Namespace: pipelineRun.Namespace, Name: childStatusReference.Name}
-			if err := t.KubeRest().Get(context.Background(), taskRunKey, taskRun); err != nil {
+			if err := t.KubeRest().Get(t.Context(), taskRunKey, taskRun); err != nil {
 				return nil, err
 			}
 			podName = taskRun.Status.PodName
@@ -149,7 +148,7 @@ func (t *TektonController) GetTaskRunLogs(pipelineRunName, pipelineTaskName, nam
 	}
 
 	podClient := t.KubeInterface().CoreV1().Pods(namespace)
-	pod, err := podClient.Get(context.Background(), podName, metav1.GetOptions{})
+	pod, err := podClient.Get(t.Context(), podName, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -174,7 +173,7 @@ func (t *TektonController) GetTaskRunFromPipelineRun(c crclient.Client, pr *pipe
 
 		taskRun := &pipeline.TaskRun{}
 		taskRunKey := types.NamespacedName{Namespace: pr.Namespace, Name: chr.Name}
-		if err := c.Get(context.Background(), taskRunKey, taskRun); err != nil {
+		if err := c.Get(t.Context(), taskRunKey, taskRun); err != nil {
 			return nil, err
 		}
 		return taskRun, nil
@@ -205,7 +204,7 @@ func (t *TektonController) GetTaskRunStatus(c crclient.Client, pr *pipeline.Pipe
 		if chr.PipelineTaskName == pipelineTaskName {
 			taskRun := &pipeline.TaskRun{}
 			taskRunKey := types.NamespacedName{Namespace: pr.Namespace, Name: chr.Name}
-			if err := c.Get(context.Background(), taskRunKey, taskRun); err != nil {
+			if err := c.Get(t.Context(), taskRunKey, taskRun); err != nil {
 				return nil, err
 			}
 			return &pipeline.PipelineRunTaskRunStatus{PipelineTaskName: chr.PipelineTaskName, Status: &taskRun.Status}, nil
@@ -217,7 +216,7 @@ func (t *TektonController) GetTaskRunStatus(c crclient.Client, pr *pipeline.Pipe
 
 // DeleteAllTaskRunsInASpecificNamespace removes all TaskRuns from a given repository. Useful when creating a lot of resources and wanting to remove all of them.
 func (t *TektonController) DeleteAllTaskRunsInASpecificNamespace(namespace string) error {
-	return t.KubeRest().DeleteAllOf(context.Background(), &pipeline.TaskRun{}, crclient.InNamespace(namespace))
+	return t.KubeRest().DeleteAllOf(t.Context(), &pipeline.TaskRun{}, crclient.InNamespace(namespace))
 }
 
 // GetTaskRunParam gets value of a TaskRun param.
@@ -260,7 +259,7 @@ func (t *TektonController) GetEnvVariable(tr *pipeline.TaskRun, envVar string) (
 
 func (t *TektonController) WatchTaskRun(taskRunName, namespace string, taskTimeout int) error {
 	g.GinkgoWriter.Printf("Waiting for pipeline %q to finish\n", taskRunName)
-	return utils.WaitUntil(t.CheckTaskRunFinished(taskRunName, namespace), time.Duration(taskTimeout)*time.Second)
+	return utils.WaitUntilContext(t.Context(), t.CheckTaskRunFinished(taskRunName, namespace), time.Duration(taskTimeout)*time.Second)
 }
 
 // CheckTaskRunFinished checks if taskRun finished.
@@ -308,5 +307,5 @@ func (t *TektonController) RunTaskAndWait(trSpec *pipeline.TaskRun, namespace st
 }
 
 func (t *TektonController) CreateTaskRun(taskRun *pipeline.TaskRun, ns string) (*pipeline.TaskRun, error) {
-	return t.PipelineClient().TektonV1().TaskRuns(ns).Create(context.Background(), taskRun, metav1.CreateOptions{})
+	return t.PipelineClient().TektonV1().TaskRuns(ns).Create(t.Context(), taskRun, metav1.CreateOptions{})
 }
@@ -1,7 +1,6 @@
 package tekton
 
 import (
-	"context"
 	"fmt"
 	"os"
 
@@ -18,7 +17,7 @@ func (t *TektonController) GetTektonChainsPublicKey() ([]byte, error) {
 	secretName := "public-key"
 	dataKey := "cosign.pub"
 
-	secret, err := t.KubeInterface().CoreV1().Secrets(namespace).Get(context.Background(), secretName, metav1.GetOptions{})
+	secret, err := t.KubeInterface().CoreV1().Secrets(namespace).Get(t.Context(), secretName, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("couldn't get the secret %s from %s namespace: %+v", secretName, namespace, err)
 	}
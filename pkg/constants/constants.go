@@ -9,6 +9,16 @@ const (
 	// A github token is required to run the tests. The token need to have permissions to the given github organization. By default the e2e use redhat-appstudio-qe github organization.
 	GITHUB_TOKEN_ENV string = "GITHUB_TOKEN" // #nosec
 
+	// If set to "app", GitHub client authenticates as installation of the GitHub App given by E2E_PAC_GITHUB_APP_ID and E2E_PAC_GITHUB_APP_PRIVATE_KEY instead of using GITHUB_TOKEN
+	GITHUB_AUTH_MODE_ENV string = "GITHUB_AUTH_MODE"
+	GithubAuthModeApp    string = "app"
+
+	// ID of the GitHub App used by Pipelines as Code
+	PAC_GITHUB_APP_ID_ENV string = "E2E_PAC_GITHUB_APP_ID"
+
+	// Private key (PEM, optionally base64 encoded) of the GitHub App used by Pipelines as Code
+	PAC_GITHUB_APP_PRIVATE_KEY_ENV string = "E2E_PAC_GITHUB_APP_PRIVATE_KEY" // #nosec
+
 	// The github organization is used to create the gitops repositories in Red Hat Appstudio.
 	GITHUB_E2E_ORGANIZATION_ENV string = "MY_GITHUB_ORG" // #nosec
 
@@ -126,6 +136,9 @@ const (
 	// If set to "true", e2e-tests installer will configure master/control plane nodes as schedulable
 	ENABLE_SCHEDULING_ON_MASTER_NODES_ENV = "ENABLE_SCHEDULING_ON_MASTER_NODES"
 
+	// If set to "true", resources tracked by the framework (see Framework.TrackResources) are not deleted when the spec failed, for debugging
+	KEEP_RESOURCES_ON_FAILURE_ENV = "KEEP_RESOURCES_ON_FAILURE"
+
 	// A gitlab bot token is required to run tests against gitlab.com. The token need to have permissions to the Gitlab repository.
 	GITLAB_BOT_TOKEN_ENV string = "GITLAB_BOT_TOKEN" // #nosec
 
@@ -138,6 +151,18 @@ const (
 	// GitLab Project ID used for helper functions in magefiles
 	GITLAB_PROJECT_ID_ENV string = "GITLAB_PROJECT_ID"
 
+	// URL of a Gitea/Forgejo instance (e.g. one running inside the test cluster). The Gitea client is only set up when it is set
+	GITEA_API_URL_ENV string = "GITEA_API_URL"
+
+	// Gitea/Forgejo access token with permissions to the test repositories
+	GITEA_TOKEN_ENV string = "GITEA_TOKEN" // #nosec
+
+	// The Gitea/Forgejo organization which owns the test repositories
+	GITEA_ORG_ENV string = "GITEA_ORG"
+
+	// Set to "true" to skip TLS verification of the Gitea/Forgejo instance, e.g. when it uses a self-signed certificate
+	GITEA_INSECURE_SKIP_VERIFY_ENV string = "GITEA_INSECURE_SKIP_VERIFY"
+
 	// Release service catalog default URL and revision for e2e tests
 	RELEASE_CATALOG_DEFAULT_URL      = "https://github.com/konflux-ci/release-service-catalog.git"
 	RELEASE_CATALOG_DEFAULT_REVISION = "staging"
@@ -0,0 +1,71 @@
+package framework
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/e2e-tests/pkg/clients/common"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/has"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/imagecontroller"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/integration"
+	kubeCl "github.com/konflux-ci/e2e-tests/pkg/clients/kubernetes"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/release"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/tekton"
+	"github.com/konflux-ci/e2e-tests/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHub() *ControllerHub {
+	cc := &kubeCl.CustomClient{}
+	return &ControllerHub{
+		HasController:         &has.HasController{CustomClient: cc},
+		CommonController:      &common.SuiteController{CustomClient: cc},
+		TektonController:      tekton.NewSuiteController(cc),
+		ReleaseController:     &release.ReleaseController{CustomClient: cc},
+		IntegrationController: &integration.IntegrationController{CustomClient: cc},
+		ImageController:       &imagecontroller.ImageController{CustomClient: cc},
+	}
+}
+
+func TestControllerHubWithContext(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "spec")
+
+	hub := newTestHub()
+	scoped := hub.WithContext(ctx)
+
+	for _, c := range []context.Context{
+		scoped.Context(),
+		scoped.HasController.Context(),
+		scoped.CommonController.Context(),
+		scoped.TektonController.Context(),
+		scoped.ReleaseController.Context(),
+		scoped.IntegrationController.Context(),
+		scoped.ImageController.Context(),
+	} {
+		assert.Equal(t, "spec", c.Value(key{}))
+	}
+
+	// Original hub is left unscoped
+	assert.Equal(t, context.Background(), hub.Context())
+	assert.Equal(t, context.Background(), hub.TektonController.Context())
+}
+
+func TestFrameworkWithContextSharesHub(t *testing.T) {
+	hub := newTestHub()
+	fw := &Framework{AsKubeAdmin: hub, AsKubeDeveloper: hub, UserNamespace: "test"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scoped := fw.WithContext(ctx)
+
+	assert.Same(t, scoped.AsKubeAdmin, scoped.AsKubeDeveloper)
+	assert.Equal(t, "test", scoped.UserNamespace)
+
+	// Cancelling the context stops waits of scoped controllers long before their timeout
+	cancel()
+	start := time.Now()
+	err := utils.WaitUntilContext(scoped.AsKubeDeveloper.Context(), func() (bool, error) { return false, nil }, time.Minute)
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
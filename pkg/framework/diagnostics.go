@@ -0,0 +1,433 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/konflux-ci/e2e-tests/pkg/utils"
+	types "github.com/onsi/ginkgo/v2/types"
+	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Collectors not setting their own limits are stopped waiting for after this time
+	DefaultDiagnosticsTimeout = time.Minute * 2
+	// Collectors not setting their own limits can store up to this many bytes
+	DefaultDiagnosticsMaxBytes = 10 * 1024 * 1024
+	// File describing what was collected for the failing spec
+	DiagnosticsIndexFile = "diagnostics-index.yaml"
+)
+
+// DiagnosticsCollector gathers artifacts helping to debug a failed spec. Collect gets framework
+// bound to a context which is cancelled once Timeout passes and returns file names with contents,
+// which are stored to the artifact directory of the spec under the collector name.
+type DiagnosticsCollector struct {
+	Name     string
+	Timeout  time.Duration
+	MaxBytes int
+	Collect  func(ctx context.Context, fwk *Framework, spec types.SpecReport) (map[string][]byte, error)
+}
+
+// DiagnosticsIndex describes what was collected for the failing spec
+type DiagnosticsIndex struct {
+	Spec       string                  `json:"spec"`
+	State      string                  `json:"state"`
+	Failure    string                  `json:"failure,omitempty"`
+	StartTime  time.Time               `json:"startTime"`
+	Collectors []DiagnosticsIndexEntry `json:"collectors"`
+}
+
+type DiagnosticsIndexEntry struct {
+	Name     string            `json:"name"`
+	Duration string            `json:"duration"`
+	Error    string            `json:"error,omitempty"`
+	Files    []DiagnosticsFile `json:"files,omitempty"`
+}
+
+type DiagnosticsFile struct {
+	Path      string `json:"path"`
+	Size      int    `json:"size"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+var (
+	diagnosticsCollectors      []DiagnosticsCollector
+	diagnosticsCollectorsMutex sync.Mutex
+)
+
+// Collectors run by ReportFailure for every failed spec, suites can add their own with RegisterDiagnosticsCollector
+func init() {
+	RegisterDiagnosticsCollector(PodLogsCollector(map[string]string{
+		"Build Service":       "build-service",
+		"JVM Build Service":   "jvm-build-service",
+		"Application Service": "application-service",
+		"Image Controller":    "image-controller"}))
+	RegisterDiagnosticsCollector(EventsCollector())
+	RegisterDiagnosticsCollector(KonfluxResourcesCollector())
+	RegisterDiagnosticsCollector(FailedTaskRunsCollector())
+}
+
+// RegisterDiagnosticsCollector adds collector run by ReportFailure for every failed spec.
+// Collector with the same name registered before is replaced.
+func RegisterDiagnosticsCollector(collector DiagnosticsCollector) {
+	diagnosticsCollectorsMutex.Lock()
+	defer diagnosticsCollectorsMutex.Unlock()
+
+	for i := range diagnosticsCollectors {
+		if diagnosticsCollectors[i].Name == collector.Name {
+			diagnosticsCollectors[i] = collector
+			return
+		}
+	}
+	diagnosticsCollectors = append(diagnosticsCollectors, collector)
+}
+
+// UnregisterDiagnosticsCollector removes collector with given name, e.g. the built-in one producing too much noise
+func UnregisterDiagnosticsCollector(name string) {
+	diagnosticsCollectorsMutex.Lock()
+	defer diagnosticsCollectorsMutex.Unlock()
+
+	for i := range diagnosticsCollectors {
+		if diagnosticsCollectors[i].Name == name {
+			diagnosticsCollectors = append(diagnosticsCollectors[:i], diagnosticsCollectors[i+1:]...)
+			return
+		}
+	}
+}
+
+// DiagnosticsCollectors returns registered collectors
+func DiagnosticsCollectors() []DiagnosticsCollector {
+	diagnosticsCollectorsMutex.Lock()
+	defer diagnosticsCollectorsMutex.Unlock()
+
+	return append([]DiagnosticsCollector{}, diagnosticsCollectors...)
+}
+
+// CollectDiagnostics runs given collectors one by one and returns artifacts they collected
+// (prefixed by collector name) together with the index file describing them
+func CollectDiagnostics(fwk *Framework, spec types.SpecReport, collectors []DiagnosticsCollector) map[string][]byte {
+	artifacts := map[string][]byte{}
+	index := DiagnosticsIndex{
+		Spec:      spec.FullText(),
+		State:     spec.State.String(),
+		Failure:   spec.FailureMessage(),
+		StartTime: spec.StartTime,
+	}
+
+	for _, collector := range collectors {
+		start := time.Now()
+		files, err := runDiagnosticsCollector(fwk, spec, collector)
+		entry := DiagnosticsIndexEntry{Name: collector.Name}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+
+		for _, file := range limitDiagnosticsFiles(files, collector.MaxBytes) {
+			path := collector.Name + "/" + file.Path
+			artifacts[path] = files[file.Path]
+			file.Path = path
+			entry.Files = append(entry.Files, file)
+		}
+		entry.Duration = time.Since(start).Round(time.Millisecond).String()
+		index.Collectors = append(index.Collectors, entry)
+	}
+
+	indexYaml, err := yaml.Marshal(index)
+	if err != nil {
+		indexYaml = []byte(fmt.Sprintf("failed to marshal diagnostics index: %v\n", err))
+	}
+	artifacts[DiagnosticsIndexFile] = indexYaml
+
+	return artifacts
+}
+
+// Run collector bounded by its timeout. Not every API call respects context,
+// so collector still running after the timeout is left behind.
+func runDiagnosticsCollector(fwk *Framework, spec types.SpecReport, collector DiagnosticsCollector) (map[string][]byte, error) {
+	timeout := collector.Timeout
+	if timeout <= 0 {
+		timeout = DefaultDiagnosticsTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	type result struct {
+		files map[string][]byte
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- result{err: fmt.Errorf("collector panicked: %v", r)}
+			}
+		}()
+		files, err := collector.Collect(ctx, fwk.WithContext(ctx), spec)
+		done <- result{files, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.files, r.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("collector timed out after %v", timeout)
+	}
+}
+
+// Keep files (sorted by name) within maxBytes, truncating the one exceeding the limit to its end
+// as the end of logs is usually the interesting part. Files over the limit are dropped.
+func limitDiagnosticsFiles(files map[string][]byte, maxBytes int) []DiagnosticsFile {
+	if maxBytes <= 0 {
+		maxBytes = DefaultDiagnosticsMaxBytes
+	}
+
+	names := []string{}
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	kept := []DiagnosticsFile{}
+	remaining := maxBytes
+	for _, name := range names {
+		content := files[name]
+		if remaining <= 0 {
+			delete(files, name)
+			continue
+		}
+		file := DiagnosticsFile{Path: name, Size: len(content)}
+		if len(content) > remaining {
+			files[name] = content[len(content)-remaining:]
+			file.Size = remaining
+			file.Truncated = true
+		}
+		remaining -= file.Size
+		kept = append(kept, file)
+	}
+	return kept
+}
+
+// PodLogsCollector stores logs of all pods in given namespaces (values of the map, keys
+// describe them), starting at first line logged after the spec started
+func PodLogsCollector(namespaces map[string]string) DiagnosticsCollector {
+	return DiagnosticsCollector{
+		Name: "pod-logs",
+		Collect: func(ctx context.Context, fwk *Framework, spec types.SpecReport) (map[string][]byte, error) {
+			files := map[string][]byte{}
+			var errs []string
+			for _, namespace := range namespaces {
+				podList, err := fwk.AsKubeAdmin.CommonController.ListAllPods(namespace)
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("failed to list pods in namespace %s: %v", namespace, err))
+					continue
+				}
+
+				for i := range podList.Items {
+					for name, log := range fwk.AsKubeAdmin.CommonController.GetPodLogs(&podList.Items[i]) {
+						if filteredLogs := FilterLogs(string(log), spec.StartTime); filteredLogs != "" {
+							files[namespace+"/"+name] = []byte(filteredLogs)
+						}
+					}
+				}
+			}
+			return files, joinDiagnosticsErrors(errs)
+		},
+	}
+}
+
+// EventsCollector stores Kubernetes events of the user namespace
+func EventsCollector() DiagnosticsCollector {
+	return DiagnosticsCollector{
+		Name: "events",
+		Collect: func(ctx context.Context, fwk *Framework, spec types.SpecReport) (map[string][]byte, error) {
+			if fwk.UserNamespace == "" {
+				return nil, nil
+			}
+			events, err := fwk.AsKubeAdmin.CommonController.KubeInterface().CoreV1().Events(fwk.UserNamespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list events in namespace %s: %v", fwk.UserNamespace, err)
+			}
+
+			sort.Slice(events.Items, func(i, j int) bool {
+				return eventTime(events.Items[i]).Before(eventTime(events.Items[j]))
+			})
+			var b strings.Builder
+			for _, e := range events.Items {
+				fmt.Fprintf(&b, "%s\t%s\t%s/%s\t%s\t%s\n", eventTime(e).Format(time.RFC3339), e.Type, e.InvolvedObject.Kind, e.InvolvedObject.Name, e.Reason, e.Message)
+			}
+			return map[string][]byte{fwk.UserNamespace + ".log": []byte(b.String())}, nil
+		},
+	}
+}
+
+func eventTime(e corev1.Event) time.Time {
+	if !e.LastTimestamp.IsZero() {
+		return e.LastTimestamp.Time
+	}
+	return e.EventTime.Time
+}
+
+// Konflux resources stored by KonfluxResourcesCollector
+var konfluxResources = []schema.GroupVersionResource{
+	{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "applications"},
+	{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "components"},
+	{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "snapshots"},
+	{Group: "appstudio.redhat.com", Version: "v1beta2", Resource: "integrationtestscenarios"},
+	{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "imagerepositories"},
+	{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "releaseplans"},
+	{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "releaseplanadmissions"},
+	{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "releases"},
+}
+
+// KonfluxResourcesCollector stores YAML of Konflux custom resources in the user namespace
+func KonfluxResourcesCollector() DiagnosticsCollector {
+	return DiagnosticsCollector{
+		Name: "konflux-resources",
+		Collect: func(ctx context.Context, fwk *Framework, spec types.SpecReport) (map[string][]byte, error) {
+			if fwk.UserNamespace == "" {
+				return nil, nil
+			}
+			files := map[string][]byte{}
+			var errs []string
+			for _, resource := range konfluxResources {
+				list, err := fwk.AsKubeAdmin.CommonController.DynamicClient().Resource(resource).Namespace(fwk.UserNamespace).List(ctx, metav1.ListOptions{})
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("failed to list %s in namespace %s: %v", resource.Resource, fwk.UserNamespace, err))
+					continue
+				}
+				if len(list.Items) == 0 {
+					continue
+				}
+				content, err := yaml.Marshal(list)
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("failed to marshal %s: %v", resource.Resource, err))
+					continue
+				}
+				files[resource.Resource+".yaml"] = content
+			}
+			return files, joinDiagnosticsErrors(errs)
+		},
+	}
+}
+
+// FailedTaskRunsCollector stores YAML and step logs of TaskRuns which failed in the user namespace since the spec started
+func FailedTaskRunsCollector() DiagnosticsCollector {
+	return DiagnosticsCollector{
+		Name: "failed-taskruns",
+		Collect: func(ctx context.Context, fwk *Framework, spec types.SpecReport) (map[string][]byte, error) {
+			if fwk.UserNamespace == "" {
+				return nil, nil
+			}
+			taskRuns, err := fwk.AsKubeAdmin.TektonController.PipelineClient().TektonV1().TaskRuns(fwk.UserNamespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list TaskRuns in namespace %s: %v", fwk.UserNamespace, err)
+			}
+
+			files := map[string][]byte{}
+			var errs []string
+			for i := range taskRuns.Items {
+				tr := &taskRuns.Items[i]
+				if tr.CreationTimestamp.Time.Before(spec.StartTime) || !taskRunFailed(tr) {
+					continue
+				}
+				if content, err := yaml.Marshal(tr); err == nil {
+					files[tr.Name+".yaml"] = content
+				}
+				if tr.Status.PodName == "" {
+					continue
+				}
+				for _, step := range tr.Status.Steps {
+					log, err := utils.GetContainerLogs(fwk.AsKubeAdmin.CommonController.KubeInterface(), tr.Status.PodName, step.Container, tr.Namespace)
+					if err != nil {
+						errs = append(errs, fmt.Sprintf("failed to get logs of step %s of TaskRun %s: %v", step.Name, tr.Name, err))
+						continue
+					}
+					files[tr.Name+"-"+step.Name+".log"] = []byte(log)
+				}
+			}
+			return files, joinDiagnosticsErrors(errs)
+		},
+	}
+}
+
+func taskRunFailed(tr *pipeline.TaskRun) bool {
+	condition := tr.Status.GetCondition(apis.ConditionSucceeded)
+	return condition != nil && condition.IsFalse()
+}
+
+// DeploymentLogsCollector stores logs of pods of given deployment, e.g. controller of the service under test,
+// starting at first line logged after the spec started
+func DeploymentLogsCollector(namespace, deployment string) DiagnosticsCollector {
+	return DiagnosticsCollector{
+		Name: "deployment-logs-" + namespace + "-" + deployment,
+		Collect: func(ctx context.Context, fwk *Framework, spec types.SpecReport) (map[string][]byte, error) {
+			d, err := fwk.AsKubeAdmin.CommonController.KubeInterface().AppsV1().Deployments(namespace).Get(ctx, deployment, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get deployment %s in namespace %s: %v", deployment, namespace, err)
+			}
+			selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid selector of deployment %s: %v", deployment, err)
+			}
+			pods, err := fwk.AsKubeAdmin.CommonController.KubeInterface().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list pods of deployment %s in namespace %s: %v", deployment, namespace, err)
+			}
+
+			files := map[string][]byte{}
+			for i := range pods.Items {
+				for name, log := range fwk.AsKubeAdmin.CommonController.GetPodLogs(&pods.Items[i]) {
+					if filteredLogs := FilterLogs(string(log), spec.StartTime); filteredLogs != "" {
+						files[name] = []byte(filteredLogs)
+					}
+				}
+			}
+			return files, nil
+		},
+	}
+}
+
+var argoCDApplicationsResource = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "applications"}
+
+// ArgoCDApplicationsCollector stores sync and health status of ArgoCD applications in given namespace
+// (e.g. openshift-gitops) so broken cluster components can be told from test failures
+func ArgoCDApplicationsCollector(namespace string) DiagnosticsCollector {
+	return DiagnosticsCollector{
+		Name: "argocd-applications",
+		Collect: func(ctx context.Context, fwk *Framework, spec types.SpecReport) (map[string][]byte, error) {
+			apps, err := fwk.AsKubeAdmin.CommonController.DynamicClient().Resource(argoCDApplicationsResource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list ArgoCD applications in namespace %s: %v", namespace, err)
+			}
+
+			var b strings.Builder
+			for _, app := range apps.Items {
+				syncStatus, _, _ := unstructured.NestedString(app.Object, "status", "sync", "status")
+				healthStatus, _, _ := unstructured.NestedString(app.Object, "status", "health", "status")
+				fmt.Fprintf(&b, "%s\tsync=%s\thealth=%s\n", app.GetName(), syncStatus, healthStatus)
+			}
+			content, err := yaml.Marshal(apps)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal ArgoCD applications: %v", err)
+			}
+			return map[string][]byte{"status.log": []byte(b.String()), "applications.yaml": content}, nil
+		},
+	}
+}
+
+func joinDiagnosticsErrors(errs []string) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(errs, "; "))
+}
@@ -0,0 +1,86 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	types "github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func TestCollectDiagnostics(t *testing.T) {
+	hub := newTestHub()
+	fw := &Framework{AsKubeAdmin: hub, AsKubeDeveloper: hub}
+	spec := types.SpecReport{LeafNodeText: "fails", State: types.SpecStateFailed}
+
+	collectors := []DiagnosticsCollector{
+		{
+			Name:     "limited",
+			MaxBytes: 10,
+			Collect: func(ctx context.Context, fwk *Framework, spec types.SpecReport) (map[string][]byte, error) {
+				return map[string][]byte{"a.log": []byte("123456"), "b.log": []byte("abcdefgh"), "c.log": []byte("dropped")}, nil
+			},
+		},
+		{
+			Name:    "slow",
+			Timeout: time.Millisecond * 50,
+			Collect: func(ctx context.Context, fwk *Framework, spec types.SpecReport) (map[string][]byte, error) {
+				<-fwk.AsKubeAdmin.Context().Done()
+				time.Sleep(time.Second)
+				return map[string][]byte{"late.log": []byte("late")}, nil
+			},
+		},
+		{
+			Name: "broken",
+			Collect: func(ctx context.Context, fwk *Framework, spec types.SpecReport) (map[string][]byte, error) {
+				panic("boom")
+			},
+		},
+		{
+			Name: "failing",
+			Collect: func(ctx context.Context, fwk *Framework, spec types.SpecReport) (map[string][]byte, error) {
+				return map[string][]byte{"partial.log": []byte("partial")}, fmt.Errorf("failed half way")
+			},
+		},
+	}
+
+	artifacts := CollectDiagnostics(fw, spec, collectors)
+
+	assert.Equal(t, "123456", string(artifacts["limited/a.log"]))
+	assert.Equal(t, "efgh", string(artifacts["limited/b.log"]))
+	assert.NotContains(t, artifacts, "limited/c.log")
+	assert.NotContains(t, artifacts, "slow/late.log")
+	assert.Equal(t, "partial", string(artifacts["failing/partial.log"]))
+
+	index := DiagnosticsIndex{}
+	assert.NoError(t, yaml.Unmarshal(artifacts[DiagnosticsIndexFile], &index))
+	assert.Equal(t, "fails", index.Spec)
+	assert.Len(t, index.Collectors, 4)
+	assert.Equal(t, []DiagnosticsFile{{Path: "limited/a.log", Size: 6}, {Path: "limited/b.log", Size: 4, Truncated: true}}, index.Collectors[0].Files)
+	assert.Contains(t, index.Collectors[1].Error, "timed out")
+	assert.Contains(t, index.Collectors[2].Error, "boom")
+	assert.Equal(t, "failed half way", index.Collectors[3].Error)
+}
+
+func TestRegisterDiagnosticsCollector(t *testing.T) {
+	before := DiagnosticsCollectors()
+	defer func() {
+		diagnosticsCollectors = before
+	}()
+
+	RegisterDiagnosticsCollector(DiagnosticsCollector{Name: "custom"})
+	RegisterDiagnosticsCollector(DiagnosticsCollector{Name: "custom", MaxBytes: 1})
+	UnregisterDiagnosticsCollector("events")
+
+	names := []string{}
+	for _, c := range DiagnosticsCollectors() {
+		names = append(names, c.Name)
+		if c.Name == "custom" {
+			assert.Equal(t, 1, c.MaxBytes)
+		}
+	}
+	assert.Equal(t, []string{"pod-logs", "konflux-resources", "failed-taskruns", "custom"}, names)
+}
@@ -10,13 +10,10 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 )
 
-func ReportFailure(f **Framework) func() {
-	namespaces := map[string]string{
-		"Build Service":       "build-service",
-		"JVM Build Service":   "jvm-build-service",
-		"Application Service": "application-service",
-		"Image Controller":    "image-controller"}
-
+// ReportFailure returns function (meant for AfterEach) which stores diagnostics of failed spec to its artifact
+// directory: output of registered collectors (see RegisterDiagnosticsCollector), of extra collectors
+// given here and the index file describing what was collected
+func ReportFailure(f **Framework, extra ...DiagnosticsCollector) func() {
 	return func() {
 		if !CurrentSpecReport().Failed() {
 			return
@@ -31,27 +28,9 @@ func ReportFailure(f **Framework) func() {
 			GinkgoWriter.Printf("failed to store test timing: %v\n", err)
 		}
 
-		allPodLogs := make(map[string][]byte)
-		for _, namespace := range namespaces {
-			podList, err := fwk.AsKubeAdmin.CommonController.ListAllPods(namespace)
-			if err != nil {
-				GinkgoWriter.Printf("failed to list pods in namespace %s: %v\n", namespace, err)
-				return
-			}
-
-			for _, pod := range podList.Items {
-				podLogs := fwk.AsKubeAdmin.CommonController.GetPodLogs(&pod)
-
-				for podName, log := range podLogs {
-					if filteredLogs := FilterLogs(string(log), CurrentSpecReport().StartTime); filteredLogs != "" {
-						allPodLogs[podName] = []byte(filteredLogs)
-					}
-				}
-			}
-		}
-
-		if err := logs.StoreArtifacts(allPodLogs); err != nil {
-			GinkgoWriter.Printf("failed to store pod logs: %v\n", err)
+		collectors := append(DiagnosticsCollectors(), extra...)
+		if err := logs.StoreArtifacts(CollectDiagnostics(fwk, CurrentSpecReport(), collectors)); err != nil {
+			GinkgoWriter.Printf("failed to store diagnostics: %v\n", err)
 		}
 	}
 }
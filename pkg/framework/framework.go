@@ -61,7 +61,9 @@ func refreshFrameworkStage(currentFramework *Framework, userName string, timeout
 			fmt.Printf("ERROR: Failed refreshing framework for user %s: %+v\n", userName, err)
 			return
 		}
-		*currentFramework = *fw
+		// Keep the resource tracker and the context the framework is bound to, see TrackResources and WithContext
+		fw.AsKubeDeveloper.setResourceTracker(currentFramework.AsKubeDeveloper.ResourceTracker())
+		*currentFramework = *fw.WithContext(currentFramework.AsKubeDeveloper.Context())
 	}
 }
 
@@ -266,3 +268,81 @@ func InitControllerHub(cc *kubeCl.CustomClient) (*ControllerHub, error) {
 		ImageController:       imageController,
 	}, nil
 }
+
+// Context returns the context API calls and waits of the hub controllers are bound to
+func (h *ControllerHub) Context() context.Context {
+	return h.CommonController.Context()
+}
+
+// WithContext returns a copy of the hub whose controllers bind API calls and waits to ctx,
+// so e.g. Ginkgo's SpecContext deadline or interrupt stops them
+func (h *ControllerHub) WithContext(ctx context.Context) *ControllerHub {
+	return &ControllerHub{
+		HasController:         h.HasController.WithContext(ctx),
+		CommonController:      h.CommonController.WithContext(ctx),
+		TektonController:      h.TektonController.WithContext(ctx),
+		ReleaseController:     h.ReleaseController.WithContext(ctx),
+		IntegrationController: h.IntegrationController.WithContext(ctx),
+		ImageController:       h.ImageController.WithContext(ctx),
+	}
+}
+
+// WithContext returns a copy of the framework with both controller hubs bound to ctx
+func (f *Framework) WithContext(ctx context.Context) *Framework {
+	scoped := *f
+	scoped.AsKubeDeveloper = f.AsKubeDeveloper.WithContext(ctx)
+	if f.AsKubeAdmin == f.AsKubeDeveloper {
+		scoped.AsKubeAdmin = scoped.AsKubeDeveloper
+	} else {
+		scoped.AsKubeAdmin = f.AsKubeAdmin.WithContext(ctx)
+	}
+	return &scoped
+}
+
+// ResourceTracker returns tracker objects created through the hub controllers are recorded to, nil if none
+func (h *ControllerHub) ResourceTracker() *kubeCl.ResourceTracker {
+	return h.CommonController.ResourceTracker()
+}
+
+func (h *ControllerHub) setResourceTracker(tracker *kubeCl.ResourceTracker) {
+	for _, cc := range []*kubeCl.CustomClient{
+		h.HasController.CustomClient,
+		h.CommonController.CustomClient,
+		h.TektonController.CustomClient,
+		h.ReleaseController.CustomClient,
+		h.IntegrationController.CustomClient,
+		h.ImageController.CustomClient,
+	} {
+		cc.SetResourceTracker(tracker)
+	}
+}
+
+// TrackResources makes the framework record objects created through its controllers (see
+// CustomClient.KubeRest) and registers Ginkgo DeferCleanup deleting them in dependency-aware order,
+// e.g. Releases before ReleasePlans and Components before Applications. This replaces hand-written
+// AfterAll teardown and cleans up also after a spec failed mid-way, unless KEEP_RESOURCES_ON_FAILURE
+// env var is "true". Has to be called from a setup node, e.g. BeforeAll.
+func (f *Framework) TrackResources() *kubeCl.ResourceTracker {
+	tracker := kubeCl.NewResourceTracker()
+	f.AsKubeDeveloper.setResourceTracker(tracker)
+	f.AsKubeAdmin.setResourceTracker(tracker)
+
+	DeferCleanup(func(ctx SpecContext) error {
+		if CurrentSpecReport().Failed() && os.Getenv(constants.KEEP_RESOURCES_ON_FAILURE_ENV) == "true" {
+			GinkgoWriter.Printf("keeping %d tracked resources for debugging as the spec failed: %v\n", len(tracker.Objects()), tracker.Objects())
+			return nil
+		}
+		return f.CleanupTrackedResources(ctx)
+	})
+
+	return tracker
+}
+
+// CleanupTrackedResources deletes objects recorded since TrackResources was called
+func (f *Framework) CleanupTrackedResources(ctx context.Context) error {
+	tracker := f.AsKubeDeveloper.ResourceTracker()
+	if tracker == nil {
+		return nil
+	}
+	return tracker.Cleanup(ctx, f.AsKubeAdmin.CommonController.KubeRest(), time.Minute*5)
+}
@@ -3,6 +3,7 @@ package logs
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	. "github.com/konflux-ci/e2e-tests/pkg/utils"
@@ -47,6 +48,9 @@ func StoreArtifacts(artifacts map[string][]byte) error {
 
 	for artifact_name, artifact_value := range artifacts {
 		filePath := fmt.Sprintf("%s/%s", artifactsDirectory, artifact_name)
+		if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+			return err
+		}
 		if err := os.WriteFile(filePath, []byte(artifact_value), 0644); err != nil {
 			return err
 		}
@@ -2,6 +2,7 @@ package build
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -61,6 +62,32 @@ func CreateGitlabBuildSecret(f *framework.Framework, secretName string, annotati
 	return nil
 }
 
+// CreateGiteaBuildSecret creates a Kubernetes secret for Gitea/Forgejo build credentials, scoped to the host of giteaURL
+func CreateGiteaBuildSecret(f *framework.Framework, secretName string, annotations map[string]string, giteaURL, token string) error {
+	u, err := url.Parse(giteaURL)
+	if err != nil {
+		return fmt.Errorf("error parsing gitea URL %s: %v", giteaURL, err)
+	}
+	buildSecret := v1.Secret{}
+	buildSecret.Name = secretName
+	buildSecret.Labels = map[string]string{
+		"appstudio.redhat.com/credentials": "scm",
+		"appstudio.redhat.com/scm.host":    u.Hostname(),
+	}
+	if annotations != nil {
+		buildSecret.Annotations = annotations
+	}
+	buildSecret.Type = "kubernetes.io/basic-auth"
+	buildSecret.StringData = map[string]string{
+		"password": token,
+	}
+	_, err = f.AsKubeAdmin.CommonController.CreateSecret(f.UserNamespace, &buildSecret)
+	if err != nil {
+		return fmt.Errorf("error creating build secret: %v", err)
+	}
+	return nil
+}
+
 func CleanupWebhooks(f *framework.Framework, repoName string) error {
 	hooks, err := f.AsKubeAdmin.CommonController.Github.ListRepoWebhooks(repoName)
 	if err != nil {
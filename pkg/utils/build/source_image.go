@@ -22,7 +22,6 @@ import (
 
 	"github.com/konflux-ci/e2e-tests/pkg/clients/github"
 	"github.com/konflux-ci/e2e-tests/pkg/clients/tekton"
-	"github.com/konflux-ci/e2e-tests/pkg/constants"
 	"github.com/konflux-ci/e2e-tests/pkg/utils"
 	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 )
@@ -169,10 +168,9 @@ func doAppSourceFilesExist(absExtraSourceDirPath string) (bool, error) {
 }
 
 // NewGithubClient creates a GitHub client with custom organization.
-// The token is retrieved in the same way as what SuiteController does.
+// The credentials are retrieved in the same way as what SuiteController does.
 func NewGithubClient(organization string) (*github.Github, error) {
-	token := utils.GetEnv(constants.GITHUB_TOKEN_ENV, "")
-	if gh, err := github.NewGithubClient(token, organization); err != nil {
+	if gh, err := github.NewGithubClientFromEnv(organization); err != nil {
 		return nil, err
 	} else {
 		return gh, nil
@@ -0,0 +1,427 @@
+package pac
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // #nosec G505 -- GitHub still sends the legacy SHA-1 signature header
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v44/github"
+
+	"github.com/konflux-ci/e2e-tests/pkg/clients/common"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/git"
+	"github.com/konflux-ci/e2e-tests/pkg/constants"
+)
+
+// PullRequestAction is an action of a simulated pull/merge request event
+type PullRequestAction string
+
+const (
+	PullRequestOpened      PullRequestAction = "opened"
+	PullRequestSynchronize PullRequestAction = "synchronize"
+	// PullRequestClosed is sent as merge when the pull request has MergeCommitSHA set
+	PullRequestClosed PullRequestAction = "closed"
+)
+
+// Repository describes the repository the simulated events come from. It has to match the URL
+// of PaC Repository CR (i.e. Component's git URL), otherwise PaC ignores the events.
+type Repository struct {
+	// URL is the web URL of the repository, e.g. https://github.com/org/repo
+	URL string
+	// ID is the numeric ID of GitHub repository or GitLab project, PaC uses it only for logging
+	ID            int64
+	DefaultBranch string
+}
+
+// WebhookEvent is a webhook request as sent by a git provider
+type WebhookEvent struct {
+	// Type is the value of X-GitHub-Event/X-Gitlab-Event header
+	Type    string
+	Headers map[string]string
+	Payload []byte
+}
+
+// WebhookSimulator builds provider-accurate webhook events and delivers them to PaC controller
+// (or to sprayproxy which forwards them to the registered PaC controllers), so PaC can be triggered
+// without pushing to a remote repository.
+type WebhookSimulator struct {
+	Provider git.GitProvider
+	// TargetURL is the URL of PaC controller route or of sprayproxy
+	TargetURL string
+	// Secret is the webhook secret configured for the repository, it is used to sign GitHub events
+	// and sent as X-Gitlab-Token for GitLab events
+	Secret string
+	// Sender is the login of the user events are sent by
+	Sender string
+	// InstallationID is the ID of GitHub App installation sent in GitHub events, PaC uses it to get
+	// a token for the repository when it is set up with GitHub App
+	InstallationID int64
+	HTTPClient     *http.Client
+}
+
+// NewWebhookSimulator creates simulator of given provider's webhooks, only GitHub and GitLab are supported.
+// TLS verification of TargetURL can be skipped, e.g. for PaC controller route with a self-signed certificate.
+func NewWebhookSimulator(provider git.GitProvider, targetURL, secret string, insecureSkipVerify bool) (*WebhookSimulator, error) {
+	if provider != git.GitHubProvider && provider != git.GitLabProvider {
+		return nil, fmt.Errorf("webhook simulation is not supported for git provider %d", provider)
+	}
+	return &WebhookSimulator{
+		Provider:  provider,
+		TargetURL: targetURL,
+		Secret:    secret,
+		Sender:    "konflux-e2e",
+		HTTPClient: &http.Client{
+			Timeout: 30 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					// #nosec G402 -- only skipped when explicitly requested
+					InsecureSkipVerify: insecureSkipVerify,
+				},
+			},
+		},
+	}, nil
+}
+
+// GetPaCControllerURL returns URL of PaC controller route, webhooks can be delivered there directly
+func GetPaCControllerURL(c *common.SuiteController) (string, error) {
+	route, err := c.GetOpenshiftRoute(constants.PaCControllerRouteName, constants.PaCControllerNamespace)
+	if err != nil {
+		return "", fmt.Errorf("failed to get PaC controller route: %v", err)
+	}
+	return fmt.Sprintf("https://%s", route.Spec.Host), nil
+}
+
+// PushEvent builds event of pushing afterSHA to the branch, beforeSHA is the previous head of the branch
+func (s *WebhookSimulator) PushEvent(repo Repository, branch, beforeSHA, afterSHA, message string) (*WebhookEvent, error) {
+	if s.Provider == git.GitLabProvider {
+		return s.gitlabEvent("Push Hook", map[string]interface{}{
+			"object_kind":   "push",
+			"event_name":    "push",
+			"before":        beforeSHA,
+			"after":         afterSHA,
+			"ref":           "refs/heads/" + branch,
+			"checkout_sha":  afterSHA,
+			"user_username": s.Sender,
+			"user_name":     s.Sender,
+			"project_id":    repo.ID,
+			"project":       gitlabProject(repo),
+			"commits": []map[string]interface{}{{
+				"id":        afterSHA,
+				"message":   message,
+				"title":     strings.SplitN(message, "\n", 2)[0],
+				"url":       fmt.Sprintf("%s/-/commit/%s", repo.URL, afterSHA),
+				"timestamp": time.Now().UTC().Format(time.RFC3339),
+			}},
+			"total_commits_count": 1,
+			"repository":          gitlabRepository(repo),
+		})
+	}
+
+	owner, name := ownerAndName(repo.URL)
+	headCommit := &github.HeadCommit{
+		ID:        github.String(afterSHA),
+		Message:   github.String(message),
+		URL:       github.String(fmt.Sprintf("%s/commit/%s", repo.URL, afterSHA)),
+		Timestamp: &github.Timestamp{Time: time.Now().UTC()},
+	}
+	return s.githubEvent("push", &github.PushEvent{
+		Ref:        github.String("refs/heads/" + branch),
+		Before:     github.String(beforeSHA),
+		After:      github.String(afterSHA),
+		Compare:    github.String(fmt.Sprintf("%s/compare/%s...%s", repo.URL, beforeSHA, afterSHA)),
+		Commits:    []*github.HeadCommit{headCommit},
+		HeadCommit: headCommit,
+		Repo: &github.PushEventRepository{
+			ID:            github.Int64(repo.ID),
+			Name:          github.String(name),
+			FullName:      github.String(owner + "/" + name),
+			Owner:         &github.User{Login: github.String(owner)},
+			HTMLURL:       github.String(repo.URL),
+			CloneURL:      github.String(repo.URL + ".git"),
+			DefaultBranch: github.String(repo.DefaultBranch),
+		},
+		Pusher:       &github.User{Login: github.String(s.Sender), Name: github.String(s.Sender)},
+		Sender:       s.githubSender(),
+		Installation: s.githubInstallation(),
+	})
+}
+
+// PullRequestEvent builds pull request (GitLab merge request) event, pr has to have the source and target
+// branch and HeadSHA set, MergeCommitSHA is used only when the action is PullRequestClosed
+func (s *WebhookSimulator) PullRequestEvent(repo Repository, action PullRequestAction, pr *git.PullRequest, title string) (*WebhookEvent, error) {
+	if s.Provider == git.GitLabProvider {
+		attributes := gitlabMergeRequest(repo, pr, title)
+		attributes["action"] = map[PullRequestAction]string{
+			PullRequestOpened:      "open",
+			PullRequestSynchronize: "update",
+			PullRequestClosed:      "close",
+		}[action]
+		attributes["state"] = "opened"
+		if action == PullRequestClosed {
+			attributes["state"] = "closed"
+			if pr.MergeCommitSHA != "" {
+				attributes["action"] = "merge"
+				attributes["state"] = "merged"
+				attributes["merge_commit_sha"] = pr.MergeCommitSHA
+			}
+		}
+		return s.gitlabEvent("Merge Request Hook", map[string]interface{}{
+			"object_kind":       "merge_request",
+			"event_type":        "merge_request",
+			"user":              gitlabUser(s.Sender),
+			"project":           gitlabProject(repo),
+			"repository":        gitlabRepository(repo),
+			"object_attributes": attributes,
+		})
+	}
+
+	ghPR := s.githubPullRequest(repo, pr, title)
+	if action == PullRequestClosed {
+		ghPR.State = github.String("closed")
+		if pr.MergeCommitSHA != "" {
+			ghPR.Merged = github.Bool(true)
+			ghPR.MergeCommitSHA = github.String(pr.MergeCommitSHA)
+		}
+	}
+	return s.githubEvent("pull_request", &github.PullRequestEvent{
+		Action:       github.String(string(action)),
+		Number:       github.Int(pr.Number),
+		PullRequest:  ghPR,
+		Repo:         githubRepository(repo),
+		Sender:       s.githubSender(),
+		Installation: s.githubInstallation(),
+	})
+}
+
+// CommentEvent builds event of commenting the pull request (GitLab merge request), e.g. with "/retest"
+func (s *WebhookSimulator) CommentEvent(repo Repository, pr *git.PullRequest, title, comment string) (*WebhookEvent, error) {
+	if s.Provider == git.GitLabProvider {
+		mergeRequest := gitlabMergeRequest(repo, pr, title)
+		mergeRequest["state"] = "opened"
+		return s.gitlabEvent("Note Hook", map[string]interface{}{
+			"object_kind": "note",
+			"event_type":  "note",
+			"user":        gitlabUser(s.Sender),
+			"project_id":  repo.ID,
+			"project":     gitlabProject(repo),
+			"repository":  gitlabRepository(repo),
+			"object_attributes": map[string]interface{}{
+				"note":          comment,
+				"noteable_type": "MergeRequest",
+				"project_id":    repo.ID,
+				"created_at":    gitlabTime(time.Now()),
+				"url":           fmt.Sprintf("%s/-/merge_requests/%d", repo.URL, pr.Number),
+			},
+			"merge_request": mergeRequest,
+		})
+	}
+
+	now := time.Now().UTC()
+	return s.githubEvent("issue_comment", &github.IssueCommentEvent{
+		Action: github.String("created"),
+		Issue: &github.Issue{
+			Number:  github.Int(pr.Number),
+			Title:   github.String(title),
+			State:   github.String("open"),
+			HTMLURL: github.String(fmt.Sprintf("%s/pull/%d", repo.URL, pr.Number)),
+			PullRequestLinks: &github.PullRequestLinks{
+				HTMLURL: github.String(fmt.Sprintf("%s/pull/%d", repo.URL, pr.Number)),
+			},
+		},
+		Comment: &github.IssueComment{
+			Body:      github.String(comment),
+			User:      s.githubSender(),
+			CreatedAt: &now,
+		},
+		Repo:         githubRepository(repo),
+		Sender:       s.githubSender(),
+		Installation: s.githubInstallation(),
+	})
+}
+
+// Send delivers the event to TargetURL, response with other than 2xx status is returned as error
+func (s *WebhookSimulator) Send(event *WebhookEvent) error {
+	req, err := http.NewRequest(http.MethodPost, s.TargetURL, bytes.NewReader(event.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range event.Headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver %s event to %s: %v", event.Type, s.TargetURL, err)
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("delivering %s event to %s failed with status %d: %s", event.Type, s.TargetURL, res.StatusCode, string(body))
+	}
+	return nil
+}
+
+func (s *WebhookSimulator) githubEvent(eventType string, event interface{}) (*WebhookEvent, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s event: %v", eventType, err)
+	}
+	headers := map[string]string{
+		"User-Agent":        "GitHub-Hookshot/e2e",
+		"X-GitHub-Event":    eventType,
+		"X-GitHub-Delivery": deliveryID(),
+	}
+	if s.Secret != "" {
+		headers["X-Hub-Signature"] = "sha1=" + sign(sha1.New, s.Secret, payload)
+		headers["X-Hub-Signature-256"] = "sha256=" + sign(sha256.New, s.Secret, payload)
+	}
+	return &WebhookEvent{Type: eventType, Headers: headers, Payload: payload}, nil
+}
+
+func (s *WebhookSimulator) gitlabEvent(eventType string, event map[string]interface{}) (*WebhookEvent, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s event: %v", eventType, err)
+	}
+	headers := map[string]string{
+		"User-Agent":          "GitLab/e2e",
+		"X-Gitlab-Event":      eventType,
+		"X-Gitlab-Event-UUID": deliveryID(),
+	}
+	if s.Secret != "" {
+		headers["X-Gitlab-Token"] = s.Secret
+	}
+	return &WebhookEvent{Type: eventType, Headers: headers, Payload: payload}, nil
+}
+
+func (s *WebhookSimulator) githubSender() *github.User {
+	return &github.User{Login: github.String(s.Sender), Type: github.String("User")}
+}
+
+// githubInstallation returns the GitHub App installation events come from, nil if InstallationID is not set
+func (s *WebhookSimulator) githubInstallation() *github.Installation {
+	if s.InstallationID == 0 {
+		return nil
+	}
+	return &github.Installation{ID: github.Int64(s.InstallationID)}
+}
+
+func (s *WebhookSimulator) githubPullRequest(repo Repository, pr *git.PullRequest, title string) *github.PullRequest {
+	ghRepo := githubRepository(repo)
+	return &github.PullRequest{
+		Number:  github.Int(pr.Number),
+		State:   github.String("open"),
+		Title:   github.String(title),
+		HTMLURL: github.String(fmt.Sprintf("%s/pull/%d", repo.URL, pr.Number)),
+		User:    s.githubSender(),
+		Head: &github.PullRequestBranch{
+			Ref:  github.String(pr.SourceBranch),
+			SHA:  github.String(pr.HeadSHA),
+			Repo: ghRepo,
+		},
+		Base: &github.PullRequestBranch{
+			Ref:  github.String(pr.TargetBranch),
+			Repo: ghRepo,
+		},
+	}
+}
+
+func githubRepository(repo Repository) *github.Repository {
+	owner, name := ownerAndName(repo.URL)
+	return &github.Repository{
+		ID:            github.Int64(repo.ID),
+		Name:          github.String(name),
+		FullName:      github.String(owner + "/" + name),
+		Owner:         &github.User{Login: github.String(owner)},
+		HTMLURL:       github.String(repo.URL),
+		CloneURL:      github.String(repo.URL + ".git"),
+		DefaultBranch: github.String(repo.DefaultBranch),
+	}
+}
+
+func gitlabMergeRequest(repo Repository, pr *git.PullRequest, title string) map[string]interface{} {
+	project := gitlabProject(repo)
+	return map[string]interface{}{
+		"iid":               pr.Number,
+		"title":             title,
+		"source_branch":     pr.SourceBranch,
+		"target_branch":     pr.TargetBranch,
+		"source_project_id": repo.ID,
+		"target_project_id": repo.ID,
+		"source":            project,
+		"target":            project,
+		"url":               fmt.Sprintf("%s/-/merge_requests/%d", repo.URL, pr.Number),
+		"updated_at":        gitlabTime(time.Now()),
+		"last_commit": map[string]interface{}{
+			"id":  pr.HeadSHA,
+			"url": fmt.Sprintf("%s/-/commit/%s", repo.URL, pr.HeadSHA),
+		},
+	}
+}
+
+func gitlabProject(repo Repository) map[string]interface{} {
+	owner, name := ownerAndName(repo.URL)
+	return map[string]interface{}{
+		"id":                  repo.ID,
+		"name":                name,
+		"namespace":           owner,
+		"path_with_namespace": owner + "/" + name,
+		"web_url":             repo.URL,
+		"git_http_url":        repo.URL + ".git",
+		"default_branch":      repo.DefaultBranch,
+	}
+}
+
+func gitlabRepository(repo Repository) map[string]interface{} {
+	_, name := ownerAndName(repo.URL)
+	return map[string]interface{}{
+		"name":     name,
+		"url":      repo.URL + ".git",
+		"homepage": repo.URL,
+	}
+}
+
+func gitlabUser(username string) map[string]interface{} {
+	return map[string]interface{}{"username": username, "name": username}
+}
+
+// gitlabTime formats time the way GitLab does in webhook payloads
+func gitlabTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05 UTC")
+}
+
+// ownerAndName splits repository URL to the owner (GitLab namespace, possibly with subgroups) and the name
+func ownerAndName(repoURL string) (string, string) {
+	path := strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+	if i := strings.Index(path, "://"); i >= 0 {
+		path = path[i+3:]
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		return "", parts[len(parts)-1]
+	}
+	return strings.Join(parts[1:len(parts)-1], "/"), parts[len(parts)-1]
+}
+
+func sign(h func() hash.Hash, secret string, payload []byte) string {
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func deliveryID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
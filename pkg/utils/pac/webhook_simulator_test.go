@@ -0,0 +1,128 @@
+package pac
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-github/v44/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/konflux-ci/e2e-tests/pkg/clients/git"
+)
+
+var (
+	testPR = &git.PullRequest{Number: 3, SourceBranch: "feature", TargetBranch: "main", HeadSHA: "head"}
+)
+
+func TestGitHubEvents(t *testing.T) {
+	s, err := NewWebhookSimulator(git.GitHubProvider, "", "secret", false)
+	assert.NoError(t, err)
+	s.InstallationID = 7
+	repo := Repository{URL: "https://github.com/org/repo", ID: 1, DefaultBranch: "main"}
+
+	push, err := s.PushEvent(repo, "main", "before", "after", "fix")
+	assert.NoError(t, err)
+	assert.NoError(t, github.ValidateSignature(push.Headers["X-Hub-Signature-256"], push.Payload, []byte("secret")))
+	parsed, err := github.ParseWebHook(push.Headers["X-GitHub-Event"], push.Payload)
+	assert.NoError(t, err)
+	pushEvent := parsed.(*github.PushEvent)
+	assert.Equal(t, "refs/heads/main", pushEvent.GetRef())
+	assert.Equal(t, "after", pushEvent.GetHeadCommit().GetID())
+	assert.Equal(t, "org/repo", pushEvent.GetRepo().GetFullName())
+	assert.Equal(t, int64(7), pushEvent.GetInstallation().GetID())
+
+	merge := *testPR
+	merge.MergeCommitSHA = "merged"
+	closed, err := s.PullRequestEvent(repo, PullRequestClosed, &merge, "title")
+	assert.NoError(t, err)
+	parsed, err = github.ParseWebHook(closed.Type, closed.Payload)
+	assert.NoError(t, err)
+	prEvent := parsed.(*github.PullRequestEvent)
+	assert.Equal(t, "closed", prEvent.GetAction())
+	assert.True(t, prEvent.GetPullRequest().GetMerged())
+	assert.Equal(t, "head", prEvent.GetPullRequest().GetHead().GetSHA())
+	assert.Equal(t, "https://github.com/org/repo", prEvent.GetRepo().GetHTMLURL())
+	assert.Equal(t, int64(7), prEvent.GetInstallation().GetID())
+
+	comment, err := s.CommentEvent(repo, testPR, "title", "/retest")
+	assert.NoError(t, err)
+	parsed, err = github.ParseWebHook(comment.Type, comment.Payload)
+	assert.NoError(t, err)
+	commentEvent := parsed.(*github.IssueCommentEvent)
+	assert.True(t, commentEvent.GetIssue().IsPullRequest())
+	assert.Equal(t, "/retest", commentEvent.GetComment().GetBody())
+	assert.Equal(t, int64(7), commentEvent.GetInstallation().GetID())
+}
+
+func TestGitLabEvents(t *testing.T) {
+	s, err := NewWebhookSimulator(git.GitLabProvider, "", "secret", false)
+	assert.NoError(t, err)
+	repo := Repository{URL: "https://gitlab.com/org/group/repo", ID: 42, DefaultBranch: "main"}
+
+	push, err := s.PushEvent(repo, "main", "before", "after", "fix")
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", push.Headers["X-Gitlab-Token"])
+	parsed, err := gitlab.ParseWebhook(gitlab.EventType(push.Type), push.Payload)
+	assert.NoError(t, err)
+	pushEvent := parsed.(*gitlab.PushEvent)
+	assert.Equal(t, "after", pushEvent.CheckoutSHA)
+	assert.Equal(t, "org/group/repo", pushEvent.Project.PathWithNamespace)
+	assert.Len(t, pushEvent.Commits, 1)
+
+	update, err := s.PullRequestEvent(repo, PullRequestSynchronize, testPR, "title")
+	assert.NoError(t, err)
+	parsed, err = gitlab.ParseWebhook(gitlab.EventType(update.Type), update.Payload)
+	assert.NoError(t, err)
+	mrEvent := parsed.(*gitlab.MergeEvent)
+	assert.Equal(t, "update", mrEvent.ObjectAttributes.Action)
+	assert.Equal(t, 3, mrEvent.ObjectAttributes.IID)
+	assert.Equal(t, "head", mrEvent.ObjectAttributes.LastCommit.ID)
+	assert.Equal(t, "https://gitlab.com/org/group/repo", mrEvent.Project.WebURL)
+
+	comment, err := s.CommentEvent(repo, testPR, "title", "/retest")
+	assert.NoError(t, err)
+	parsed, err = gitlab.ParseWebhook(gitlab.EventType(comment.Type), comment.Payload)
+	assert.NoError(t, err)
+	noteEvent := parsed.(*gitlab.MergeCommentEvent)
+	assert.Equal(t, "/retest", noteEvent.ObjectAttributes.Note)
+	assert.Equal(t, "feature", noteEvent.MergeRequest.SourceBranch)
+}
+
+func TestSend(t *testing.T) {
+	received := map[string]string{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received[r.Header.Get("X-GitHub-Event")] = string(body)
+		if r.Header.Get("X-GitHub-Event") == "push" {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	s, err := NewWebhookSimulator(git.GitHubProvider, server.URL, "", true)
+	assert.NoError(t, err)
+	repo := Repository{URL: "https://github.com/org/repo"}
+
+	push, err := s.PushEvent(repo, "main", "before", "after", "fix")
+	assert.NoError(t, err)
+	assert.NotContains(t, push.Headers, "X-Hub-Signature-256")
+	assert.NoError(t, s.Send(push))
+
+	// Self-signed certificate is only accepted when TLS verification is skipped explicitly
+	verifying, err := NewWebhookSimulator(git.GitHubProvider, server.URL, "", false)
+	assert.NoError(t, err)
+	assert.ErrorContains(t, verifying.Send(push), "certificate")
+	assert.Equal(t, string(push.Payload), received["push"])
+
+	opened, err := s.PullRequestEvent(repo, PullRequestOpened, testPR, "title")
+	assert.NoError(t, err)
+	assert.ErrorContains(t, s.Send(opened), "status 400")
+
+	_, err = NewWebhookSimulator(git.GiteaProvider, server.URL, "", false)
+	assert.Error(t, err)
+}
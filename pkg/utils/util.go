@@ -129,13 +129,23 @@ func GetGeneratedNamespace(name string) string {
 }
 
 func WaitUntilWithInterval(cond wait.ConditionFunc, interval time.Duration, timeout time.Duration) error {
-	return wait.PollUntilContextTimeout(context.Background(), interval, timeout, true, func(ctx context.Context) (bool, error) { return cond() })
+	return WaitUntilWithIntervalContext(context.Background(), cond, interval, timeout)
 }
 
 func WaitUntil(cond wait.ConditionFunc, timeout time.Duration) error {
 	return WaitUntilWithInterval(cond, time.Second, timeout)
 }
 
+// WaitUntilWithIntervalContext is like WaitUntilWithInterval, but stops waiting also when ctx is done
+func WaitUntilWithIntervalContext(ctx context.Context, cond wait.ConditionFunc, interval time.Duration, timeout time.Duration) error {
+	return wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) { return cond() })
+}
+
+// WaitUntilContext is like WaitUntil, but stops waiting also when ctx is done
+func WaitUntilContext(ctx context.Context, cond wait.ConditionFunc, timeout time.Duration) error {
+	return WaitUntilWithIntervalContext(ctx, cond, time.Second, timeout)
+}
+
 func ExecuteCommandInASpecificDirectory(command string, args []string, directory string) error {
 	cmd := exec.Command(command, args...) // nolint:gosec
 	cmd.Dir = directory
@@ -246,7 +256,7 @@ func ExtractGitRepositoryNameFromURL(url string) (name string) {
 }
 
 func GetGithubAppID() (int64, error) {
-	appIDStr := GetEnv("E2E_PAC_GITHUB_APP_ID", constants.DefaultPaCGitHubAppID)
+	appIDStr := GetEnv(constants.PAC_GITHUB_APP_ID_ENV, constants.DefaultPaCGitHubAppID)
 
 	id, err := strconv.ParseInt(appIDStr, 10, 64)
 	if err != nil {
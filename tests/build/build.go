@@ -21,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/konflux-ci/e2e-tests/pkg/clients/git"
+	ghclient "github.com/konflux-ci/e2e-tests/pkg/clients/github"
 	"github.com/konflux-ci/e2e-tests/pkg/clients/has"
 	"github.com/konflux-ci/e2e-tests/pkg/constants"
 	"github.com/konflux-ci/e2e-tests/pkg/framework"
@@ -430,6 +431,11 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build-ser
 				case git.GitLabProvider:
 					expectedNote := fmt.Sprintf("%s-on-pull-request** has successfully validated your commit", customBranchComponentName)
 					f.AsKubeAdmin.HasController.GitLab.ValidateNoteInMergeRequestComment(helloWorldComponentGitLabProjectID, expectedNote, prNumber)
+				case git.GiteaProvider:
+					expectedStatusName := fmt.Sprintf("%s-%s", customBranchComponentName, "on-pull-request")
+					status, err := git.WaitForCommitStatus(gitClient, helloWorldRepository, prHeadSha, expectedStatusName, time.Minute*5)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(status.State).To(Equal(git.CommitStatusSuccess))
 				}
 			})
 		})
@@ -495,6 +501,11 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build-ser
 				case git.GitLabProvider:
 					expectedNote := fmt.Sprintf("%s-on-pull-request** has successfully validated your commit", customBranchComponentName)
 					f.AsKubeAdmin.HasController.GitLab.ValidateNoteInMergeRequestComment(helloWorldComponentGitLabProjectID, expectedNote, prNumber)
+				case git.GiteaProvider:
+					expectedStatusName := fmt.Sprintf("%s-%s", customBranchComponentName, "on-pull-request")
+					status, err := git.WaitForCommitStatus(gitClient, helloWorldRepository, createdFileSHA, expectedStatusName, time.Minute*5)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(status.State).To(Equal(git.CommitStatusSuccess))
 				}
 			})
 		})
@@ -639,8 +650,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build-ser
 			})
 		})
 	},
-		Entry("github", git.GitHubProvider, "gh"),
-		Entry("gitlab", git.GitLabProvider, "gl"),
+		gitProviderEntries(),
 	)
 
 	Describe("test pac with multiple components using same repository", Ordered, Label("pac-build", "multi-component"), func() {
@@ -897,6 +907,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build-ser
 	Describe("test build secret lookup", Label("pac-build", "secret-lookup"), Ordered, func() {
 		var testNamespace, applicationName, firstComponentBaseBranchName, secondComponentBaseBranchName, firstComponentName, secondComponentName, firstPacBranchName, secondPacBranchName string
 		var buildPipelineAnnotation map[string]string
+		var noAppOrgGithub *ghclient.Github
 		BeforeAll(func() {
 			if os.Getenv(constants.SKIP_PAC_TESTS_ENV) == "true" {
 				Skip("Skipping this test due to configuration issue with Spray proxy")
@@ -909,15 +920,18 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build-ser
 			_, err = f.AsKubeAdmin.HasController.CreateApplication(applicationName, testNamespace)
 			Expect(err).NotTo(HaveOccurred())
 
-			// Update the default github org
-			f.AsKubeAdmin.CommonController.Github.UpdateGithubOrg(noAppOrgName)
+			// The org has no GitHub App installed, so the token is used even when the suite authenticates as the App
+			githubToken := utils.GetEnv(constants.GITHUB_TOKEN_ENV, "")
+			Expect(githubToken).ToNot(BeEmpty(), "%s env var is required to access %s org", constants.GITHUB_TOKEN_ENV, noAppOrgName)
+			noAppOrgGithub, err = ghclient.NewGithubClient(githubToken, noAppOrgName)
+			Expect(err).NotTo(HaveOccurred())
 
 			firstComponentBaseBranchName = fmt.Sprintf("component-one-base-%s", util.GenerateRandomString(6))
-			err = f.AsKubeAdmin.CommonController.Github.CreateRef(secretLookupGitSourceRepoOneName, secretLookupDefaultBranchOne, secretLookupGitRevisionOne, firstComponentBaseBranchName)
+			err = noAppOrgGithub.CreateRef(secretLookupGitSourceRepoOneName, secretLookupDefaultBranchOne, secretLookupGitRevisionOne, firstComponentBaseBranchName)
 			Expect(err).ShouldNot(HaveOccurred())
 
 			secondComponentBaseBranchName = fmt.Sprintf("component-two-base-%s", util.GenerateRandomString(6))
-			err = f.AsKubeAdmin.CommonController.Github.CreateRef(secretLookupGitSourceRepoTwoName, secretLookupDefaultBranchTwo, secretLookupGitRevisionTwo, secondComponentBaseBranchName)
+			err = noAppOrgGithub.CreateRef(secretLookupGitSourceRepoTwoName, secretLookupDefaultBranchTwo, secretLookupGitRevisionTwo, secondComponentBaseBranchName)
 			Expect(err).ShouldNot(HaveOccurred())
 
 			// use custom bundle if env defined
@@ -933,28 +947,28 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build-ser
 			}
 
 			// Delete new branches created by PaC
-			err = f.AsKubeAdmin.CommonController.Github.DeleteRef(secretLookupGitSourceRepoOneName, firstPacBranchName)
+			err = noAppOrgGithub.DeleteRef(secretLookupGitSourceRepoOneName, firstPacBranchName)
 			if err != nil {
 				Expect(err.Error()).To(ContainSubstring("Reference does not exist"))
 			}
-			err = f.AsKubeAdmin.CommonController.Github.DeleteRef(secretLookupGitSourceRepoTwoName, secondPacBranchName)
+			err = noAppOrgGithub.DeleteRef(secretLookupGitSourceRepoTwoName, secondPacBranchName)
 			if err != nil {
 				Expect(err.Error()).To(ContainSubstring("Reference does not exist"))
 			}
 
 			// Delete the created first component base branch
-			err = f.AsKubeAdmin.CommonController.Github.DeleteRef(secretLookupGitSourceRepoOneName, firstComponentBaseBranchName)
+			err = noAppOrgGithub.DeleteRef(secretLookupGitSourceRepoOneName, firstComponentBaseBranchName)
 			if err != nil {
 				Expect(err.Error()).To(ContainSubstring("Reference does not exist"))
 			}
 			// Delete the created second component base branch
-			err = f.AsKubeAdmin.CommonController.Github.DeleteRef(secretLookupGitSourceRepoTwoName, secondComponentBaseBranchName)
+			err = noAppOrgGithub.DeleteRef(secretLookupGitSourceRepoTwoName, secondComponentBaseBranchName)
 			if err != nil {
 				Expect(err.Error()).To(ContainSubstring("Reference does not exist"))
 			}
 
 			// Delete created webhook from GitHub
-			err = build.CleanupWebhooks(f, secretLookupGitSourceRepoTwoName)
+			err = git.NewGitHubClient(noAppOrgGithub).CleanupWebhooks(secretLookupGitSourceRepoTwoName, f.ClusterAppDomain)
 			if err != nil {
 				Expect(err.Error()).To(ContainSubstring("404 Not Found"))
 			}
@@ -1072,7 +1086,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build-ser
 				interval = time.Second * 1
 				Expect(f.AsKubeAdmin.HasController.DeleteComponent(secondComponentName, testNamespace, true)).To(Succeed())
 				Eventually(func() bool {
-					exists, err := f.AsKubeAdmin.CommonController.Github.ExistsRef(secretLookupGitSourceRepoTwoName, secondPacBranchName)
+					exists, err := noAppOrgGithub.ExistsRef(secretLookupGitSourceRepoTwoName, secondPacBranchName)
 					Expect(err).ShouldNot(HaveOccurred())
 					return exists
 				}, timeout, interval).Should(BeFalse(), fmt.Sprintf("timed out when waiting for the branch %s to be deleted from %s repository", secondPacBranchName, secretLookupGitSourceRepoTwoName))
@@ -1280,6 +1294,18 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build-ser
 				ChildComponentDef.gitRepo = fmt.Sprintf(gitlabUrlFormat, childRepository)
 
 				componentDependenciesChildRepository = fmt.Sprintf("%s/%s", gitlabOrg, componentDependenciesChildRepoName)
+			case git.GiteaProvider:
+				gt := f.AsKubeAdmin.CommonController.Gitea
+				Expect(gt).ShouldNot(BeNil(), "%s env var is not set", constants.GITEA_API_URL_ENV)
+				gitClient = git.NewGiteaClient(gt)
+
+				parentRepository = fmt.Sprintf("%s/%s", gt.Organization(), ParentComponentDef.repoName)
+				ParentComponentDef.gitRepo = fmt.Sprintf("%s/%s", gt.BaseURL(), parentRepository)
+
+				childRepository = fmt.Sprintf("%s/%s", gt.Organization(), ChildComponentDef.repoName)
+				ChildComponentDef.gitRepo = fmt.Sprintf("%s/%s", gt.BaseURL(), childRepository)
+
+				componentDependenciesChildRepository = fmt.Sprintf("%s/%s", gt.Organization(), componentDependenciesChildRepoName)
 			}
 			ParentComponentDef.componentName = fmt.Sprintf("%s-multi-component-parent-%s", gitPrefix, branchString)
 			ChildComponentDef.componentName = fmt.Sprintf("%s-multi-component-child-%s", gitPrefix, branchString)
@@ -1332,7 +1358,8 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build-ser
 			// get the build pipeline bundle annotation
 			buildPipelineAnnotation = build.GetBuildPipelineBundleAnnotation(constants.DockerBuild)
 
-			if gitProvider == git.GitLabProvider {
+			switch gitProvider {
+			case git.GitLabProvider:
 				gitlabToken := utils.GetEnv(constants.GITLAB_BOT_TOKEN_ENV, "")
 				Expect(gitlabToken).ShouldNot(BeEmpty())
 
@@ -1340,6 +1367,10 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build-ser
 
 				err = build.CreateGitlabBuildSecret(f, "pipelines-as-code-secret", secretAnnotations, gitlabToken)
 				Expect(err).ShouldNot(HaveOccurred())
+			case git.GiteaProvider:
+				gt := f.AsKubeAdmin.CommonController.Gitea
+				err = build.CreateGiteaBuildSecret(f, "pipelines-as-code-secret", map[string]string{}, gt.BaseURL(), utils.GetEnv(constants.GITEA_TOKEN_ENV, ""))
+				Expect(err).ShouldNot(HaveOccurred())
 			}
 		})
 
@@ -1586,11 +1617,23 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build-ser
 			})
 		})
 	},
-		Entry("github", git.GitHubProvider, "gh"),
-		Entry("gitlab", git.GitLabProvider, "gl"),
+		gitProviderEntries(),
 	)
 })
 
+// gitProviderEntries returns the table entries of the git providers the suite runs against.
+// Gitea is covered only when GITEA_API_URL points to an instance, which CI jobs don't provide.
+func gitProviderEntries() []TableEntry {
+	entries := []TableEntry{
+		Entry("github", git.GitHubProvider, "gh"),
+		Entry("gitlab", git.GitLabProvider, "gl"),
+	}
+	if os.Getenv(constants.GITEA_API_URL_ENV) != "" {
+		entries = append(entries, Entry("gitea", git.GiteaProvider, "gt"))
+	}
+	return entries
+}
+
 func setupGitProvider(f *framework.Framework, gitProvider git.GitProvider) (git.Client, string, string) {
 	switch gitProvider {
 	case git.GitHubProvider:
@@ -1608,6 +1651,16 @@ func setupGitProvider(f *framework.Framework, gitProvider git.GitProvider) (git.
 		Expect(err).ShouldNot(HaveOccurred())
 
 		return gitClient, helloWorldComponentGitLabURL, helloWorldComponentGitLabProjectID
+	case git.GiteaProvider:
+		gt := f.AsKubeAdmin.CommonController.Gitea
+		Expect(gt).ShouldNot(BeNil(), "%s env var is not set", constants.GITEA_API_URL_ENV)
+		gitClient := git.NewGiteaClient(gt)
+
+		err := build.CreateGiteaBuildSecret(f, "pipelines-as-code-secret", map[string]string{}, gt.BaseURL(), utils.GetEnv(constants.GITEA_TOKEN_ENV, ""))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		repository := fmt.Sprintf("%s/%s", gt.Organization(), helloWorldComponentGitSourceRepoName)
+		return gitClient, fmt.Sprintf("%s/%s", gt.BaseURL(), repository), repository
 	}
 	return nil, "", ""
 }
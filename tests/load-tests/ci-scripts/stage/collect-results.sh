@@ -37,7 +37,7 @@ python3 -m pip install matplotlib
 } &>"${ARTIFACT_DIR}/monitoring-setup.log"
 
 echo "[$(date --utc -Ins)] Create summary JSON with timings"
-./evaluate.py "${ARTIFACT_DIR}/load-test-timings.csv" "${ARTIFACT_DIR}/load-test-timings.json"
+go run loadtest.go evaluate --input-dir "${ARTIFACT_DIR}" --output-dir "${ARTIFACT_DIR}"
 
 echo "[$(date --utc -Ins)] Counting PRs and TRs"
 ci-scripts/utility_scripts/count-multiarch-taskruns.py --data-dir "${ARTIFACT_DIR}" >"${ARTIFACT_DIR}/count-multiarch-taskruns.log"
@@ -54,7 +54,7 @@ STATUS_DATA_FILE="${ARTIFACT_DIR}/load-test.json"
 status_data.py \
     --status-data-file "${STATUS_DATA_FILE}" \
     --set "name=Konflux loadtest" "started=$( cat started )" "ended=$( cat ended )" \
-    --set-subtree-json "parameters.options=${ARTIFACT_DIR}/load-test-options.json" "results.measurements=${ARTIFACT_DIR}/load-test-timings.json" "results.durations=${ARTIFACT_DIR}/get-taskruns-durations.json"
+    --set-subtree-json "parameters.options=${ARTIFACT_DIR}/load-test-options.json" "results.durations=${ARTIFACT_DIR}/get-taskruns-durations.json"
 
 echo "[$(date --utc -Ins)] Adding monitoring data"
 mstarted="$( date -d "$( cat started )" --utc -Iseconds )"
@@ -1,17 +1,21 @@
 package main
 
+import "context"
 import "fmt"
+import "os"
+import "os/signal"
+import "syscall"
 import "time"
 
+import evaluate "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/evaluate"
 import journey "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/journey"
+import ledger "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/ledger"
 import options "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/options"
 import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
 
 import cobra "github.com/spf13/cobra"
 import klog "k8s.io/klog/v2"
 
-//import "os"
-//import "context"
 //import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 //import schema "k8s.io/apimachinery/pkg/runtime/schema"
 //import watch "k8s.io/apimachinery/pkg/watch"
@@ -29,32 +33,65 @@ var rootCmd = &cobra.Command{
 	Long:  `Konflux performance test`,
 }
 
+var evaluateOpts = evaluate.Opts{}
+
+var evaluateCmd = &cobra.Command{
+	Use:   "evaluate",
+	Short: "Compute KPIs of finished load test",
+	Long:  `Compute KPIs from load-test-timings.csv and load-test-errors.csv of finished load test and store them to load-test.json and load-test-summary.md`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		results, err := evaluate.Evaluate(&evaluateOpts)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("KPI mean: %v\n", results.Measurements.KPI.Mean)
+		fmt.Printf("KPI errors: %v\n", results.Measurements.KPI.Errors)
+		return nil
+	},
+}
+
 func init() {
+	evaluateCmd.Flags().StringVar(&evaluateOpts.InputDir, "input-dir", ".", "directory with load-test-timings.csv and load-test-errors.csv")
+	evaluateCmd.Flags().StringVarP(&evaluateOpts.OutputDir, "output-dir", "o", ".", "directory where load-test.json (updated if it exists) and load-test-summary.md are stored")
+	evaluateCmd.Flags().StringSliceVar(&evaluateOpts.Metrics, "metrics", evaluate.DefaultMetrics, "comma separated measured function names that together form the KPI")
+	rootCmd.AddCommand(evaluateCmd)
+
 	rootCmd.Flags().StringVar(&opts.ComponentRepoUrl, "component-repo", "https://github.com/nodeshift-starters/devfile-sample", "the component repo URL to be used")
 	rootCmd.Flags().IntVar(&opts.ApplicationsCount, "applications-count", 1, "number of applications to create per user")
 	rootCmd.Flags().IntVar(&opts.ComponentsCount, "components-count", 1, "number of components to create per application")
 	rootCmd.Flags().StringVar(&opts.ComponentRepoRevision, "component-repo-revision", "main", "the component repo revision, git branch")
 	rootCmd.Flags().StringVar(&opts.ComponentContainerFile, "component-repo-container-file", "Dockerfile", "the component repo container file to build")
 	rootCmd.Flags().StringVar(&opts.ComponentContainerContext, "component-repo-container-context", "/", "the context for image build")
+	rootCmd.Flags().StringVar(&opts.ScenarioFile, "scenario", "", "YAML file describing the mix of user profiles to run, component and test scenario options are used as defaults for the profiles")
 	rootCmd.Flags().StringVar(&opts.QuayRepo, "quay-repo", "redhat-user-workloads-stage", "the target quay repo for PaC templated image pushes")
 	rootCmd.Flags().StringVar(&opts.UsernamePrefix, "username", "testuser", "the prefix used for usersignup names")
 	rootCmd.Flags().BoolVarP(&opts.Stage, "stage", "s", false, "is you want to run the test on stage")
 	rootCmd.Flags().BoolVarP(&opts.Purge, "purge", "p", false, "purge all users or resources (on stage) after test is done")
 	rootCmd.Flags().BoolVarP(&opts.PurgeOnly, "purge-only", "u", false, "do not run test, only purge resources (this implies --purge)")
+	rootCmd.Flags().BoolVar(&opts.PurgeFromLedger, "purge-from-ledger", false, "do not run test, only purge resources recorded in --ledger-file that were not purged yet")
+	rootCmd.Flags().StringVar(&opts.LedgerFile, "ledger-file", "", "file to record resources created by the test to (default is load-test-ledger.jsonl in --output-dir)")
 	rootCmd.Flags().StringVar(&opts.TestScenarioGitURL, "test-scenario-git-url", "https://github.com/konflux-ci/integration-examples.git", "test scenario GIT URL")
 	rootCmd.Flags().StringVar(&opts.TestScenarioRevision, "test-scenario-revision", "main", "test scenario GIT URL repo revision to use")
 	rootCmd.Flags().StringVar(&opts.TestScenarioPathInRepo, "test-scenario-path-in-repo", "pipelines/integration_resolver_pipeline_pass.yaml", "test scenario path in GIT repo")
+	rootCmd.Flags().BoolVar(&opts.Release, "release", false, "if you want to release built snapshots and wait for release pipelines to finish (requires --waitpipelines)")
+	rootCmd.Flags().StringVar(&opts.ReleasePipelineGitURL, "release-pipeline-git-url", "https://github.com/konflux-ci/release-service-catalog.git", "release pipeline GIT URL used by release step")
+	rootCmd.Flags().StringVar(&opts.ReleasePipelineRevision, "release-pipeline-revision", "development", "release pipeline GIT URL repo revision to use")
+	rootCmd.Flags().StringVar(&opts.ReleasePipelinePathInRepo, "release-pipeline-path-in-repo", "pipelines/managed/e2e/e2e.yaml", "release pipeline path in GIT repo")
 	rootCmd.Flags().BoolVarP(&opts.WaitPipelines, "waitpipelines", "w", false, "if you want to wait for pipelines to finish")
 	rootCmd.Flags().BoolVarP(&opts.WaitIntegrationTestsPipelines, "waitintegrationtestspipelines", "i", false, "if you want to wait for IntegrationTests (Integration Test Scenario) pipelines to finish")
-	rootCmd.Flags().BoolVar(&opts.FailFast, "fail-fast", false, "if you want the test to fail fast at first failure")
-	rootCmd.Flags().IntVarP(&opts.Concurrency, "concurrency", "c", 1, "number of concurrent threads to execute")
+	rootCmd.Flags().BoolVar(&opts.FailFast, "fail-fast", false, "if you want the test to fail fast at first failure in fatal error category (e.g. quota exceeded)")
+	rootCmd.Flags().IntVarP(&opts.Concurrency, "concurrency", "c", 1, "number of concurrent threads to execute (ignored when scenario defines arrival phases)")
 	rootCmd.Flags().IntVar(&opts.JourneyRepeats, "journey-repeats", 1, "number of times to repeat user journey (either this or --journey-duration)")
 	rootCmd.Flags().StringVar(&opts.JourneyDuration, "journey-duration", "1h", "repeat user journey until this timeout (either this or --journey-repeats)")
+	rootCmd.Flags().BoolVar(&opts.JourneyHardStop, "journey-hard-stop", false, "stop user journeys still running when --journey-duration expires instead of letting them finish")
 	rootCmd.Flags().BoolVar(&opts.PipelineMintmakerDisabled, "pipeline-mintmaker-disabled", true, "if you want to stop Mintmaker to be creating update PRs for your component (default in loadtest different from Konflux default)")
 	rootCmd.Flags().BoolVar(&opts.PipelineRepoTemplating, "pipeline-repo-templating", false, "if we should use in repo template pipelines (merge PaC PR, template repo pipelines and ignore custom pipeline run, e.g. required for multi arch test)")
 	rootCmd.Flags().StringArrayVar(&opts.PipelineImagePullSecrets, "pipeline-image-pull-secrets", []string{}, "space separated secrets needed to pull task images")
 	rootCmd.Flags().StringVarP(&opts.OutputDir, "output-dir", "o", ".", "directory where output files such as load-tests.log or load-tests.json are stored")
 	rootCmd.Flags().StringVar(&opts.BuildPipelineSelectorBundle, "build-pipeline-selector-bundle", "", "BuildPipelineSelector bundle to use when testing with build-definition PR")
+	rootCmd.Flags().StringVar(&opts.MetricsListen, "metrics-listen", "", "address (e.g. ':9090') to serve live metrics of the test on, in Prometheus/OpenMetrics format at /metrics")
+	rootCmd.Flags().StringVar(&opts.MetricsPushURL, "metrics-push-url", "", "Pushgateway compatible URL to periodically push live metrics of the test to")
+	rootCmd.Flags().DurationVar(&opts.MetricsPushInterval, "metrics-push-interval", time.Second*30, "how often to push live metrics to --metrics-push-url")
 	rootCmd.Flags().BoolVarP(&opts.LogInfo, "log-info", "v", false, "log messages with info level and above")
 	rootCmd.Flags().BoolVarP(&opts.LogDebug, "log-debug", "d", false, "log messages with debug level and above")
 	rootCmd.Flags().BoolVarP(&opts.LogTrace, "log-trace", "t", false, "log messages with trace level and above (i.e. everything)")
@@ -64,10 +101,14 @@ func main() {
 	var err error
 
 	// Setup argument parser
-	err = rootCmd.Execute()
+	cmd, err := rootCmd.ExecuteC()
 	if err != nil {
 		klog.Fatalln(err)
 	}
+	if cmd != rootCmd {
+		// Subcommand did its job already
+		return
+	}
 	if rootCmd.Flags().Lookup("help").Value.String() == "true" {
 		fmt.Println(rootCmd.UsageString())
 		return
@@ -76,6 +117,10 @@ func main() {
 	if err != nil {
 		logging.Logger.Fatal("Failed to process options: %v", err)
 	}
+	err = journey.ValidateSteps(&opts)
+	if err != nil {
+		logging.Logger.Fatal("Failed to process options: %v", err)
+	}
 
 	// Setup logging
 	logging.Logger.Level = logging.WARNING
@@ -88,12 +133,42 @@ func main() {
 	if opts.LogTrace {
 		logging.Logger.Level = logging.TRACE
 	}
+	logging.Logger.FailFast = opts.FailFast
 
 	// Show test options
 	logging.Logger.Debug("Options: %+v", opts)
 
 	// Tier up measurements logger
 	logging.MeasurementsStart(opts.OutputDir)
+	logging.MetricsStart(opts.MetricsListen, opts.MetricsPushURL, "load-test-"+opts.UsernamePrefix, opts.MetricsPushInterval)
+	logging.TraceStart(opts.OutputDir)
+	traceSpan := logging.TraceThreadStart("load-test", "load test", nil, map[string]string{"loadtest.username.prefix": opts.UsernamePrefix}, &opts)
+
+	// Open ledger of created resources
+	err = ledger.Open(opts.LedgerFile)
+	if err != nil {
+		logging.Logger.Fatal("Failed to open ledger: %v", err)
+	}
+
+	// Only purge resources recorded in the ledger if requested
+	if opts.PurgeFromLedger {
+		_, err = logging.Measure(journey.PurgeFromLedger, &opts)
+		if err != nil {
+			logging.Logger.Error("Purging from ledger failed: %v", err)
+		}
+		ledger.Close()
+		traceStop(traceSpan)
+		logging.MetricsStop()
+		logging.MeasurementsStop()
+		return
+	}
+
+	// Stop user journeys cleanly when interrupted or when their time is up
+	journeyCtx, journeyCancel := context.WithCancel(context.Background())
+	defer journeyCancel()
+	go stopJourneys(journeyCancel)
+	journey.SetContext(journeyCtx)
+	logging.Logger.Stop = journeyCancel
 
 	// Start given number of `perUserThread()` threads using `journey.Setup()` and wait for them to finish
 	_, err = logging.Measure(journey.Setup, perUserThread, &opts)
@@ -107,13 +182,56 @@ func main() {
 		logging.Logger.Error("Purging failed: %v", err)
 	}
 
-	// Tier down measurements logger
+	// Tier down ledger, trace and measurements logger
+	ledger.Close()
+	traceStop(traceSpan)
+	logging.MetricsStop()
 	logging.MeasurementsStop()
+
+	// Results are written now, so fail the test if it was stopped by fail fast
+	if reason := logging.Logger.FailedFast(); reason != "" {
+		logging.Logger.Fatal("Test stopped after %s", reason)
+	}
+}
+
+// Cancel user journeys on SIGINT or SIGTERM and with '--journey-hard-stop' also when '--journey-duration'
+// expires. Resources are still purged then, second signal kills the test right away.
+func stopJourneys(cancel context.CancelFunc) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	var deadline <-chan time.Time
+	if opts.JourneyHardStop {
+		deadline = time.After(time.Until(opts.JourneyUntil))
+	}
+
+	select {
+	case sig := <-signals:
+		logging.Logger.Warning("Received %v, stopping user journeys, send it again to exit immediately", sig)
+	case <-deadline:
+		logging.Logger.Warning("Journey duration expired, stopping user journeys")
+	}
+
+	signal.Stop(signals)
+	cancel()
+}
+
+// End span of the whole test and write the trace
+func traceStop(traceSpan *logging.Span) {
+	logging.TraceThreadEnd(traceSpan)
+	err := logging.TraceStop()
+	if err != nil {
+		logging.Logger.Error("Failed to write trace: %v", err)
+	}
 }
 
 // Single user journey
 func perUserThread(threadCtx *journey.MainContext) {
 	defer threadCtx.ThreadsWG.Done()
+	defer threadCtx.TraceEnd()
+	defer journey.StopWatching(threadCtx.Namespace)
+	defer func() { journey.StopWatching(threadCtx.ManagedNamespace) }()
+	defer logging.TrackThread("user")()
 
 	var err error
 
@@ -208,10 +326,16 @@ func perUserThread(threadCtx *journey.MainContext) {
 			break
 		}
 
+		// Check if we were interrupted
+		if journey.Stopped() {
+			logging.Logger.Debug("Done with user journey because journeys were stopped")
+			break
+		}
+
 	}
 
-	// Collect info about PVCs
-	_, err = logging.Measure(journey.HandlePersistentVolumeClaim, threadCtx)
+	// Run user level steps, e.g. collect info about PVCs
+	err = journey.RunUserSteps(threadCtx)
 	if err != nil {
 		logging.Logger.Error("Thread failed: %v", err)
 		return
@@ -222,6 +346,8 @@ func perUserThread(threadCtx *journey.MainContext) {
 // Single application journey (there can be multiple parallel apps per user)
 func perApplicationThread(perApplicationCtx *journey.PerApplicationContext) {
 	defer perApplicationCtx.PerApplicationWG.Done()
+	defer perApplicationCtx.TraceEnd()
+	defer logging.TrackThread("application")()
 
 	var err error
 
@@ -232,15 +358,8 @@ func perApplicationThread(perApplicationCtx *journey.PerApplicationContext) {
 		return
 	}
 
-	// Create application
-	_, err = logging.Measure(journey.HandleApplication, perApplicationCtx)
-	if err != nil {
-		logging.Logger.Error("Thread failed: %v", err)
-		return
-	}
-
-	// Create integration test scenario
-	_, err = logging.Measure(journey.HandleIntegrationTestScenario, perApplicationCtx)
+	// Run application level steps, e.g. create application and integration test scenarios
+	err = journey.RunApplicationSteps(perApplicationCtx)
 	if err != nil {
 		logging.Logger.Error("Thread failed: %v", err)
 		return
@@ -257,6 +376,8 @@ func perApplicationThread(perApplicationCtx *journey.PerApplicationContext) {
 // Single component journey (there can be multiple parallel comps per app)
 func perComponentThread(perComponentCtx *journey.PerComponentContext) {
 	defer perComponentCtx.PerComponentWG.Done()
+	defer perComponentCtx.TraceEnd()
+	defer logging.TrackThread("component")()
 	defer func() {
 		_, err := logging.Measure(journey.HandlePerComponentCollection, perComponentCtx)
 		if err != nil {
@@ -273,22 +394,8 @@ func perComponentThread(perComponentCtx *journey.PerComponentContext) {
 		return
 	}
 
-	// Create component
-	_, err = logging.Measure(journey.HandleComponent, perComponentCtx)
-	if err != nil {
-		logging.Logger.Error("Per component thread failed: %v", err)
-		return
-	}
-
-	// Wait for build pipiline run
-	_, err = logging.Measure(journey.HandlePipelineRun, perComponentCtx)
-	if err != nil {
-		logging.Logger.Error("Per component thread failed: %v", err)
-		return
-	}
-
-	// Wait for test pipiline run
-	_, err = logging.Measure(journey.HandleTest, perComponentCtx)
+	// Run component level steps, e.g. create component and wait for its build and test pipeline runs
+	err = journey.RunComponentSteps(perComponentCtx)
 	if err != nil {
 		logging.Logger.Error("Per component thread failed: %v", err)
 		return
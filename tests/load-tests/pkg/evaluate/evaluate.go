@@ -0,0 +1,570 @@
+package evaluate
+
+import "encoding/csv"
+import "encoding/json"
+import "errors"
+import "fmt"
+import "io"
+import "math"
+import "math/big"
+import "os"
+import "path/filepath"
+import "sort"
+import "strconv"
+import "strings"
+import "time"
+
+// Column indexes in load-test-timings.csv
+const columnWhen = 0
+const columnMetric = 1
+const columnDuration = 2
+const columnParams = 3
+const columnError = 4
+const columnPhase = 5 // missing in files from older runs
+const columnUser = 6  // missing in files from older runs
+
+// Column indexes in load-test-errors.csv
+const columnErrorWhen = 0
+const columnErrorCode = 1
+const columnErrorMessage = 2
+const columnErrorCategory = 3 // missing in files written before errors were classified
+
+// Metrics we care about that together form KPI metric duration
+var DefaultMetrics = []string{
+	"HandleUser",
+	"createApplication",
+	"validateApplication",
+	"createIntegrationTestScenario",
+	"validateIntegrationTestScenario",
+	"createComponent",
+	"validatePipelineRunCreation",
+	"validatePipelineRunCondition",
+	"validatePipelineRunSignature",
+	"validateSnapshotCreation",
+	"validateTestPipelineRunCreation",
+	"validateTestPipelineRunCondition",
+}
+
+// Metrics of optional release step, they become part of KPI when the test measured them
+var ReleaseMetrics = []string{
+	"createRelease",
+	"validateReleasePipelineRunCreation",
+	"validateReleasePipelineRunCondition",
+}
+
+// Struct to hold evaluation options
+type Opts struct {
+	InputDir  string   // where load-test-timings.csv and load-test-errors.csv are
+	OutputDir string   // where load-test.json and load-test-summary.md are written
+	Metrics   []string // metrics forming the KPI, function names as measured by logging.Measure
+}
+
+// Represents one row of load-test-timings.csv
+type measurement struct {
+	When     time.Time
+	Metric   string
+	Duration float64
+	Failed   bool
+	Phase    string
+	User     string
+}
+
+// Represents one row of load-test-errors.csv
+type failure struct {
+	When     time.Time
+	Code     int
+	Message  string
+	Category string
+}
+
+// Statistics of a list of durations in seconds
+type DurationStats struct {
+	Samples int     `json:"samples"`
+	Min     float64 `json:"min"`
+	Mean    float64 `json:"mean"`
+	P50     float64 `json:"p50"`
+	P90     float64 `json:"p90"`
+	P95     float64 `json:"p95"`
+	P99     float64 `json:"p99"`
+	Max     float64 `json:"max"`
+}
+
+// Without samples only their count is stored, same as evaluate.py used to do
+func (s DurationStats) MarshalJSON() ([]byte, error) {
+	if s.Samples == 0 {
+		return []byte(`{"samples":0}`), nil
+	}
+	type durationStats DurationStats
+	return json.Marshal(durationStats(s))
+}
+
+// Statistics of a list of timestamps
+type WhenStats struct {
+	Min  string  `json:"min"`
+	Mean string  `json:"mean"`
+	Max  string  `json:"max"`
+	Span float64 `json:"span"`
+}
+
+// Without timestamps nothing is stored, same as evaluate.py used to do
+func (s WhenStats) MarshalJSON() ([]byte, error) {
+	if s.Min == "" {
+		return []byte(`{}`), nil
+	}
+	type whenStats WhenStats
+	return json.Marshal(whenStats(s))
+}
+
+// Statistics of passed or failed measurements of one metric
+type ResultStats struct {
+	Duration DurationStats `json:"duration"`
+	When     WhenStats     `json:"when"`
+}
+
+// Statistics of one metric
+type MetricStats struct {
+	Pass      ResultStats `json:"pass"`
+	Fail      ResultStats `json:"fail"`
+	ErrorRate *float64    `json:"error_rate"` // nil when metric was not measured at all
+}
+
+// Sum of mean durations of all KPI metrics, -1 if some of them have no passed sample
+type KPIStats struct {
+	Mean   float64 `json:"mean"`
+	Errors int     `json:"errors"`
+}
+
+// Statistics of a set of measurements
+type MeasurementsStats struct {
+	Metrics map[string]*MetricStats
+	KPI     KPIStats
+}
+
+// Metrics are stored next to KPI, same as evaluate.py used to do, so existing consumers keep working
+func (s MeasurementsStats) MarshalJSON() ([]byte, error) {
+	data := map[string]interface{}{"KPI": s.KPI}
+	for metric, stats := range s.Metrics {
+		data[metric] = stats
+	}
+	return json.Marshal(data)
+}
+
+// Statistics of failures with one error code
+type ErrorStats struct {
+	Count      int            `json:"count"`
+	Rate       float64        `json:"rate"` // share of all failures
+	Example    string         `json:"example"`
+	Categories map[string]int `json:"categories,omitempty"` // failures with the code per error category
+}
+
+// Everything we compute, stored under "results" in load-test.json
+type Results struct {
+	Measurements MeasurementsStats             `json:"measurements"`
+	Errors       map[string]*ErrorStats        `json:"errors"`
+	Phases       map[string]*MeasurementsStats `json:"phases,omitempty"`
+	Users        map[string]*KPIStats          `json:"users,omitempty"`
+}
+
+// Compute statistics from measurements and failures of finished load test and store them to load-test.json and load-test-summary.md
+func Evaluate(opts *Opts) (*Results, error) {
+	measurements, err := loadMeasurements(filepath.Join(opts.InputDir, "load-test-timings.csv"))
+	if err != nil {
+		return nil, err
+	}
+
+	failures, err := loadFailures(filepath.Join(opts.InputDir, "load-test-errors.csv"))
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := opts.Metrics
+	if measured(measurements, ReleaseMetrics) {
+		metrics = appendMissing(metrics, ReleaseMetrics)
+	}
+
+	results := computeResults(measurements, failures, metrics)
+
+	err = writeJSON(filepath.Join(opts.OutputDir, "load-test.json"), results)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.WriteFile(filepath.Join(opts.OutputDir, "load-test-summary.md"), []byte(summary(results, metrics)), 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Error writing summary: %v", err)
+	}
+
+	return results, nil
+}
+
+// Read CSV file, missing file is same as empty one
+func readCSV(path string) ([][]string, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return [][]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error opening %s: %v", path, err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	var rows [][]string
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing %s: %v", path, err)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// Load measurements, user and phase columns are empty when measurement does not belong to any user or phase
+func loadMeasurements(path string) ([]measurement, error) {
+	rows, err := readCSV(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var measurements []measurement
+	for _, row := range rows {
+		if len(row) <= columnError {
+			continue
+		}
+
+		when, err := time.Parse(time.RFC3339Nano, row[columnWhen])
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing time %s in %s: %v", row[columnWhen], path, err)
+		}
+		duration, err := strconv.ParseFloat(row[columnDuration], 64)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing duration %s in %s: %v", row[columnDuration], path, err)
+		}
+
+		m := measurement{
+			When:     when.Truncate(time.Microsecond), // same precision as evaluate.py used
+			Metric:   row[columnMetric],
+			Duration: duration,
+			Failed:   row[columnError] != "<nil>",
+		}
+		if len(row) > columnPhase {
+			m.Phase = row[columnPhase]
+		}
+		if len(row) > columnUser {
+			m.User = row[columnUser]
+		}
+
+		measurements = append(measurements, m)
+	}
+
+	return measurements, nil
+}
+
+// Load failures logged by logging.Logger.Fail
+func loadFailures(path string) ([]failure, error) {
+	rows, err := readCSV(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var failures []failure
+	for _, row := range rows {
+		if len(row) <= columnErrorMessage {
+			continue
+		}
+
+		when, err := time.Parse(time.RFC3339Nano, row[columnErrorWhen])
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing time %s in %s: %v", row[columnErrorWhen], path, err)
+		}
+		code, err := strconv.Atoi(row[columnErrorCode])
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing error code %s in %s: %v", row[columnErrorCode], path, err)
+		}
+
+		category := ""
+		if len(row) > columnErrorCategory {
+			category = row[columnErrorCategory]
+		}
+
+		failures = append(failures, failure{When: when, Code: code, Message: row[columnErrorMessage], Category: category})
+	}
+
+	return failures, nil
+}
+
+// Returns true if there is measurement of any of given metrics
+func measured(measurements []measurement, metrics []string) bool {
+	for _, m := range measurements {
+		for _, metric := range metrics {
+			if strings.HasSuffix(m.Metric, "."+metric) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Append metrics not yet in the list
+func appendMissing(list []string, metrics []string) []string {
+	result := append([]string{}, list...)
+	for _, metric := range metrics {
+		found := false
+		for _, m := range list {
+			if m == metric {
+				found = true
+			}
+		}
+		if !found {
+			result = append(result, metric)
+		}
+	}
+	return result
+}
+
+// Compute overall, per phase and per user statistics
+func computeResults(measurements []measurement, failures []failure, metrics []string) *Results {
+	results := &Results{
+		Measurements: computeMeasurements(measurements, metrics),
+		Errors:       computeErrors(failures),
+	}
+
+	byPhase := map[string][]measurement{}
+	byUser := map[string][]measurement{}
+	for _, m := range measurements {
+		if m.Phase != "" {
+			byPhase[m.Phase] = append(byPhase[m.Phase], m)
+		}
+		if m.User != "" {
+			byUser[m.User] = append(byUser[m.User], m)
+		}
+	}
+
+	if len(byPhase) > 0 {
+		results.Phases = map[string]*MeasurementsStats{}
+		for phase, data := range byPhase {
+			stats := computeMeasurements(data, metrics)
+			results.Phases[phase] = &stats
+		}
+	}
+
+	if len(byUser) > 0 {
+		results.Users = map[string]*KPIStats{}
+		for user, data := range byUser {
+			stats := computeMeasurements(data, metrics)
+			results.Users[user] = &stats.KPI
+		}
+	}
+
+	return results
+}
+
+// Compute per metric statistics and KPI of given measurements
+func computeMeasurements(measurements []measurement, metrics []string) MeasurementsStats {
+	stats := MeasurementsStats{Metrics: map[string]*MetricStats{}}
+
+	for _, metric := range metrics {
+		var passDurations, failDurations []float64
+		var passWhen, failWhen []time.Time
+		for _, m := range measurements {
+			if !strings.HasSuffix(m.Metric, "."+metric) {
+				continue
+			}
+			if m.Failed {
+				failDurations = append(failDurations, m.Duration)
+				failWhen = append(failWhen, m.When)
+			} else {
+				passDurations = append(passDurations, m.Duration)
+				passWhen = append(passWhen, m.When)
+			}
+		}
+
+		metricStats := &MetricStats{
+			Pass: ResultStats{Duration: countStats(passDurations), When: countStatsWhen(passWhen)},
+			Fail: ResultStats{Duration: countStats(failDurations), When: countStatsWhen(failWhen)},
+		}
+
+		// If we had 0 measurements in some metric, that means not a single
+		// build made it through all steps, so KPI mean does not make sense
+		// as it would only cover part of the journey
+		if len(passDurations) == 0 {
+			stats.KPI.Mean = -1
+		} else if stats.KPI.Mean != -1 {
+			stats.KPI.Mean += metricStats.Pass.Duration.Mean
+		}
+
+		if samples := len(passDurations) + len(failDurations); samples > 0 {
+			rate := float64(len(failDurations)) / float64(samples)
+			metricStats.ErrorRate = &rate
+			stats.KPI.Errors += len(failDurations)
+		}
+
+		stats.Metrics[metric] = metricStats
+	}
+
+	return stats
+}
+
+// Count failures per error code
+func computeErrors(failures []failure) map[string]*ErrorStats {
+	stats := map[string]*ErrorStats{}
+
+	for _, f := range failures {
+		code := strconv.Itoa(f.Code)
+		if _, ok := stats[code]; !ok {
+			stats[code] = &ErrorStats{Example: f.Message}
+		}
+		stats[code].Count++
+		if f.Category != "" {
+			if stats[code].Categories == nil {
+				stats[code].Categories = map[string]int{}
+			}
+			stats[code].Categories[f.Category]++
+		}
+	}
+
+	for _, s := range stats {
+		s.Rate = float64(s.Count) / float64(len(failures))
+	}
+
+	return stats
+}
+
+// Compute statistics of given durations
+func countStats(data []float64) DurationStats {
+	if len(data) == 0 {
+		return DurationStats{}
+	}
+
+	sorted := append([]float64{}, data...)
+	sort.Float64s(sorted)
+
+	return DurationStats{
+		Samples: len(sorted),
+		Min:     sorted[0],
+		Mean:    mean(sorted),
+		P50:     percentile(sorted, 50),
+		P90:     percentile(sorted, 90),
+		P95:     percentile(sorted, 95),
+		P99:     percentile(sorted, 99),
+		Max:     sorted[len(sorted)-1],
+	}
+}
+
+// Return given percentile of sorted data, interpolating linearly between closest ranks
+func percentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// Return mean of given values rounded only once, like Python statistics.mean does
+func mean(data []float64) float64 {
+	sum := new(big.Rat)
+	for _, d := range data {
+		sum.Add(sum, new(big.Rat).SetFloat64(d))
+	}
+	result, _ := sum.Quo(sum, big.NewRat(int64(len(data)), 1)).Float64()
+	return result
+}
+
+// Format timestamp the way Python datetime.isoformat does
+func isoformat(t time.Time) string {
+	if t.Nanosecond() == 0 {
+		return t.Format("2006-01-02T15:04:05-07:00")
+	}
+	return t.Format("2006-01-02T15:04:05.000000-07:00")
+}
+
+// Compute statistics of given timestamps
+func countStatsWhen(data []time.Time) WhenStats {
+	if len(data) == 0 {
+		return WhenStats{}
+	}
+	if len(data) == 1 {
+		return WhenStats{Min: isoformat(data[0]), Mean: isoformat(data[0]), Max: isoformat(data[0])}
+	}
+
+	min, max := data[0], data[0]
+	timestamps := make([]float64, 0, len(data))
+	for _, w := range data {
+		if w.Before(min) {
+			min = w
+		}
+		if w.After(max) {
+			max = w
+		}
+		timestamps = append(timestamps, float64(w.UnixMicro())/1e6)
+	}
+
+	// Mean timestamp is rounded to microseconds half to even, same as Python datetime.fromtimestamp does
+	seconds, fraction := math.Modf(mean(timestamps))
+	micros := math.RoundToEven(fraction * 1e6)
+	if micros >= 1e6 {
+		seconds, micros = seconds+1, micros-1e6
+	} else if micros < 0 {
+		seconds, micros = seconds-1, micros+1e6
+	}
+
+	return WhenStats{
+		Min:  isoformat(min),
+		Mean: isoformat(time.Unix(int64(seconds), int64(micros)*1000).UTC()),
+		Max:  isoformat(max),
+		Span: float64(max.Sub(min).Microseconds()) / 1e6,
+	}
+}
+
+// Store results under "results" key of given JSON file, keeping whatever else is already there
+func writeJSON(path string, results *Results) error {
+	data := map[string]interface{}{}
+
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err == nil {
+		err = json.Unmarshal(content, &data)
+		if err != nil {
+			return fmt.Errorf("Error parsing %s: %v", path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("Error reading %s: %v", path, err)
+	}
+
+	// Go through JSON to get results as generic map we can merge into existing data
+	resultsJSON, err := json.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("Error marshalling results: %v", err)
+	}
+	resultsData := map[string]interface{}{}
+	err = json.Unmarshal(resultsJSON, &resultsData)
+	if err != nil {
+		return fmt.Errorf("Error unmarshalling results: %v", err)
+	}
+
+	existing, ok := data["results"].(map[string]interface{})
+	if !ok {
+		existing = map[string]interface{}{}
+	}
+	for k, v := range resultsData {
+		existing[k] = v
+	}
+	data["results"] = existing
+
+	content, err = json.MarshalIndent(data, "", "    ")
+	if err != nil {
+		return fmt.Errorf("Error marshalling %s: %v", path, err)
+	}
+
+	err = os.WriteFile(path, content, 0600)
+	if err != nil {
+		return fmt.Errorf("Error writing %s: %v", path, err)
+	}
+
+	return nil
+}
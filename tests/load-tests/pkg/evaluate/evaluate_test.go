@@ -0,0 +1,90 @@
+package evaluate
+
+import "encoding/json"
+import "os"
+import "path/filepath"
+import "testing"
+
+import assert "github.com/stretchr/testify/assert"
+
+// Drop keys evaluate.py did not compute from "duration" objects
+func dropPercentiles(data interface{}) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		if _, ok := v["samples"]; ok {
+			for _, key := range []string{"p50", "p90", "p95", "p99"} {
+				delete(v, key)
+			}
+		}
+		for _, value := range v {
+			dropPercentiles(value)
+		}
+	}
+}
+
+// testdata/evaluate-py.json was produced by evaluate.py from testdata/load-test-timings.csv
+func TestEvaluateMatchesPython(t *testing.T) {
+	measurements, err := loadMeasurements(filepath.Join("testdata", "load-test-timings.csv"))
+	assert.NoError(t, err)
+
+	results := computeResults(measurements, nil, DefaultMetrics)
+	content, err := json.Marshal(results.Measurements)
+	assert.NoError(t, err)
+	var actual interface{}
+	assert.NoError(t, json.Unmarshal(content, &actual))
+	dropPercentiles(actual)
+
+	content, err = os.ReadFile(filepath.Join("testdata", "evaluate-py.json"))
+	assert.NoError(t, err)
+	var expected interface{}
+	assert.NoError(t, json.Unmarshal(content, &expected))
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestEvaluateBreakdowns(t *testing.T) {
+	measurements, err := loadMeasurements(filepath.Join("testdata", "load-test-timings.csv"))
+	assert.NoError(t, err)
+
+	results := computeResults(measurements, nil, []string{"HandleUser"})
+
+	// Users come from the user column, measurements of users not created yet belong to none
+	assert.Equal(t, map[string]*KPIStats{
+		"user-0": {Mean: 1.5},
+		"user-1": {Mean: 2.25},
+	}, results.Users)
+
+	assert.Len(t, results.Phases, 2)
+	assert.Equal(t, KPIStats{Mean: 2.25, Errors: 1}, results.Phases["steady"].KPI)
+	duration := results.Measurements.Metrics["HandleUser"].Pass.Duration
+	assert.Equal(t, 1.875, duration.P50)
+	assert.InDelta(t, 2.175, duration.P90, 1e-9)
+	assert.InDelta(t, 2.2425, duration.P99, 1e-9)
+}
+
+func TestEvaluateWithoutSamples(t *testing.T) {
+	stats := computeMeasurements(nil, []string{"HandleUser"})
+
+	content, err := json.Marshal(stats)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"KPI": {"mean": -1, "errors": 0},
+		"HandleUser": {
+			"pass": {"duration": {"samples": 0}, "when": {}},
+			"fail": {"duration": {"samples": 0}, "when": {}},
+			"error_rate": null
+		}
+	}`, string(content))
+}
+
+func TestComputeErrors(t *testing.T) {
+	errors := computeErrors([]failure{
+		{Code: 10, Message: "first", Category: "timeout"},
+		{Code: 10, Message: "second", Category: "timeout"},
+		{Code: 20, Message: "other"},
+		{Code: 10, Message: "third", Category: "quota"},
+	})
+
+	assert.Equal(t, &ErrorStats{Count: 3, Rate: 0.75, Example: "first", Categories: map[string]int{"timeout": 2, "quota": 1}}, errors["10"])
+	assert.Equal(t, &ErrorStats{Count: 1, Rate: 0.25, Example: "other"}, errors["20"])
+}
@@ -0,0 +1,125 @@
+package evaluate
+
+import "fmt"
+import "sort"
+import "strconv"
+import "strings"
+
+// Render results as Markdown tables
+func summary(results *Results, metrics []string) string {
+	var b strings.Builder
+
+	b.WriteString("# Load test summary\n\n")
+	fmt.Fprintf(&b, "KPI mean: %s, KPI errors: %d\n\n", formatKPIMean(results.Measurements.KPI.Mean), results.Measurements.KPI.Errors)
+
+	b.WriteString("## Metrics\n\n")
+	b.WriteString("| Metric | Samples | Errors | Error rate | Min | Mean | p50 | p90 | p95 | p99 | Max |\n")
+	b.WriteString("|---|---:|---:|---:|---:|---:|---:|---:|---:|---:|---:|\n")
+	for _, metric := range metrics {
+		s := results.Measurements.Metrics[metric]
+		d := s.Pass.Duration
+		fmt.Fprintf(&b, "| %s | %d | %d | %s | %.3f | %.3f | %.3f | %.3f | %.3f | %.3f | %.3f |\n",
+			metric, d.Samples, s.Fail.Duration.Samples, formatRate(s.ErrorRate), d.Min, d.Mean, d.P50, d.P90, d.P95, d.P99, d.Max)
+	}
+	b.WriteString("\n")
+
+	if len(results.Errors) > 0 {
+		b.WriteString("## Errors\n\n")
+		b.WriteString("| Code | Count | Rate | Categories | Example |\n")
+		b.WriteString("|---:|---:|---:|---|---|\n")
+		codes := make([]string, 0, len(results.Errors))
+		for code := range results.Errors {
+			codes = append(codes, code)
+		}
+		sort.Slice(codes, func(i, j int) bool {
+			a, _ := strconv.Atoi(codes[i])
+			b, _ := strconv.Atoi(codes[j])
+			return a < b
+		})
+		for _, code := range codes {
+			e := results.Errors[code]
+			fmt.Fprintf(&b, "| %s | %d | %.1f%% | %s | %s |\n", code, e.Count, e.Rate*100, formatCategories(e.Categories), strings.ReplaceAll(e.Example, "|", "\\|"))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(results.Phases) > 0 {
+		b.WriteString("## Phases\n\n")
+		b.WriteString("| Phase | KPI mean | KPI errors |\n")
+		b.WriteString("|---|---:|---:|\n")
+		phases := make([]string, 0, len(results.Phases))
+		for phase := range results.Phases {
+			phases = append(phases, phase)
+		}
+		// Phases in order in which they started
+		sort.Slice(phases, func(i, j int) bool {
+			return firstSeen(results.Phases[phases[i]]) < firstSeen(results.Phases[phases[j]])
+		})
+		for _, phase := range phases {
+			kpi := results.Phases[phase].KPI
+			fmt.Fprintf(&b, "| %s | %s | %d |\n", phase, formatKPIMean(kpi.Mean), kpi.Errors)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(results.Users) > 0 {
+		b.WriteString("## Users\n\n")
+		b.WriteString("| User | KPI mean | KPI errors |\n")
+		b.WriteString("|---|---:|---:|\n")
+		users := make([]string, 0, len(results.Users))
+		for user := range results.Users {
+			users = append(users, user)
+		}
+		sort.Strings(users)
+		for _, user := range users {
+			kpi := results.Users[user]
+			fmt.Fprintf(&b, "| %s | %s | %d |\n", user, formatKPIMean(kpi.Mean), kpi.Errors)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// KPI mean is -1 when some KPI metric has no passed sample
+func formatKPIMean(mean float64) string {
+	if mean == -1 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.3f", mean)
+}
+
+func formatRate(rate *float64) string {
+	if rate == nil {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.1f%%", *rate*100)
+}
+
+// Earliest timestamp of any measurement, timestamps of one test run share time zone, so they sort as strings
+func firstSeen(stats *MeasurementsStats) string {
+	first := ""
+	for _, s := range stats.Metrics {
+		for _, when := range []string{s.Pass.When.Min, s.Fail.When.Min} {
+			if when != "" && (first == "" || when < first) {
+				first = when
+			}
+		}
+	}
+	return first
+}
+
+// Format counts per error category, e.g. "timeout: 3, unknown: 1"
+func formatCategories(categories map[string]int) string {
+	names := make([]string, 0, len(categories))
+	for name := range categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s: %d", name, categories[name]))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -3,6 +3,7 @@ package journey
 import "fmt"
 import "time"
 
+import ledger "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/ledger"
 import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
 
 import framework "github.com/konflux-ci/e2e-tests/pkg/framework"
@@ -21,7 +22,7 @@ func validateApplication(f *framework.Framework, name, namespace string) error {
 	timeout := time.Minute * 15
 
 	// TODO It would be much better to watch this resource for a condition
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
+	err := utils.WaitUntilWithIntervalContext(f.AsKubeDeveloper.Context(), func() (done bool, err error) {
 		_, err = f.AsKubeDeveloper.HasController.GetApplication(name, namespace)
 		if err != nil {
 			logging.Logger.Debug("Unable to get application %s in namespace %s: %v", name, namespace, err)
@@ -34,14 +35,14 @@ func validateApplication(f *framework.Framework, name, namespace string) error {
 	return err
 }
 
-func HandleApplication(ctx *PerApplicationContext) error {
+func HandleApplication(ctx *PerApplicationContext, f *framework.Framework) error {
 	var err error
 
 	logging.Logger.Debug("Creating application %s in namespace %s", ctx.ApplicationName, ctx.ParentContext.Namespace)
 
 	_, err = logging.Measure(
 		createApplication,
-		ctx.Framework,
+		f,
 		ctx.ParentContext.Namespace,
 		time.Minute*60,
 		ctx.ApplicationName,
@@ -49,10 +50,11 @@ func HandleApplication(ctx *PerApplicationContext) error {
 	if err != nil {
 		return logging.Logger.Fail(30, "Application failed creation: %v", err)
 	}
+	recordCreated(ctx.ParentContext, ledger.KindApplication, ctx.ParentContext.Namespace, "", ctx.ApplicationName)
 
 	_, err = logging.Measure(
 		validateApplication,
-		ctx.Framework,
+		f,
 		ctx.ApplicationName,
 		ctx.ParentContext.Namespace,
 	)
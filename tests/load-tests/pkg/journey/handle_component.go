@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	ledger "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/ledger"
 	logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
 
 	constants "github.com/konflux-ci/e2e-tests/pkg/constants"
@@ -18,6 +19,10 @@ import (
 
 	appstudioApi "github.com/konflux-ci/application-api/api/v1alpha1"
 
+	imagecontroller "github.com/konflux-ci/image-controller/api/v1alpha1"
+
+	rclient "sigs.k8s.io/controller-runtime/pkg/client"
+
 	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 )
 
@@ -118,19 +123,12 @@ func createComponent(f *framework.Framework, namespace, name, repoUrl, repoRevis
 }
 
 func getPaCPullNumber(f *framework.Framework, namespace, name string) (int, error) {
-	interval := time.Second * 20
 	timeout := time.Minute * 15
-	var comp *appstudioApi.Component
 	var pull string
 	var pullNumber int
 
-	// TODO It would be much better to watch this resource for a condition
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		comp, err = f.AsKubeDeveloper.HasController.GetComponent(name, namespace)
-		if err != nil {
-			logging.Logger.Debug("Unable to get created Component %s in namespace %s: %v", name, namespace, err)
-			return false, nil
-		}
+	_, err := waitForTypedObject(f, namespace, componentsResource, timeout, matchName(name), func(comp *appstudioApi.Component) (bool, error) {
+		var err error
 
 		// Check for right annotation
 		pull, err = getPaCPull(comp.Annotations)
@@ -143,7 +141,7 @@ func getPaCPullNumber(f *framework.Framework, namespace, name string) (int, erro
 		}
 
 		return true, nil
-	}, interval, timeout)
+	})
 	if err != nil {
 		return -1, fmt.Errorf("Unable to get PaC pull number for component %s in namespace %s: %v", name, namespace, err)
 	}
@@ -180,7 +178,7 @@ func listPipelineRunsWithTimeout(f *framework.Framework, namespace, appName, com
 	interval := time.Second * 20
 	timeout := time.Minute * 60
 
-	err = utils.WaitUntilWithInterval(func() (done bool, err error) {
+	err = utils.WaitUntilWithIntervalContext(f.AsKubeDeveloper.Context(), func() (done bool, err error) {
 		prs, err = f.AsKubeDeveloper.HasController.GetComponentPipelineRunsWithType(compName, appName, namespace, "build", sha)
 		if err != nil {
 			logging.Logger.Debug("Waiting for PipelineRun for component %s in namespace %s", compName, namespace)
@@ -274,7 +272,34 @@ func utilityRepoTemplatingComponentCleanup(f *framework.Framework, namespace, ap
 	return nil
 }
 
-func HandleComponent(ctx *PerComponentContext) error {
+// Record resources created for component once its PaC PR is opened: image repository, PaC branch and on Gitlab webhook
+func recordComponentResources(ctx *PerComponentContext, f *framework.Framework) {
+	mainCtx := ctx.ParentContext.ParentContext
+	repoUrl := mainCtx.ComponentRepoUrl
+
+	imageRepositories := &imagecontroller.ImageRepositoryList{}
+	err := f.AsKubeDeveloper.ImageController.KubeRest().List(
+		f.AsKubeDeveloper.Context(),
+		imageRepositories,
+		rclient.InNamespace(mainCtx.Namespace),
+		rclient.MatchingLabels{"appstudio.redhat.com/component": ctx.ComponentName},
+	)
+	if err != nil {
+		logging.Logger.Warning("Unable to list image repositories of component %s in namespace %s: %v", ctx.ComponentName, mainCtx.Namespace, err)
+	} else {
+		for _, imageRepository := range imageRepositories.Items {
+			recordCreated(mainCtx, ledger.KindImageRepository, mainCtx.Namespace, "", imageRepository.Name)
+		}
+	}
+
+	recordCreated(mainCtx, ledger.KindGitBranch, "", repoUrl, constants.PaCPullRequestBranchPrefix+ctx.ComponentName)
+
+	if strings.Contains(repoUrl, "gitlab.") && f.ClusterAppDomain != "" {
+		recordCreated(mainCtx, ledger.KindGitlabWebhook, "", repoUrl, f.ClusterAppDomain)
+	}
+}
+
+func HandleComponent(ctx *PerComponentContext, f *framework.Framework) error {
 	var err error
 
 	logging.Logger.Debug("Creating component %s in namespace %s", ctx.ComponentName, ctx.ParentContext.ParentContext.Namespace)
@@ -282,13 +307,13 @@ func HandleComponent(ctx *PerComponentContext) error {
 	// Create component
 	_, err = logging.Measure(
 		createComponent,
-		ctx.Framework,
+		f,
 		ctx.ParentContext.ParentContext.Namespace,
 		ctx.ComponentName,
 		ctx.ParentContext.ParentContext.ComponentRepoUrl,
-		ctx.ParentContext.ParentContext.Opts.ComponentRepoRevision,
-		ctx.ParentContext.ParentContext.Opts.ComponentContainerContext,
-		ctx.ParentContext.ParentContext.Opts.ComponentContainerFile,
+		ctx.ParentContext.ParentContext.Profile.ComponentRepoRevision,
+		ctx.ParentContext.ParentContext.Profile.ComponentContainerContext,
+		ctx.ParentContext.ParentContext.Profile.ComponentContainerFile,
 		ctx.ParentContext.ParentContext.Opts.BuildPipelineSelectorBundle,
 		ctx.ParentContext.ApplicationName,
 		ctx.ParentContext.ParentContext.Opts.PipelineMintmakerDisabled,
@@ -296,11 +321,12 @@ func HandleComponent(ctx *PerComponentContext) error {
 	if err != nil {
 		return logging.Logger.Fail(60, "Component failed creation: %v", err)
 	}
+	recordCreated(ctx.ParentContext.ParentContext, ledger.KindComponent, ctx.ParentContext.ParentContext.Namespace, "", ctx.ComponentName)
 
 	var pullIface interface{}
 	pullIface, err = logging.Measure(
 		getPaCPullNumber,
-		ctx.Framework,
+		f,
 		ctx.ParentContext.ParentContext.Namespace,
 		ctx.ComponentName,
 	)
@@ -314,6 +340,7 @@ func HandleComponent(ctx *PerComponentContext) error {
 	if !ok {
 		return logging.Logger.Fail(62, "Type assertion failed on pull: %+v", pullIface)
 	}
+	recordComponentResources(ctx, f)
 
 	// If this is supposed to be a multi-arch build, we do not care about
 	// current build, we just merge the PR, update pipelines and trigger
@@ -325,19 +352,19 @@ func HandleComponent(ctx *PerComponentContext) error {
 			"QUAY_REPO":   ctx.ParentContext.ParentContext.Opts.QuayRepo,
 			"APPLICATION": ctx.ParentContext.ApplicationName,
 			"COMPONENT":   ctx.ComponentName,
-			"BRANCH":      ctx.ParentContext.ParentContext.Opts.ComponentRepoRevision,
+			"BRANCH":      ctx.ParentContext.ParentContext.Profile.ComponentRepoRevision,
 			"REPOURL":     ctx.ParentContext.ParentContext.ComponentRepoUrl,
 		}
 
 		// Skip what we do not care about, merge PR, graft pipeline yamls
 		_, err = logging.Measure(
 			utilityRepoTemplatingComponentCleanup,
-			ctx.Framework,
+			f,
 			ctx.ParentContext.ParentContext.Namespace,
 			ctx.ParentContext.ApplicationName,
 			ctx.ComponentName,
 			ctx.ParentContext.ParentContext.ComponentRepoUrl,
-			ctx.ParentContext.ParentContext.Opts.ComponentRepoRevision,
+			ctx.ParentContext.ParentContext.Profile.ComponentRepoRevision,
 			ctx.MergeRequestNumber,
 			placeholders,
 		)
@@ -351,7 +378,7 @@ func HandleComponent(ctx *PerComponentContext) error {
 	if len(ctx.ParentContext.ParentContext.Opts.PipelineImagePullSecrets) > 0 {
 		_, err = logging.Measure(
 			configurePipelineImagePullSecrets,
-			ctx.Framework,
+			f,
 			ctx.ParentContext.ParentContext.Namespace,
 			ctx.ComponentName,
 			ctx.ParentContext.ParentContext.Opts.PipelineImagePullSecrets,
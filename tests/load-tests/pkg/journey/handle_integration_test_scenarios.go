@@ -1,11 +1,11 @@
 package journey
 
 import (
-	"context"
 	"fmt"
 	"strings"
 	"time"
 
+	ledger "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/ledger"
 	logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
 
 	framework "github.com/konflux-ci/e2e-tests/pkg/framework"
@@ -33,8 +33,8 @@ func validateIntegrationTestScenario(f *framework.Framework, namespace, name, ap
 	var its integrationApi.IntegrationTestScenario
 
 	// TODO It would be much better to watch this resource for a condition
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		err = f.AsKubeDeveloper.IntegrationController.KubeRest().Get(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, &its)
+	err := utils.WaitUntilWithIntervalContext(f.AsKubeDeveloper.Context(), func() (done bool, err error) {
+		err = f.AsKubeDeveloper.IntegrationController.KubeRest().Get(f.AsKubeDeveloper.Context(), types.NamespacedName{Name: name, Namespace: namespace}, &its)
 		if err != nil {
 			logging.Logger.Debug("Unable to get created integration test scenario %s for application %s in namespace %s: %v", name, appName, namespace, err)
 			return false, nil
@@ -64,38 +64,41 @@ func validateIntegrationTestScenario(f *framework.Framework, namespace, name, ap
 
 }
 
-func HandleIntegrationTestScenario(ctx *PerApplicationContext) error {
+func HandleIntegrationTestScenario(ctx *PerApplicationContext, f *framework.Framework) error {
 	var err error
 
-	name := fmt.Sprintf("%s-its-%s", ctx.ParentContext.Username, util.GenerateRandomString(5))
-	logging.Logger.Debug("Creating integration test scenario %s for application %s in namespace %s", name, ctx.ApplicationName, ctx.ParentContext.Namespace)
-
-	_, err = logging.Measure(
-		createIntegrationTestScenario,
-		ctx.Framework,
-		ctx.ParentContext.Namespace,
-		name,
-		ctx.ApplicationName,
-		ctx.ParentContext.Opts.TestScenarioGitURL,
-		ctx.ParentContext.Opts.TestScenarioRevision,
-		ctx.ParentContext.Opts.TestScenarioPathInRepo,
-	)
-	if err != nil {
-		return logging.Logger.Fail(40, "Integration test scenario failed creation: %v", err)
-	}
+	for _, scenario := range ctx.ParentContext.Profile.IntegrationTestScenarios {
+		name := fmt.Sprintf("%s-its-%s", ctx.ParentContext.Username, util.GenerateRandomString(5))
+		logging.Logger.Debug("Creating integration test scenario %s for application %s in namespace %s", name, ctx.ApplicationName, ctx.ParentContext.Namespace)
+
+		_, err = logging.Measure(
+			createIntegrationTestScenario,
+			f,
+			ctx.ParentContext.Namespace,
+			name,
+			ctx.ApplicationName,
+			scenario.GitURL,
+			scenario.Revision,
+			scenario.PathInRepo,
+		)
+		if err != nil {
+			return logging.Logger.Fail(40, "Integration test scenario failed creation: %v", err)
+		}
+		recordCreated(ctx.ParentContext, ledger.KindIntegrationTestScenario, ctx.ParentContext.Namespace, "", name)
+
+		_, err = logging.Measure(
+			validateIntegrationTestScenario,
+			f,
+			ctx.ParentContext.Namespace,
+			name,
+			ctx.ApplicationName,
+		)
+		if err != nil {
+			return logging.Logger.Fail(41, "Integration test scenario failed validation: %v", err)
+		}
 
-	_, err = logging.Measure(
-		validateIntegrationTestScenario,
-		ctx.Framework,
-		ctx.ParentContext.Namespace,
-		name,
-		ctx.ApplicationName,
-	)
-	if err != nil {
-		return logging.Logger.Fail(41, "Integration test scenario failed validation: %v", err)
+		ctx.IntegrationTestScenarioNames = append(ctx.IntegrationTestScenarioNames, name)
 	}
 
-	ctx.IntegrationTestScenarioName = name
-
 	return nil
 }
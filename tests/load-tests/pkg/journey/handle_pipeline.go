@@ -7,39 +7,31 @@ import "time"
 import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
 
 import framework "github.com/konflux-ci/e2e-tests/pkg/framework"
-import utils "github.com/konflux-ci/e2e-tests/pkg/utils"
 import pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 
+// Labels of build pipeline run of given component
+func buildPipelineRunLabels(appName, compName string) map[string]string {
+	return map[string]string{
+		"appstudio.openshift.io/application":    appName,
+		"appstudio.openshift.io/component":      compName,
+		"pipelines.appstudio.openshift.io/type": "build",
+	}
+}
+
 func validatePipelineRunCreation(f *framework.Framework, namespace, appName, compName string) error {
-	interval := time.Second * 20
 	timeout := time.Minute * 30
 
-	// TODO It would be much better to watch this resource for a condition
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		_, err = f.AsKubeDeveloper.HasController.GetComponentPipelineRunWithType(compName, appName, namespace, "build", "")
-		if err != nil {
-			logging.Logger.Debug("Unable to get created PipelineRun for component %s in namespace %s: %v", compName, namespace, err)
-			return false, nil
-		}
+	_, err := waitForTypedObject(f, namespace, pipelineRunsResource, timeout, matchLabels(buildPipelineRunLabels(appName, compName)), func(pr *pipeline.PipelineRun) (bool, error) {
 		return true, nil
-	}, interval, timeout)
+	})
 
 	return err
 }
 
 func validatePipelineRunCondition(f *framework.Framework, namespace, appName, compName string) error {
-	interval := time.Second * 20
 	timeout := time.Minute * 60
-	var pr *pipeline.PipelineRun
-
-	// TODO It would be much better to watch this resource for a condition
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		pr, err = f.AsKubeDeveloper.HasController.GetComponentPipelineRunWithType(compName, appName, namespace, "build", "")
-		if err != nil {
-			logging.Logger.Debug("Unable to get created PipelineRun for component %s in namespace %s: %v", compName, namespace, err)
-			return false, nil
-		}
 
+	_, err := waitForTypedObject(f, namespace, pipelineRunsResource, timeout, matchLabels(buildPipelineRunLabels(appName, compName)), func(pr *pipeline.PipelineRun) (bool, error) {
 		// Check if there are some conditions
 		if len(pr.Status.Conditions) == 0 {
 			logging.Logger.Debug("PipelineRun for component %s in namespace %s lacks status conditions", compName, namespace)
@@ -61,24 +53,15 @@ func validatePipelineRunCondition(f *framework.Framework, namespace, appName, co
 
 		logging.Logger.Trace("Still waiting for pipeline run condition for component %s in namespace %s", compName, namespace)
 		return false, nil
-	}, interval, timeout)
+	})
 
 	return err
 }
 
 func validatePipelineRunSignature(f *framework.Framework, namespace, appName, compName string) error {
-	interval := time.Second * 20
 	timeout := time.Minute * 60
-	var pr *pipeline.PipelineRun
-
-	// TODO It would be much better to watch this resource for a condition
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		pr, err = f.AsKubeDeveloper.HasController.GetComponentPipelineRunWithType(compName, appName, namespace, "build", "")
-		if err != nil {
-			logging.Logger.Debug("Unable to get created PipelineRun for component %s in namespace %s: %v", compName, namespace, err)
-			return false, nil
-		}
 
+	_, err := waitForTypedObject(f, namespace, pipelineRunsResource, timeout, matchLabels(buildPipelineRunLabels(appName, compName)), func(pr *pipeline.PipelineRun) (bool, error) {
 		// Check if there are some annotations
 		if len(pr.Annotations) == 0 {
 			logging.Logger.Debug("PipelineRun for component %s in namespace %s lacks metadata annotations", compName, namespace)
@@ -97,23 +80,19 @@ func validatePipelineRunSignature(f *framework.Framework, namespace, appName, co
 			logging.Logger.Debug("PipelineRun for component %s in namespace %s do not have 'chains.tekton.dev/signed' annotation", compName, namespace)
 			return false, nil
 		}
-	}, interval, timeout)
+	})
 
 	return err
 }
 
-func HandlePipelineRun(ctx *PerComponentContext) error {
-	if !ctx.ParentContext.ParentContext.Opts.WaitPipelines {
-		return nil
-	}
-
+func HandlePipelineRun(ctx *PerComponentContext, f *framework.Framework) error {
 	var err error
 
 	logging.Logger.Debug("Creating build pipeline run for component %s in namespace %s", ctx.ComponentName, ctx.ParentContext.ParentContext.Namespace)
 
 	_, err = logging.Measure(
 		validatePipelineRunCreation,
-		ctx.Framework,
+		f,
 		ctx.ParentContext.ParentContext.Namespace,
 		ctx.ParentContext.ApplicationName,
 		ctx.ComponentName,
@@ -124,7 +103,7 @@ func HandlePipelineRun(ctx *PerComponentContext) error {
 
 	_, err = logging.Measure(
 		validatePipelineRunCondition,
-		ctx.Framework,
+		f,
 		ctx.ParentContext.ParentContext.Namespace,
 		ctx.ParentContext.ApplicationName,
 		ctx.ComponentName,
@@ -135,7 +114,7 @@ func HandlePipelineRun(ctx *PerComponentContext) error {
 
 	_, err = logging.Measure(
 		validatePipelineRunSignature,
-		ctx.Framework,
+		f,
 		ctx.ParentContext.ParentContext.Namespace,
 		ctx.ParentContext.ApplicationName,
 		ctx.ComponentName,
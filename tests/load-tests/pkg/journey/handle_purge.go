@@ -1,14 +1,53 @@
 package journey
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	ledger "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/ledger"
+	loadtestutils "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/loadtestutils"
 	logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
+	options "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/options"
 
+	kubeCl "github.com/konflux-ci/e2e-tests/pkg/clients/kubernetes"
 	framework "github.com/konflux-ci/e2e-tests/pkg/framework"
+	utils "github.com/konflux-ci/e2e-tests/pkg/utils"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
 )
 
+// Cluster resources recorded in the ledger
+var ledgerResources = map[string]schema.GroupVersionResource{
+	ledger.KindApplication:              {Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "applications"},
+	ledger.KindComponent:                {Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "components"},
+	ledger.KindImageRepository:          {Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "imagerepositories"},
+	ledger.KindIntegrationTestScenario:  {Group: "appstudio.redhat.com", Version: "v1beta2", Resource: "integrationtestscenarios"},
+	ledger.KindServiceAccount:           {Group: "", Version: "v1", Resource: "serviceaccounts"},
+	ledger.KindRoleBinding:              {Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "rolebindings"},
+	ledger.KindEnterpriseContractPolicy: {Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "enterprisecontractpolicies"},
+	ledger.KindReleasePlan:              {Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "releaseplans"},
+	ledger.KindReleasePlanAdmission:     {Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "releaseplanadmissions"},
+	ledger.KindRelease:                  {Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "releases"},
+}
+
+// Record resource created by user journey to the ledger
+func recordCreated(ctx *MainContext, kind, namespace, repo, name string) {
+	err := ledger.Created(ledger.Entry{
+		Kind:      kind,
+		Namespace: namespace,
+		Repo:      repo,
+		Name:      name,
+		User:      ctx.Username,
+		Thread:    ctx.ThreadIndex,
+	})
+	if err != nil {
+		logging.Logger.Error("Failed to record %s %s to ledger: %v", kind, name, err)
+	}
+}
+
 func purgeStage(f *framework.Framework, namespace string) error {
 	var err error
 
@@ -54,14 +93,15 @@ func Purge() error {
 	errCounter := 0
 
 	for _, ctx := range MainContexts {
+		f := detachFramework(ctx.Framework)
 		if ctx.Opts.Stage {
-			err := purgeStage(ctx.Framework, ctx.Namespace)
+			err := purgeStage(f, ctx.Namespace)
 			if err != nil {
 				logging.Logger.Error("Error when purging Stage: %v", err)
 				errCounter++
 			}
 		} else {
-			err := purgeCi(ctx.Framework, ctx.Username)
+			err := purgeCi(f, ctx.Username)
 			if err != nil {
 				logging.Logger.Error("Error when purging CI: %v", err)
 				errCounter++
@@ -76,3 +116,148 @@ func Purge() error {
 		return nil
 	}
 }
+
+// Delete one resource recorded in the ledger, resource that does not exist anymore is fine
+func purgeLedgerEntry(hub *framework.ControllerHub, e *ledger.Entry) error {
+	switch e.Kind {
+	case ledger.KindNamespace:
+		_, err := hub.CommonController.KubeInterface().CoreV1().Namespaces().Get(context.Background(), e.Name, metav1.GetOptions{})
+		if k8sErrors.IsNotFound(err) {
+			return nil
+		}
+		return hub.CommonController.DeleteNamespace(e.Name)
+
+	case ledger.KindApplication, ledger.KindComponent, ledger.KindImageRepository, ledger.KindIntegrationTestScenario,
+		ledger.KindServiceAccount, ledger.KindRoleBinding, ledger.KindEnterpriseContractPolicy,
+		ledger.KindReleasePlan, ledger.KindReleasePlanAdmission, ledger.KindRelease:
+		err := hub.CommonController.DynamicClient().Resource(ledgerResources[e.Kind]).Namespace(e.Namespace).Delete(context.Background(), e.Name, metav1.DeleteOptions{})
+		if k8sErrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+
+	case ledger.KindGithubRepository:
+		return hub.CommonController.Github.DeleteRepositoryIfExists(e.Name)
+
+	case ledger.KindGitBranch:
+		repoName, err := getRepoNameFromRepoUrl(e.Repo)
+		if err != nil {
+			return err
+		}
+		var exists bool
+		if strings.Contains(e.Repo, "gitlab.") {
+			exists, err = hub.CommonController.Gitlab.ExistsBranch(repoName, e.Name)
+			if err == nil && exists {
+				err = hub.CommonController.Gitlab.DeleteBranch(repoName, e.Name)
+			}
+		} else {
+			exists, err = hub.CommonController.Github.ExistsRef(repoName, e.Name)
+			if err == nil && exists {
+				err = hub.CommonController.Github.DeleteRef(repoName, e.Name)
+			}
+		}
+		return err
+
+	case ledger.KindGitlabWebhook:
+		repoName, err := getRepoNameFromRepoUrl(e.Repo)
+		if err != nil {
+			return err
+		}
+		return hub.CommonController.Gitlab.DeleteWebhooks(repoName, e.Name)
+
+	default:
+		return fmt.Errorf("Unknown kind %s", e.Kind)
+	}
+}
+
+// Get clients to purge resources of given user with
+func ledgerUserHub(opts *options.Opts, e *ledger.Entry, stageUsers []loadtestutils.User, hubs map[string]*framework.ControllerHub) (*framework.ControllerHub, error) {
+	if opts.Stage {
+		if hub, ok := hubs[e.User]; ok {
+			return hub, nil
+		}
+		if e.Thread >= len(stageUsers) || stageUsers[e.Thread].Username != e.User {
+			return nil, fmt.Errorf("User %s (thread %d) not found in Stage users", e.User, e.Thread)
+		}
+		user := stageUsers[e.Thread]
+		f, err := framework.NewFrameworkWithTimeout(
+			e.User,
+			time.Minute*60,
+			utils.Options{
+				ToolchainApiUrl: user.APIURL,
+				KeycloakUrl:     user.SSOURL,
+				OfflineToken:    user.Token,
+			})
+		if err != nil {
+			return nil, fmt.Errorf("Unable to provision framework for user %s: %v", e.User, err)
+		}
+		hubs[e.User] = f.AsKubeDeveloper
+		return f.AsKubeDeveloper, nil
+	}
+
+	// Admin can purge everything, do not create the framework as it would create user namespace
+	if hub, ok := hubs[""]; ok {
+		return hub, nil
+	}
+	client, err := kubeCl.NewAdminKubernetesClient()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to create admin client: %v", err)
+	}
+	hub, err := framework.InitControllerHub(client)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to initialize admin clients: %v", err)
+	}
+	hubs[""] = hub
+	return hub, nil
+}
+
+// Delete exactly the resources recorded in the ledger that were not purged yet,
+// newest first. Every purged resource is recorded too, so this can be resumed.
+func PurgeFromLedger(opts *options.Opts) error {
+	pending, skipped, err := ledger.Pending(opts.LedgerFile)
+	if err != nil {
+		return err
+	}
+	if skipped > 0 {
+		logging.Logger.Warning("Skipped %d unparsable lines in ledger %s", skipped, opts.LedgerFile)
+	}
+	logging.Logger.Info("Purging %d resources recorded in ledger %s", len(pending), opts.LedgerFile)
+
+	var stageUsers []loadtestutils.User
+	if opts.Stage {
+		stageUsers, err = loadtestutils.LoadStageUsers("users.json")
+		if err != nil {
+			return fmt.Errorf("Failed to load Stage users: %v", err)
+		}
+	}
+
+	hubs := map[string]*framework.ControllerHub{}
+	errCounter := 0
+
+	for i := len(pending) - 1; i >= 0; i-- {
+		e := &pending[i]
+
+		hub, err := ledgerUserHub(opts, e, stageUsers, hubs)
+		if err == nil {
+			err = purgeLedgerEntry(hub, e)
+		}
+		if err != nil {
+			logging.Logger.Error("Error when purging %s: %v", e, err)
+			errCounter++
+			continue
+		}
+
+		err = ledger.Purged(*e)
+		if err != nil {
+			logging.Logger.Error("Failed to record purge of %s to ledger: %v", e, err)
+		}
+		logging.Logger.Debug("Purged %s", e)
+	}
+
+	if errCounter > 0 {
+		return fmt.Errorf("Hit %d errors when purging resources from ledger", errCounter)
+	} else {
+		logging.Logger.Info("No errors when purging resources from ledger")
+		return nil
+	}
+}
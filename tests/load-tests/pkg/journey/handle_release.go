@@ -0,0 +1,308 @@
+package journey
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	ledger "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/ledger"
+	logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
+
+	framework "github.com/konflux-ci/e2e-tests/pkg/framework"
+
+	util "github.com/devfile/library/v2/pkg/util"
+
+	tektonutils "github.com/konflux-ci/release-service/tekton/utils"
+
+	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// Service account release pipelines run as in managed namespace
+const releaseServiceAccountName = "release-service-account"
+
+// Key release pipeline uses to verify signatures of built images
+const releasePublicKey = "k8s://openshift-pipelines/public-key"
+
+// Labels of release pipeline run of given release
+func releasePipelineRunLabels(releaseName, releaseNamespace string) map[string]string {
+	return map[string]string{
+		"release.appstudio.openshift.io/name":      releaseName,
+		"release.appstudio.openshift.io/namespace": releaseNamespace,
+	}
+}
+
+// Prepare managed namespace where releases are processed: service account release pipelines
+// run as and Enterprise Contract policy releases are verified against. On Stage users can not
+// create namespaces, so there tenant namespace is used as managed namespace.
+func createReleaseManagedNamespace(f *framework.Framework, ctx *MainContext, managedNamespace, policyName string) error {
+	if !ctx.Opts.Stage {
+		_, err := f.AsKubeAdmin.CommonController.CreateTestNamespace(managedNamespace)
+		if err != nil {
+			return fmt.Errorf("Unable to create managed namespace %s: %v", managedNamespace, err)
+		}
+		recordCreated(ctx, ledger.KindNamespace, "", "", managedNamespace)
+	}
+
+	serviceAccount, err := f.AsKubeDeveloper.CommonController.CreateServiceAccount(releaseServiceAccountName, managedNamespace, nil, nil)
+	if err != nil {
+		return fmt.Errorf("Unable to create service account %s in namespace %s: %v", releaseServiceAccountName, managedNamespace, err)
+	}
+	recordCreated(ctx, ledger.KindServiceAccount, managedNamespace, "", releaseServiceAccountName)
+
+	roleBinding, err := f.AsKubeDeveloper.ReleaseController.CreateReleasePipelineRoleBindingForServiceAccount(managedNamespace, serviceAccount)
+	if err != nil {
+		return fmt.Errorf("Unable to create role binding for service account %s in namespace %s: %v", releaseServiceAccountName, managedNamespace, err)
+	}
+	recordCreated(ctx, ledger.KindRoleBinding, managedNamespace, "", roleBinding.Name)
+
+	defaultPolicy, err := f.AsKubeDeveloper.TektonController.GetEnterpriseContractPolicy("default", "enterprise-contract-service")
+	if err != nil {
+		return fmt.Errorf("Unable to get default Enterprise Contract policy: %v", err)
+	}
+	policySpec := defaultPolicy.Spec
+	policySpec.PublicKey = releasePublicKey
+	_, err = f.AsKubeDeveloper.TektonController.CreateEnterpriseContractPolicy(policyName, managedNamespace, policySpec)
+	if err != nil {
+		return fmt.Errorf("Unable to create Enterprise Contract policy %s in namespace %s: %v", policyName, managedNamespace, err)
+	}
+	recordCreated(ctx, ledger.KindEnterpriseContractPolicy, managedNamespace, "", policyName)
+
+	return nil
+}
+
+// Create release plan in tenant namespace and release plan admission in managed namespace for the application.
+// Releases are created by the journey, so automatic release is disabled.
+func createReleasePlans(f *framework.Framework, ctx *PerApplicationContext, releasePlanName, releasePlanAdmissionName, policyName string) error {
+	mainCtx := ctx.ParentContext
+
+	_, err := f.AsKubeDeveloper.ReleaseController.CreateReleasePlan(releasePlanName, mainCtx.Namespace, ctx.ApplicationName, mainCtx.ManagedNamespace, "false", nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("Unable to create release plan %s in namespace %s: %v", releasePlanName, mainCtx.Namespace, err)
+	}
+	recordCreated(mainCtx, ledger.KindReleasePlan, mainCtx.Namespace, "", releasePlanName)
+
+	// Components of the application are known upfront, see PerComponentSetup
+	components := []map[string]interface{}{}
+	for componentIndex := 0; componentIndex < mainCtx.Profile.ComponentsCount; componentIndex++ {
+		componentName := fmt.Sprintf("%s-comp-%d", ctx.ApplicationName, componentIndex)
+		components = append(components, map[string]interface{}{
+			"component":  componentName,
+			"repository": fmt.Sprintf("quay.io/%s/%s-released", mainCtx.Opts.QuayRepo, componentName),
+		})
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"mapping": map[string]interface{}{
+			"components": components,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Unable to marshal release plan admission data: %v", err)
+	}
+
+	_, err = f.AsKubeDeveloper.ReleaseController.CreateReleasePlanAdmission(
+		releasePlanAdmissionName,
+		mainCtx.ManagedNamespace,
+		"",
+		mainCtx.Namespace,
+		policyName,
+		releaseServiceAccountName,
+		[]string{ctx.ApplicationName},
+		false,
+		&tektonutils.PipelineRef{
+			Resolver: "git",
+			Params: []tektonutils.Param{
+				{Name: "url", Value: mainCtx.Opts.ReleasePipelineGitURL},
+				{Name: "revision", Value: mainCtx.Opts.ReleasePipelineRevision},
+				{Name: "pathInRepo", Value: mainCtx.Opts.ReleasePipelinePathInRepo},
+			},
+		},
+		&runtime.RawExtension{Raw: data},
+	)
+	if err != nil {
+		return fmt.Errorf("Unable to create release plan admission %s in namespace %s: %v", releasePlanAdmissionName, mainCtx.ManagedNamespace, err)
+	}
+	recordCreated(mainCtx, ledger.KindReleasePlanAdmission, mainCtx.ManagedNamespace, "", releasePlanAdmissionName)
+
+	return nil
+}
+
+func createRelease(f *framework.Framework, namespace, name, snapshotName, releasePlanName string) error {
+	_, err := f.AsKubeDeveloper.ReleaseController.CreateRelease(name, namespace, snapshotName, releasePlanName)
+	if err != nil {
+		return fmt.Errorf("Unable to create release %s for snapshot %s in namespace %s: %v", name, snapshotName, namespace, err)
+	}
+	return nil
+}
+
+func validateReleasePipelineRunCreation(f *framework.Framework, managedNamespace, namespace, releaseName string) error {
+	timeout := time.Minute * 30
+
+	_, err := waitForTypedObject(f, managedNamespace, pipelineRunsResource, timeout, matchLabels(releasePipelineRunLabels(releaseName, namespace)), func(pr *pipeline.PipelineRun) (bool, error) {
+		return pr.HasStarted(), nil
+	})
+
+	return err
+}
+
+func validateReleasePipelineRunCondition(f *framework.Framework, managedNamespace, namespace, releaseName string) error {
+	timeout := time.Minute * 60
+
+	_, err := waitForTypedObject(f, managedNamespace, pipelineRunsResource, timeout, matchLabels(releasePipelineRunLabels(releaseName, namespace)), func(pr *pipeline.PipelineRun) (bool, error) {
+		// Check if there are some conditions
+		if len(pr.Status.Conditions) == 0 {
+			logging.Logger.Debug("Release PipelineRun for release %s in namespace %s lacks status conditions", releaseName, managedNamespace)
+			return false, nil
+		}
+
+		// Check right condition status
+		for _, condition := range pr.Status.Conditions {
+			if (strings.HasPrefix(string(condition.Type), "Error") || strings.HasSuffix(string(condition.Type), "Error")) && condition.Status == "True" {
+				return false, fmt.Errorf("Release PipelineRun for release %s in namespace %s is in error state: %+v", releaseName, managedNamespace, condition)
+			}
+			if condition.Type == "Succeeded" && condition.Status == "False" {
+				return false, fmt.Errorf("Release PipelineRun for release %s in namespace %s failed: %+v", releaseName, managedNamespace, condition)
+			}
+			if condition.Type == "Succeeded" && condition.Status == "True" {
+				return true, nil
+			}
+		}
+
+		logging.Logger.Trace("Still waiting for release pipeline run condition for release %s in namespace %s", releaseName, managedNamespace)
+		return false, nil
+	})
+
+	return err
+}
+
+// Set up managed namespace of the user once, through framework of the component that releases first
+func ensureReleaseManagedNamespace(f *framework.Framework, ctx *MainContext) error {
+	ctx.releaseMutex.Lock()
+	defer ctx.releaseMutex.Unlock()
+
+	if ctx.ManagedNamespace != "" {
+		return nil
+	}
+
+	managedNamespace := ctx.Namespace
+	if !ctx.Opts.Stage {
+		managedNamespace = ctx.Namespace + "-managed"
+	}
+
+	_, err := logging.Measure(
+		createReleaseManagedNamespace,
+		f,
+		ctx,
+		managedNamespace,
+		ctx.Username+"-release-policy",
+	)
+	if err != nil {
+		return err
+	}
+
+	ctx.ManagedNamespace = managedNamespace
+	return nil
+}
+
+// Set up release plan and release plan admission of the application once, through framework
+// of the component that releases first
+func ensureReleasePlans(f *framework.Framework, ctx *PerApplicationContext) error {
+	ctx.releaseMutex.Lock()
+	defer ctx.releaseMutex.Unlock()
+
+	if ctx.ReleasePlanName != "" {
+		return nil
+	}
+
+	_, err := logging.Measure(
+		createReleasePlans,
+		f,
+		ctx,
+		ctx.ApplicationName+"-rp",
+		ctx.ApplicationName+"-rpa",
+		ctx.ParentContext.Username+"-release-policy",
+	)
+	if err != nil {
+		return err
+	}
+
+	ctx.ReleasePlanName = ctx.ApplicationName + "-rp"
+	return nil
+}
+
+func HandleRelease(ctx *PerComponentContext, f *framework.Framework) error {
+	var err error
+
+	mainCtx := ctx.ParentContext.ParentContext
+
+	logging.Logger.Debug("Releasing component %s in namespace %s", ctx.ComponentName, mainCtx.Namespace)
+
+	err = ensureReleaseManagedNamespace(f, mainCtx)
+	if err != nil {
+		return logging.Logger.Fail(110, "Release managed namespace failed setup: %v", err)
+	}
+
+	err = ensureReleasePlans(f, ctx.ParentContext)
+	if err != nil {
+		return logging.Logger.Fail(111, "Release plans failed creation: %v", err)
+	}
+
+	// Snapshot is known if test pipeline runs step ran already
+	if ctx.SnapshotName == "" {
+		result, err := logging.Measure(
+			validateSnapshotCreation,
+			f,
+			mainCtx.Namespace,
+			ctx.ComponentName,
+		)
+		if err != nil {
+			return logging.Logger.Fail(112, "Snapshot failed creation: %v", err)
+		}
+		snapshotName, ok := result.(string)
+		if !ok {
+			return logging.Logger.Fail(112, "Snapshot name type assertion failed")
+		}
+		ctx.SnapshotName = snapshotName
+	}
+
+	ctx.ReleaseName = fmt.Sprintf("%s-rel-%s", ctx.ComponentName, util.GenerateRandomString(5))
+
+	_, err = logging.Measure(
+		createRelease,
+		f,
+		mainCtx.Namespace,
+		ctx.ReleaseName,
+		ctx.SnapshotName,
+		ctx.ParentContext.ReleasePlanName,
+	)
+	if err != nil {
+		return logging.Logger.Fail(113, "Release failed creation: %v", err)
+	}
+	recordCreated(mainCtx, ledger.KindRelease, mainCtx.Namespace, "", ctx.ReleaseName)
+
+	_, err = logging.Measure(
+		validateReleasePipelineRunCreation,
+		f,
+		mainCtx.ManagedNamespace,
+		mainCtx.Namespace,
+		ctx.ReleaseName,
+	)
+	if err != nil {
+		return logging.Logger.Fail(114, "Release Pipeline Run failed creation: %v", err)
+	}
+
+	_, err = logging.Measure(
+		validateReleasePipelineRunCondition,
+		f,
+		mainCtx.ManagedNamespace,
+		mainCtx.Namespace,
+		ctx.ReleaseName,
+	)
+	if err != nil {
+		return logging.Logger.Fail(115, "Release Pipeline Run failed run: %v", err)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import "fmt"
 import "strings"
 import "regexp"
 
+import ledger "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/ledger"
 import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
 
 import framework "github.com/konflux-ci/e2e-tests/pkg/framework"
@@ -160,12 +161,12 @@ func templateFiles(f *framework.Framework, repoUrl, repoRevision string, placeho
 }
 
 func HandleRepoForking(ctx *MainContext) error {
-	logging.Logger.Debug("Forking repository %s for user %s", ctx.Opts.ComponentRepoUrl, ctx.Username)
+	logging.Logger.Debug("Forking repository %s for user %s", ctx.Profile.ComponentRepoUrl, ctx.Username)
 
 	forkUrl, err := ForkRepo(
 		ctx.Framework,
-		ctx.Opts.ComponentRepoUrl,
-		ctx.Opts.ComponentRepoRevision,
+		ctx.Profile.ComponentRepoUrl,
+		ctx.Profile.ComponentRepoRevision,
 		ctx.Username,
 	)
 	if err != nil {
@@ -174,5 +175,13 @@ func HandleRepoForking(ctx *MainContext) error {
 
 	ctx.ComponentRepoUrl = forkUrl
 
+	if forkUrl != ctx.Profile.ComponentRepoUrl {
+		forkName, err := getRepoNameFromRepoUrl(forkUrl)
+		if err != nil {
+			return logging.Logger.Fail(80, "Repo forking failed: %v", err)
+		}
+		recordCreated(ctx, ledger.KindGithubRepository, "", "", forkName)
+	}
+
 	return nil
 }
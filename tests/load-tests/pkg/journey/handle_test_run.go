@@ -8,57 +8,44 @@ import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
 
 import appstudioApi "github.com/konflux-ci/application-api/api/v1alpha1"
 import framework "github.com/konflux-ci/e2e-tests/pkg/framework"
-import utils "github.com/konflux-ci/e2e-tests/pkg/utils"
 import pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 
+// Labels of test pipeline run of given integration test scenario and snapshot
+func testPipelineRunLabels(itsName, snapName string) map[string]string {
+	return map[string]string{
+		"pipelines.appstudio.openshift.io/type": "test",
+		"test.appstudio.openshift.io/scenario":  itsName,
+		"appstudio.openshift.io/snapshot":       snapName,
+	}
+}
+
 func validateSnapshotCreation(f *framework.Framework, namespace, compName string) (string, error) {
-	interval := time.Second * 20
 	timeout := time.Minute * 30
-	var snap *appstudioApi.Snapshot
 
-	// TODO It would be much better to watch this resource for a condition
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		snap, err = f.AsKubeDeveloper.IntegrationController.GetSnapshot("", "", compName, namespace)
-		if err != nil {
-			logging.Logger.Debug("Unable to get created Snapshot for component %s in namespace %s: %v", compName, namespace, err)
-			return false, nil
-		}
+	snap, err := waitForTypedObject(f, namespace, snapshotsResource, timeout, matchLabels(map[string]string{"appstudio.openshift.io/component": compName}), func(snap *appstudioApi.Snapshot) (bool, error) {
 		return true, nil
-	}, interval, timeout)
+	})
+	if err != nil {
+		return "", err
+	}
 
-	return snap.Name, err
+	return snap.Name, nil
 }
 
 func validateTestPipelineRunCreation(f *framework.Framework, namespace, itsName, snapName string) error {
-	interval := time.Second * 20
 	timeout := time.Minute * 30
 
-	// TODO It would be much better to watch this resource for a condition
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		_, err = f.AsKubeDeveloper.IntegrationController.GetIntegrationPipelineRun(itsName, snapName, namespace)
-		if err != nil {
-			logging.Logger.Debug("Unable to get created test PipelineRun for integration test pipeline %s in namespace %s: %v", itsName, namespace, err)
-			return false, nil
-		}
+	_, err := waitForTypedObject(f, namespace, pipelineRunsResource, timeout, matchLabels(testPipelineRunLabels(itsName, snapName)), func(pr *pipeline.PipelineRun) (bool, error) {
 		return true, nil
-	}, interval, timeout)
+	})
 
 	return err
 }
 
 func validateTestPipelineRunCondition(f *framework.Framework, namespace, itsName, snapName string) error {
-	interval := time.Second * 20
 	timeout := time.Minute * 60
-	var pr *pipeline.PipelineRun
-
-	// TODO It would be much better to watch this resource for a condition
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		pr, err = f.AsKubeDeveloper.IntegrationController.GetIntegrationPipelineRun(itsName, snapName, namespace)
-		if err != nil {
-			logging.Logger.Debug("Unable to get created test PipelineRun for integration test pipeline %s in namespace %s: %v", snapName, namespace, err)
-			return false, nil
-		}
 
+	_, err := waitForTypedObject(f, namespace, pipelineRunsResource, timeout, matchLabels(testPipelineRunLabels(itsName, snapName)), func(pr *pipeline.PipelineRun) (bool, error) {
 		// Check if there are some conditions
 		if len(pr.Status.Conditions) == 0 {
 			logging.Logger.Debug("PipelineRun for integration test pipeline %s in namespace %s lacks status conditions", snapName, namespace)
@@ -77,16 +64,12 @@ func validateTestPipelineRunCondition(f *framework.Framework, namespace, itsName
 
 		logging.Logger.Trace("Still waiting for test pipeline run for integration test pipeline %s in namespace %s", snapName, namespace)
 		return false, nil
-	}, interval, timeout)
+	})
 
 	return err
 }
 
-func HandleTest(ctx *PerComponentContext) error {
-	if !ctx.ParentContext.ParentContext.Opts.WaitPipelines || !ctx.ParentContext.ParentContext.Opts.WaitIntegrationTestsPipelines {
-		return nil
-	}
-
+func HandleTest(ctx *PerComponentContext, f *framework.Framework) error {
 	var err error
 	var ok bool
 
@@ -94,7 +77,7 @@ func HandleTest(ctx *PerComponentContext) error {
 
 	result1, err1 := logging.Measure(
 		validateSnapshotCreation,
-		ctx.Framework,
+		f,
 		ctx.ParentContext.ParentContext.Namespace,
 		ctx.ComponentName,
 	)
@@ -106,26 +89,28 @@ func HandleTest(ctx *PerComponentContext) error {
 		return logging.Logger.Fail(81, "Snapshot name type assertion failed")
 	}
 
-	_, err = logging.Measure(
-		validateTestPipelineRunCreation,
-		ctx.Framework,
-		ctx.ParentContext.ParentContext.Namespace,
-		ctx.ParentContext.IntegrationTestScenarioName,
-		ctx.SnapshotName,
-	)
-	if err != nil {
-		return logging.Logger.Fail(82, "Test Pipeline Run failed creation: %v", err)
-	}
+	for _, itsName := range ctx.ParentContext.IntegrationTestScenarioNames {
+		_, err = logging.Measure(
+			validateTestPipelineRunCreation,
+			f,
+			ctx.ParentContext.ParentContext.Namespace,
+			itsName,
+			ctx.SnapshotName,
+		)
+		if err != nil {
+			return logging.Logger.Fail(82, "Test Pipeline Run failed creation: %v", err)
+		}
 
-	_, err = logging.Measure(
-		validateTestPipelineRunCondition,
-		ctx.Framework,
-		ctx.ParentContext.ParentContext.Namespace,
-		ctx.ParentContext.IntegrationTestScenarioName,
-		ctx.SnapshotName,
-	)
-	if err != nil {
-		return logging.Logger.Fail(83, "Test Pipeline Run failed run: %v", err)
+		_, err = logging.Measure(
+			validateTestPipelineRunCondition,
+			f,
+			ctx.ParentContext.ParentContext.Namespace,
+			itsName,
+			ctx.SnapshotName,
+		)
+		if err != nil {
+			return logging.Logger.Fail(83, "Test Pipeline Run failed run: %v", err)
+		}
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package journey
 import "fmt"
 import "time"
 
+import ledger "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/ledger"
 import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
 
 import "github.com/konflux-ci/e2e-tests/pkg/framework"
@@ -34,6 +35,16 @@ func HandleUser(ctx *MainContext) error {
 
 	ctx.Namespace = ctx.Framework.UserNamespace
 
+	bindFramework(ctx.Framework, &ctx.stepContext)
+	logging.TraceBind(ctx.traceSpan, ctx.Framework)
+	logging.TraceSetAttribute(ctx.traceSpan, "loadtest.username", ctx.Username)
+	logging.TraceSetAttribute(ctx.traceSpan, "loadtest.namespace", ctx.Namespace)
+
+	// Namespace on Stage belongs to the precreated user
+	if !ctx.Opts.Stage {
+		recordCreated(ctx, ledger.KindNamespace, "", "", ctx.Namespace)
+	}
+
 	return nil
 }
 
@@ -59,6 +70,9 @@ func HandleNewFrameworkForComp(ctx *PerComponentContext) error {
 		return logging.Logger.Fail(11, "Unable to provision framework for user %s: %v", ctx.ParentContext.ParentContext.Username, err)
 	}
 
+	bindFramework(ctx.Framework, &ctx.stepContext)
+	logging.TraceBind(ctx.traceSpan, ctx.Framework)
+
 	return nil
 }
 
@@ -84,5 +98,8 @@ func HandleNewFrameworkForApp(ctx *PerApplicationContext) error {
 		return logging.Logger.Fail(12, "Unable to provision framework for user %s: %v", ctx.ParentContext.Username, err)
 	}
 
+	bindFramework(ctx.Framework, &ctx.stepContext)
+	logging.TraceBind(ctx.traceSpan, ctx.Framework)
+
 	return nil
 }
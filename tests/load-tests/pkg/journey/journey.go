@@ -1,7 +1,9 @@
 package journey
 
+import "context"
 import "fmt"
 import "sync"
+import "time"
 
 import options "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/options"
 import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
@@ -13,26 +15,107 @@ import util "github.com/devfile/library/v2/pkg/util"
 // Pointers to all user journey thread contexts
 var MainContexts []*MainContext
 
+// Context user journeys run in, see SetContext
+var journeyContext = context.Background()
+
+// Set context user journeys run in, has to be called before Setup. Once it is cancelled
+// (e.g. on SIGINT), API calls and waits of journey frameworks stop and threads finish
+// without starting any more work.
+func SetContext(ctx context.Context) {
+	journeyContext = ctx
+}
+
+// Check if user journeys were asked to stop
+func Stopped() bool {
+	return journeyContext.Err() != nil
+}
+
+// Bind framework to step context of its thread. Framework is updated in place, because
+// on Stage it is periodically refreshed through the same pointer (and keeps its context then).
+func bindFramework(f *framework.Framework, steps *stepContext) {
+	*f = *f.WithContext(steps)
+}
+
+// Get copy of framework not bound to journey context, e.g. to clean up after journeys were stopped
+func detachFramework(f *framework.Framework) *framework.Framework {
+	if f == nil {
+		return nil
+	}
+	return f.WithContext(context.Background())
+}
+
 // Struct to hold user journey thread data
 type MainContext struct {
 	ThreadsWG              *sync.WaitGroup
 	ThreadIndex            int
 	JourneyRepeatsCounter  int
 	Opts                   *options.Opts
+	Profile                *options.Profile
+	Arrival                *options.ScheduledArrival // when user starts its journey, nil if all start at once
 	StageUsers             *[]loadtestutils.User
 	Framework              *framework.Framework
 	Username               string
 	Namespace              string
-	ComponentRepoUrl       string // overrides same value from Opts, needed when templating repos
+	ComponentRepoUrl       string // overrides same value from Profile, needed when templating repos
+	ManagedNamespace       string // where releases are processed, set up by first component that gets released
+	releaseMutex           sync.Mutex
+	traceSpan              *logging.Span
+	stepContext            stepContext // steps of the thread run with this context, see bindFramework
 	PerApplicationContexts []*PerApplicationContext
 }
 
+// Return name of the user, empty until it is created
+func (ctx *MainContext) JourneyUser() string {
+	if ctx == nil {
+		return ""
+	}
+	return ctx.Username
+}
+
+// Return phase user arrived in, empty if all users start at once
+func (ctx *MainContext) ArrivalPhase() string {
+	if ctx == nil || ctx.Arrival == nil {
+		return ""
+	}
+	return ctx.Arrival.Phase
+}
+
+// Framework of the user, bound to its step context
+func (ctx *MainContext) threadFramework() *framework.Framework {
+	return ctx.Framework
+}
+
+func (ctx *MainContext) threadStepContext() *stepContext {
+	return &ctx.stepContext
+}
+
+// Start span of user thread, parent is the span opts are bound to
+func (ctx *MainContext) traceStart() {
+	ctx.traceSpan = logging.TraceThreadStart(
+		"user",
+		fmt.Sprintf("user %d", ctx.ThreadIndex),
+		ctx.Opts,
+		map[string]string{
+			"loadtest.thread":  fmt.Sprint(ctx.ThreadIndex),
+			"loadtest.profile": ctx.Profile.Name,
+		},
+		ctx,
+	)
+}
+
+// End span of user thread
+func (ctx *MainContext) TraceEnd() {
+	logging.TraceThreadEnd(ctx.traceSpan)
+}
+
 // Just to create user
 func initUserThread(threadCtx *MainContext) {
 	defer threadCtx.ThreadsWG.Done()
 
 	var err error
 
+	threadCtx.traceStart()
+
 	// Create user if needed
 	_, err = logging.Measure(HandleUser, threadCtx)
 	if err != nil {
@@ -56,22 +139,37 @@ func Setup(fn func(*MainContext), opts *options.Opts) (string, error) {
 		}
 	}
 
+	// Distribute users among scenario profiles
+	profiles := opts.Scenario.AssignProfiles(opts.Concurrency)
+
 	// Initialize all user thread contexts
 	for threadIndex := 0; threadIndex < opts.Concurrency; threadIndex++ {
-		logging.Logger.Info("Initiating thread %d", threadIndex)
+		logging.Logger.Info("Initiating thread %d with profile %s", threadIndex, profiles[threadIndex].Name)
+
+		var arrival *options.ScheduledArrival
+		if opts.ArrivalSchedule != nil {
+			arrival = &opts.ArrivalSchedule[threadIndex]
+		}
 
 		threadCtx := &MainContext{
-			ThreadsWG:        threadsWG,
-			ThreadIndex:      threadIndex,
-			Opts:             opts,
-			StageUsers:       &stageUsers,
-			Username:         "",
-			Namespace:        "",
+			ThreadsWG:   threadsWG,
+			ThreadIndex: threadIndex,
+			Opts:        opts,
+			Profile:     profiles[threadIndex],
+			Arrival:     arrival,
+			StageUsers:  &stageUsers,
+			Username:    "",
+			Namespace:   "",
 		}
 
 		MainContexts = append(MainContexts, threadCtx)
 	}
 
+	// In open model users are created when they arrive
+	if opts.ArrivalSchedule != nil && !opts.PurgeOnly {
+		return setupArrivals(fn, opts, threadsWG)
+	}
+
 	// Create all users (if necessary) and initialize their frameworks
 	for _, threadCtx := range MainContexts {
 		go initUserThread(threadCtx)
@@ -82,6 +180,9 @@ func Setup(fn func(*MainContext), opts *options.Opts) (string, error) {
 	// If we are supposed to only purge resources, now when frameworks are initialized, we are done
 	if opts.PurgeOnly {
 		logging.Logger.Info("Skipping rest of user journey as we were asked to just purge resources")
+		for _, threadCtx := range MainContexts {
+			threadCtx.TraceEnd()
+		}
 		return "", nil
 	}
 
@@ -105,23 +206,111 @@ func Setup(fn func(*MainContext), opts *options.Opts) (string, error) {
 	return "", nil
 }
 
+// Start user journey threads at their scheduled arrival times. Every user
+// is created and its repository forked only when it arrives.
+func setupArrivals(fn func(*MainContext), opts *options.Opts, threadsWG *sync.WaitGroup) (string, error) {
+	start := time.Now()
+
+	var phases []logging.Phase
+	for i, end := range opts.Scenario.Arrival.PhaseEnds() {
+		phases = append(phases, logging.Phase{Name: opts.Scenario.Arrival.Phases[i].Name, End: end})
+	}
+	logging.MeasurementsPhases(start, phases)
+
+	// Fork repositories one by one as GitHub do not allow more than 3 running forks in parallel anyway
+	forkMutex := &sync.Mutex{}
+
+	for _, threadCtx := range MainContexts {
+		go func(threadCtx *MainContext) {
+			select {
+			case <-time.After(time.Until(start.Add(threadCtx.Arrival.Offset))):
+			case <-journeyContext.Done():
+				logging.Logger.Info("User thread %d not started as journeys were stopped", threadCtx.ThreadIndex)
+				threadCtx.ThreadsWG.Done()
+				return
+			}
+			logging.Logger.Info("User thread %d arrived in phase %s", threadCtx.ThreadIndex, threadCtx.Arrival.Phase)
+			threadCtx.traceStart()
+
+			_, err := logging.Measure(HandleUser, threadCtx)
+			if err != nil {
+				logging.Logger.Error("Thread failed: %v", err)
+				threadCtx.TraceEnd()
+				threadCtx.ThreadsWG.Done()
+				return
+			}
+
+			forkMutex.Lock()
+			_, err = logging.Measure(HandleRepoForking, threadCtx)
+			forkMutex.Unlock()
+			if err != nil {
+				logging.Logger.Error("Thread failed: %v", err)
+				threadCtx.TraceEnd()
+				threadCtx.ThreadsWG.Done()
+				return
+			}
+
+			// Thread function marks thread as done
+			fn(threadCtx)
+		}(threadCtx)
+	}
+
+	threadsWG.Wait()
+
+	return "", nil
+}
+
 // Struct to hold data for thread to process each application
 type PerApplicationContext struct {
-	PerApplicationWG            *sync.WaitGroup
-	ApplicationIndex            int
-	Framework                   *framework.Framework
-	ParentContext               *MainContext
-	ApplicationName             string
-	IntegrationTestScenarioName string
-	PerComponentContexts        []*PerComponentContext
+	PerApplicationWG             *sync.WaitGroup
+	ApplicationIndex             int
+	Framework                    *framework.Framework
+	ParentContext                *MainContext
+	ApplicationName              string
+	IntegrationTestScenarioNames []string
+	ReleasePlanName              string // set up by first component of the application that gets released
+	releaseMutex                 sync.Mutex
+	traceSpan                    *logging.Span
+	stepContext                  stepContext // steps of the thread run with this context, see bindFramework
+	PerComponentContexts         []*PerComponentContext
+}
+
+// Return name of the user of the application
+func (ctx *PerApplicationContext) JourneyUser() string {
+	if ctx == nil {
+		return ""
+	}
+	return ctx.ParentContext.JourneyUser()
+}
+
+// Return phase user of the application arrived in
+func (ctx *PerApplicationContext) ArrivalPhase() string {
+	if ctx == nil {
+		return ""
+	}
+	return ctx.ParentContext.ArrivalPhase()
+}
+
+// Framework of the application thread, bound to its step context
+func (ctx *PerApplicationContext) threadFramework() *framework.Framework {
+	return ctx.Framework
+}
+
+func (ctx *PerApplicationContext) threadStepContext() *stepContext {
+	return &ctx.stepContext
+}
+
+// End span of application thread
+func (ctx *PerApplicationContext) TraceEnd() {
+	logging.TraceThreadEnd(ctx.traceSpan)
 }
 
 // Start all the threads to process all applications per user
 func PerApplicationSetup(fn func(*PerApplicationContext), parentContext *MainContext) (string, error) {
 	perApplicationWG := &sync.WaitGroup{}
-	perApplicationWG.Add(parentContext.Opts.ApplicationsCount)
+	perApplicationWG.Add(parentContext.Profile.ApplicationsCount)
 
-	for applicationIndex := 0; applicationIndex < parentContext.Opts.ApplicationsCount; applicationIndex++ {
+	for applicationIndex := 0; applicationIndex < parentContext.Profile.ApplicationsCount; applicationIndex++ {
 		logging.Logger.Info("Initiating per application thread %d-%d", parentContext.ThreadIndex, applicationIndex)
 
 		perApplicationCtx := &PerApplicationContext{
@@ -133,6 +322,14 @@ func PerApplicationSetup(fn func(*PerApplicationContext), parentContext *MainCon
 
 		parentContext.PerApplicationContexts = append(parentContext.PerApplicationContexts, perApplicationCtx)
 
+		perApplicationCtx.traceSpan = logging.TraceThreadStart(
+			"application",
+			fmt.Sprintf("user %d app %d", parentContext.ThreadIndex, applicationIndex),
+			parentContext,
+			map[string]string{"loadtest.application": perApplicationCtx.ApplicationName},
+			perApplicationCtx,
+		)
+
 		go fn(perApplicationCtx)
 	}
 
@@ -149,15 +346,48 @@ type PerComponentContext struct {
 	ParentContext      *PerApplicationContext
 	ComponentName      string
 	SnapshotName       string
+	ReleaseName        string
 	MergeRequestNumber int
+	traceSpan          *logging.Span
+	stepContext        stepContext // steps of the thread run with this context, see bindFramework
+}
+
+// Return name of the user of the component
+func (ctx *PerComponentContext) JourneyUser() string {
+	if ctx == nil {
+		return ""
+	}
+	return ctx.ParentContext.JourneyUser()
+}
+
+// Return phase user of the component arrived in
+func (ctx *PerComponentContext) ArrivalPhase() string {
+	if ctx == nil {
+		return ""
+	}
+	return ctx.ParentContext.ArrivalPhase()
+}
+
+// Framework of the component thread, bound to its step context
+func (ctx *PerComponentContext) threadFramework() *framework.Framework {
+	return ctx.Framework
+}
+
+func (ctx *PerComponentContext) threadStepContext() *stepContext {
+	return &ctx.stepContext
+}
+
+// End span of component thread
+func (ctx *PerComponentContext) TraceEnd() {
+	logging.TraceThreadEnd(ctx.traceSpan)
 }
 
 // Start all the threads to process all components per application
 func PerComponentSetup(fn func(*PerComponentContext), parentContext *PerApplicationContext) (string, error) {
 	perComponentWG := &sync.WaitGroup{}
-	perComponentWG.Add(parentContext.ParentContext.Opts.ComponentsCount)
+	perComponentWG.Add(parentContext.ParentContext.Profile.ComponentsCount)
 
-	for componentIndex := 0; componentIndex < parentContext.ParentContext.Opts.ComponentsCount; componentIndex++ {
+	for componentIndex := 0; componentIndex < parentContext.ParentContext.Profile.ComponentsCount; componentIndex++ {
 		logging.Logger.Info("Initiating per component thread %d-%d-%d", parentContext.ParentContext.ThreadIndex, parentContext.ApplicationIndex, componentIndex)
 
 		perComponentCtx := &PerComponentContext{
@@ -169,6 +399,14 @@ func PerComponentSetup(fn func(*PerComponentContext), parentContext *PerApplicat
 
 		parentContext.PerComponentContexts = append(parentContext.PerComponentContexts, perComponentCtx)
 
+		perComponentCtx.traceSpan = logging.TraceThreadStart(
+			"component",
+			fmt.Sprintf("user %d app %d comp %d", parentContext.ParentContext.ThreadIndex, parentContext.ApplicationIndex, componentIndex),
+			parentContext,
+			map[string]string{"loadtest.component": perComponentCtx.ComponentName},
+			perComponentCtx,
+		)
+
 		go fn(perComponentCtx)
 	}
 
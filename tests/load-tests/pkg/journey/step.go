@@ -0,0 +1,312 @@
+package journey
+
+import "context"
+import "fmt"
+import "sort"
+import "sync"
+import "time"
+
+import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
+import options "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/options"
+
+import framework "github.com/konflux-ci/e2e-tests/pkg/framework"
+
+// Step of user journey, run with context of the journey level it is registered on
+// (*MainContext, *PerApplicationContext or *PerComponentContext). Step is expected
+// to measure and report failures itself, steps created by NewStep do that. Step talks
+// to the cluster through given framework of the thread, which is bound to context of
+// the running step attempt (see stepContext), also when the step uses objects of parent
+// contexts.
+type Step[C any] interface {
+	Name() string
+	Run(ctx C, f *framework.Framework) error
+}
+
+// Step running plain handler function
+type handlerStep[C any] struct {
+	name    string
+	handler func(C, *framework.Framework) error
+}
+
+func (s *handlerStep[C]) Name() string {
+	return s.name
+}
+
+// Measurement is named after the handler, so it does not change when handler is ported to a step
+func (s *handlerStep[C]) Run(ctx C, f *framework.Framework) error {
+	_, err := logging.Measure(s.handler, ctx, f)
+	return err
+}
+
+// Create step with given name running given handler function
+func NewStep[C any](name string, handler func(C, *framework.Framework) error) Step[C] {
+	return &handlerStep[C]{name: name, handler: handler}
+}
+
+// Struct to hold steps available on one journey level
+type Registry[C any] struct {
+	mutex sync.Mutex
+	steps map[string]Step[C]
+}
+
+func newRegistry[C any]() *Registry[C] {
+	return &Registry[C]{steps: map[string]Step[C]{}}
+}
+
+// Register step, so profiles can use it by its name
+func (r *Registry[C]) Register(step Step[C]) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.steps[step.Name()]; ok {
+		return fmt.Errorf("Step %s is already registered", step.Name())
+	}
+	r.steps[step.Name()] = step
+	return nil
+}
+
+// Get step by its name
+func (r *Registry[C]) Get(name string) (Step[C], bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	step, ok := r.steps[name]
+	return step, ok
+}
+
+// Names of all registered steps, sorted
+func (r *Registry[C]) Names() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	names := []string{}
+	for name := range r.steps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Steps available on every journey level
+var UserSteps = newRegistry[*MainContext]()
+var ApplicationSteps = newRegistry[*PerApplicationContext]()
+var ComponentSteps = newRegistry[*PerComponentContext]()
+
+// Register built-in steps
+func init() {
+	builtins := []error{
+		UserSteps.Register(NewStep(options.StepPersistentVolumeClaims, HandlePersistentVolumeClaim)),
+		ApplicationSteps.Register(NewStep(options.StepApplication, HandleApplication)),
+		ApplicationSteps.Register(NewStep(options.StepIntegrationTestScenarios, HandleIntegrationTestScenario)),
+		ComponentSteps.Register(NewStep(options.StepComponent, HandleComponent)),
+		ComponentSteps.Register(NewStep(options.StepBuildPipelineRun, HandlePipelineRun)),
+		ComponentSteps.Register(NewStep(options.StepTestPipelineRun, HandleTest)),
+		ComponentSteps.Register(NewStep(options.StepRelease, HandleRelease)),
+	}
+	for _, err := range builtins {
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Check all the steps profiles use are registered on the level they are used on
+func ValidateSteps(opts *options.Opts) error {
+	for _, p := range opts.Scenario.Profiles {
+		err := validateLevelSteps(UserSteps, p.Journey.User, "user", p.Name)
+		if err == nil {
+			err = validateLevelSteps(ApplicationSteps, p.Journey.Application, "application", p.Name)
+		}
+		if err == nil {
+			err = validateLevelSteps(ComponentSteps, p.Journey.Component, "component", p.Name)
+		}
+		if err != nil {
+			return err
+		}
+
+		for name := range p.Journey.Policies {
+			if !p.HasStep(name) {
+				return fmt.Errorf("Profile %s defines policy for step %s it does not run", p.Name, name)
+			}
+		}
+
+		for _, name := range journeyOrder(p.Journey) {
+			for _, dependency := range options.StepDependencies(name) {
+				if stepPosition(p.Journey, dependency) > stepPosition(p.Journey, name) {
+					return fmt.Errorf("Profile %s runs step %s before step %s it depends on", p.Name, name, dependency)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Steps in order they run in: application steps run before components of the application
+// are processed and user steps once user is done with all its applications
+func journeyOrder(journey *options.Journey) []string {
+	order := append([]string{}, journey.Application...)
+	order = append(order, journey.Component...)
+	return append(order, journey.User...)
+}
+
+// Position of step in the journey order, -1 if journey does not run it
+func stepPosition(journey *options.Journey, name string) int {
+	for i, step := range journeyOrder(journey) {
+		if step == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func validateLevelSteps[C any](registry *Registry[C], names []string, level, profile string) error {
+	for _, name := range names {
+		if _, ok := registry.Get(name); !ok {
+			return fmt.Errorf("Profile %s uses unknown %s step %s, known %s steps are %v", profile, level, name, level, registry.Names())
+		}
+	}
+	return nil
+}
+
+// Run given steps one by one according to their policies, stop at first failed step
+// or when journeys were stopped
+func runSteps[C any](registry *Registry[C], names []string, journey *options.Journey, ctx C) error {
+	for _, name := range names {
+		if Stopped() {
+			return fmt.Errorf("Step %s not started as journeys were stopped", name)
+		}
+
+		step, ok := registry.Get(name)
+		if !ok {
+			return fmt.Errorf("Step %s is not registered", name)
+		}
+
+		err := runStep(step, journey.Policy(name), ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Context of step attempts of one journey thread. Framework of the thread is bound to it
+// once, so API calls and waits of the framework (also after it was refreshed on Stage)
+// follow context of the running step attempt, and journey context between attempts.
+// Thread runs its step attempts one at a time, so context does not change under a call.
+type stepContext struct {
+	mutex   sync.Mutex
+	attempt context.Context
+}
+
+func (c *stepContext) current() context.Context {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.attempt == nil {
+		return journeyContext
+	}
+	return c.attempt
+}
+
+func (c *stepContext) set(attempt context.Context) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.attempt = attempt
+}
+
+func (c *stepContext) Deadline() (time.Time, bool) {
+	return c.current().Deadline()
+}
+
+func (c *stepContext) Done() <-chan struct{} {
+	return c.current().Done()
+}
+
+func (c *stepContext) Err() error {
+	return c.current().Err()
+}
+
+func (c *stepContext) Value(key any) any {
+	return c.current().Value(key)
+}
+
+// Implemented by journey thread contexts, so steps run with framework of the thread
+type journeyThread interface {
+	threadFramework() *framework.Framework
+	threadStepContext() *stepContext
+}
+
+// Run step, retrying it when it fails if policy says so. Step that timed out is not retried.
+func runStep[C any](step Step[C], policy options.StepPolicy, ctx C) error {
+	for attempt := 0; ; attempt++ {
+		span := logging.TraceSpanStart("step", "step "+step.Name(), ctx, map[string]string{
+			"loadtest.step":    step.Name(),
+			"loadtest.attempt": fmt.Sprint(attempt + 1),
+		})
+		err, timedOut := runStepAttempt(step, policy.TimeoutDuration(), ctx)
+		logging.TraceSpanEnd(span, err)
+		if err == nil || timedOut || attempt >= policy.Retries || Stopped() {
+			return err
+		}
+
+		logging.Logger.Warning("Step %s failed (attempt %d of %d), retrying in %v: %v", step.Name(), attempt+1, policy.Retries+1, policy.RetryDelayDuration(), err)
+		select {
+		case <-time.After(policy.RetryDelayDuration()):
+		case <-journeyContext.Done():
+			return err
+		}
+	}
+}
+
+// Run one attempt of step with its own context derived from journey context, cancelled
+// when timeout (if any) expires. Returns step error and whether the step timed out.
+// Timed out step is cancelled and waited for, so it does not touch thread context
+// anymore once thread goes on.
+func runStepAttempt[C any](step Step[C], timeout time.Duration, ctx C) (error, bool) {
+	attemptCtx, cancel := context.WithCancel(journeyContext)
+	if timeout > 0 {
+		attemptCtx, cancel = context.WithTimeout(journeyContext, timeout)
+	}
+	defer cancel()
+
+	var f *framework.Framework
+	if thread, ok := any(ctx).(journeyThread); ok {
+		f = thread.threadFramework()
+		thread.threadStepContext().set(attemptCtx)
+		defer thread.threadStepContext().set(nil)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- step.Run(ctx, f)
+	}()
+
+	select {
+	case err := <-done:
+		return err, false
+	case <-attemptCtx.Done():
+		if Stopped() {
+			// Step sees journeys were stopped through its context, let it finish
+			return <-done, false
+		}
+		err := logging.Logger.Fail(90, "Step %s timed out after %v", step.Name(), timeout)
+		<-done
+		return err, true
+	}
+}
+
+// Run user level steps of the user profile
+func RunUserSteps(ctx *MainContext) error {
+	return runSteps(UserSteps, ctx.Profile.Journey.User, ctx.Profile.Journey, ctx)
+}
+
+// Run application level steps of the user profile
+func RunApplicationSteps(ctx *PerApplicationContext) error {
+	return runSteps(ApplicationSteps, ctx.ParentContext.Profile.Journey.Application, ctx.ParentContext.Profile.Journey, ctx)
+}
+
+// Run component level steps of the user profile
+func RunComponentSteps(ctx *PerComponentContext) error {
+	return runSteps(ComponentSteps, ctx.ParentContext.ParentContext.Profile.Journey.Component, ctx.ParentContext.ParentContext.Profile.Journey, ctx)
+}
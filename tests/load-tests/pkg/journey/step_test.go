@@ -0,0 +1,233 @@
+package journey
+
+import "context"
+import "fmt"
+import "testing"
+import "time"
+
+import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
+import options "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/options"
+
+import framework "github.com/konflux-ci/e2e-tests/pkg/framework"
+import has "github.com/konflux-ci/e2e-tests/pkg/clients/has"
+import common "github.com/konflux-ci/e2e-tests/pkg/clients/common"
+import tekton "github.com/konflux-ci/e2e-tests/pkg/clients/tekton"
+import release "github.com/konflux-ci/e2e-tests/pkg/clients/release"
+import integration "github.com/konflux-ci/e2e-tests/pkg/clients/integration"
+import imagecontroller "github.com/konflux-ci/e2e-tests/pkg/clients/imagecontroller"
+import kubeCl "github.com/konflux-ci/e2e-tests/pkg/clients/kubernetes"
+
+import assert "github.com/stretchr/testify/assert"
+
+// Thread context of test steps
+type testContext struct {
+	framework   *framework.Framework
+	stepContext stepContext
+	ran         []string
+}
+
+func (c *testContext) threadFramework() *framework.Framework {
+	return c.framework
+}
+
+func (c *testContext) threadStepContext() *stepContext {
+	return &c.stepContext
+}
+
+// Thread context with test framework bound to its step context
+func newTestContext() *testContext {
+	ctx := &testContext{framework: testFramework()}
+	bindFramework(ctx.framework, &ctx.stepContext)
+	return ctx
+}
+
+// Measurements and failures of steps are written to temporary directory
+func startMeasurements(t *testing.T) {
+	logging.MeasurementsStart(t.TempDir())
+	t.Cleanup(logging.MeasurementsStop)
+}
+
+// Step that is not measured, so it can be left running after test ends
+type unmeasuredStep[C any] struct {
+	name string
+	run  func(ctx C, f *framework.Framework) error
+}
+
+func (s *unmeasuredStep[C]) Name() string {
+	return s.name
+}
+
+func (s *unmeasuredStep[C]) Run(ctx C, f *framework.Framework) error {
+	return s.run(ctx, f)
+}
+
+func recordingStep(name string, err error) Step[*testContext] {
+	return NewStep(name, func(ctx *testContext, f *framework.Framework) error {
+		ctx.ran = append(ctx.ran, name)
+		return err
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	registry := newRegistry[*testContext]()
+
+	assert.NoError(t, registry.Register(recordingStep("second", nil)))
+	assert.NoError(t, registry.Register(recordingStep("first", nil)))
+	assert.EqualError(t, registry.Register(recordingStep("first", nil)), "Step first is already registered")
+
+	step, ok := registry.Get("first")
+	assert.True(t, ok)
+	assert.Equal(t, "first", step.Name())
+	_, ok = registry.Get("third")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"first", "second"}, registry.Names())
+}
+
+func TestRunSteps(t *testing.T) {
+	startMeasurements(t)
+	registry := newRegistry[*testContext]()
+	assert.NoError(t, registry.Register(recordingStep("first", nil)))
+	assert.NoError(t, registry.Register(recordingStep("failing", fmt.Errorf("failed"))))
+	assert.NoError(t, registry.Register(recordingStep("last", nil)))
+
+	ctx := &testContext{}
+	assert.NoError(t, runSteps(registry, []string{"last", "first"}, &options.Journey{}, ctx))
+	assert.Equal(t, []string{"last", "first"}, ctx.ran)
+
+	// Steps after failed one are not run
+	ctx = &testContext{}
+	assert.EqualError(t, runSteps(registry, []string{"first", "failing", "last"}, &options.Journey{}, ctx), "failed")
+	assert.Equal(t, []string{"first", "failing"}, ctx.ran)
+
+	assert.EqualError(t, runSteps(registry, []string{"unknown"}, &options.Journey{}, &testContext{}), "Step unknown is not registered")
+}
+
+func TestRunStepRetries(t *testing.T) {
+	startMeasurements(t)
+
+	attempts := 0
+	var contexts []context.Context
+	flaky := NewStep("flaky", func(ctx *testContext, f *framework.Framework) error {
+		attempts++
+		assert.NoError(t, f.AsKubeDeveloper.Context().Err())
+		contexts = append(contexts, ctx.stepContext.current())
+		if attempts < 3 {
+			return fmt.Errorf("attempt %d failed", attempts)
+		}
+		return nil
+	})
+
+	ctx := newTestContext()
+	assert.NoError(t, runStep(flaky, options.StepPolicy{Retries: 2}, ctx))
+	assert.Equal(t, 3, attempts)
+	// Every attempt runs with its own context that is cancelled once the attempt is over
+	assert.Len(t, contexts, 3)
+	assert.NotEqual(t, contexts[0], contexts[1])
+	for _, c := range contexts {
+		assert.Error(t, c.Err())
+	}
+	// Between attempts framework of the thread is bound to journey context again
+	assert.Equal(t, journeyContext, ctx.stepContext.current())
+
+	attempts = 0
+	assert.EqualError(t, runStep(flaky, options.StepPolicy{Retries: 1}, newTestContext()), "attempt 2 failed")
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRunStepTimeout(t *testing.T) {
+	startMeasurements(t)
+
+	attempts := 0
+	cancelled := make(chan error, 1)
+	hanging := &unmeasuredStep[*testContext]{name: "hanging", run: func(ctx *testContext, f *framework.Framework) error {
+		attempts++
+		attemptCtx := f.AsKubeDeveloper.Context()
+		<-attemptCtx.Done()
+		cancelled <- attemptCtx.Err()
+		return attemptCtx.Err()
+	}}
+
+	err := runStep(hanging, options.StepPolicy{Timeout: "50ms", Retries: 2}, newTestContext())
+
+	assert.EqualError(t, err, "FAIL(90): Step hanging timed out after 50ms")
+	// Step is cancelled through its context and waited for, but not retried
+	assert.Equal(t, context.DeadlineExceeded, <-cancelled)
+	assert.Equal(t, 1, attempts)
+}
+
+// Framework with controllers not connected to any cluster, enough to bind it to contexts
+func testFramework() *framework.Framework {
+	hub := &framework.ControllerHub{
+		HasController:         &has.HasController{CustomClient: &kubeCl.CustomClient{}},
+		CommonController:      &common.SuiteController{CustomClient: &kubeCl.CustomClient{}},
+		TektonController:      &tekton.TektonController{CustomClient: &kubeCl.CustomClient{}},
+		ReleaseController:     &release.ReleaseController{CustomClient: &kubeCl.CustomClient{}},
+		IntegrationController: &integration.IntegrationController{CustomClient: &kubeCl.CustomClient{}},
+		ImageController:       &imagecontroller.ImageController{CustomClient: &kubeCl.CustomClient{}},
+	}
+	return &framework.Framework{AsKubeAdmin: hub, AsKubeDeveloper: hub}
+}
+
+func TestRunStepTimeoutFramework(t *testing.T) {
+	startMeasurements(t)
+
+	journeyFramework := testFramework()
+	ctx := &PerComponentContext{Framework: journeyFramework}
+	bindFramework(ctx.Framework, &ctx.stepContext)
+	hanging := &unmeasuredStep[*PerComponentContext]{name: "hanging", run: func(ctx *PerComponentContext, f *framework.Framework) error {
+		// Step gets framework of the thread, so it sees its refreshes on Stage
+		assert.Same(t, ctx.Framework, f)
+		<-f.AsKubeDeveloper.Context().Done()
+		ctx.ReleaseName = "written after timeout"
+		return f.AsKubeAdmin.ReleaseController.Context().Err()
+	}}
+
+	err := runStep(hanging, options.StepPolicy{Timeout: "50ms"}, ctx)
+	assert.EqualError(t, err, "FAIL(90): Step hanging timed out after 50ms")
+
+	// Timed out step finished before thread goes on with its context
+	assert.Equal(t, "written after timeout", ctx.ReleaseName)
+
+	// Thread goes on with its framework not bound to cancelled attempt context, e.g. to clean up
+	assert.Same(t, journeyFramework, ctx.Framework)
+	assert.NoError(t, ctx.Framework.AsKubeDeveloper.Context().Err())
+	assert.NoError(t, ctx.Framework.AsKubeAdmin.HasController.Context().Err())
+}
+
+func TestRunStepWithoutTimeout(t *testing.T) {
+	startMeasurements(t)
+
+	slow := NewStep("slow", func(ctx *testContext, f *framework.Framework) error {
+		time.Sleep(10 * time.Millisecond)
+		return f.AsKubeDeveloper.Context().Err()
+	})
+
+	assert.NoError(t, runStep(slow, options.StepPolicy{}, newTestContext()))
+}
+
+func TestValidateSteps(t *testing.T) {
+	profile := func(journey *options.Journey) *options.Opts {
+		return &options.Opts{Scenario: &options.Scenario{Profiles: []options.Profile{{Name: "custom", Journey: journey}}}}
+	}
+
+	assert.NoError(t, ValidateSteps(profile(&options.Journey{
+		Application: []string{options.StepApplication, options.StepIntegrationTestScenarios},
+		Component:   []string{options.StepComponent, options.StepBuildPipelineRun, options.StepTestPipelineRun, options.StepRelease},
+	})))
+
+	assert.EqualError(t, ValidateSteps(profile(&options.Journey{
+		Application: []string{options.StepApplication},
+		Component:   []string{options.StepComponent, options.StepRelease, options.StepBuildPipelineRun},
+	})), "Profile custom runs step release before step build-pipeline-run it depends on")
+
+	assert.EqualError(t, ValidateSteps(profile(&options.Journey{
+		Application: []string{options.StepApplication},
+		Component:   []string{"unknown"},
+	})), "Profile custom uses unknown component step unknown, known component steps are [build-pipeline-run component release test-pipeline-run]")
+
+	assert.EqualError(t, ValidateSteps(profile(&options.Journey{
+		Application: []string{options.StepApplication},
+		Component:   []string{options.StepComponent},
+		Policies:    map[string]options.StepPolicy{options.StepRelease: {Retries: 1}},
+	})), "Profile custom defines policy for step release it does not run")
+}
@@ -0,0 +1,230 @@
+package journey
+
+import "context"
+import "fmt"
+import "sort"
+import "sync"
+import "time"
+
+import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
+
+import framework "github.com/konflux-ci/e2e-tests/pkg/framework"
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+import runtime "k8s.io/apimachinery/pkg/runtime"
+import schema "k8s.io/apimachinery/pkg/runtime/schema"
+import watch "k8s.io/apimachinery/pkg/watch"
+import cache "k8s.io/client-go/tools/cache"
+
+// Resources journey steps wait for
+var pipelineRunsResource = schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "pipelineruns"}
+var snapshotsResource = schema.GroupVersionResource{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "snapshots"}
+var componentsResource = schema.GroupVersionResource{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "components"}
+
+// How long to wait for informer to list resources initially
+const watcherSyncTimeout = time.Minute * 5
+
+// Shared informers of all the users namespaces
+var watchers = map[string]*namespaceWatcher{}
+var watchersMutex = &sync.Mutex{}
+
+// Struct to hold informers of one namespace and journey steps waiting for changes there
+type namespaceWatcher struct {
+	framework *framework.Framework // of the journey thread that started watching, refreshed on Stage
+	namespace string
+	stop      chan struct{}
+	mutex     sync.Mutex
+	informers map[schema.GroupVersionResource]cache.SharedIndexInformer
+	waiters   map[schema.GroupVersionResource]map[chan struct{}]bool
+}
+
+// Get informer for given resource in given namespace, starting it if needed. Framework
+// has to be the one of journey thread given to steps (not its copy), as watcher keeps
+// using it and only that one is refreshed on Stage.
+func getInformer(f *framework.Framework, namespace string, resource schema.GroupVersionResource) (*namespaceWatcher, cache.SharedIndexInformer, error) {
+	watchersMutex.Lock()
+	w, ok := watchers[namespace]
+	if !ok {
+		w = &namespaceWatcher{
+			framework: f,
+			namespace: namespace,
+			stop:      make(chan struct{}),
+			informers: map[schema.GroupVersionResource]cache.SharedIndexInformer{},
+			waiters:   map[schema.GroupVersionResource]map[chan struct{}]bool{},
+		}
+		watchers[namespace] = w
+	}
+	watchersMutex.Unlock()
+
+	w.mutex.Lock()
+	informer, ok := w.informers[resource]
+	if !ok {
+		informer = w.newInformer(resource)
+		w.informers[resource] = informer
+		w.waiters[resource] = map[chan struct{}]bool{}
+		go informer.Run(w.stop)
+	}
+	w.mutex.Unlock()
+
+	syncCtx, cancel := context.WithTimeout(context.Background(), watcherSyncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), informer.HasSynced) {
+		return nil, nil, fmt.Errorf("Informer for %s in namespace %s failed to sync", resource.Resource, namespace)
+	}
+
+	return w, informer, nil
+}
+
+// Create informer for given resource. Client is taken from framework of the journey
+// thread on every list and watch, as that framework is periodically refreshed on Stage.
+func (w *namespaceWatcher) newInformer(resource schema.GroupVersionResource) cache.SharedIndexInformer {
+	listWatch := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return w.framework.AsKubeDeveloper.CommonController.DynamicClient().Resource(resource).Namespace(w.namespace).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return w.framework.AsKubeDeveloper.CommonController.DynamicClient().Resource(resource).Namespace(w.namespace).Watch(context.Background(), options)
+		},
+	}
+
+	informer := cache.NewSharedIndexInformer(listWatch, &unstructured.Unstructured{}, 0, cache.Indexers{})
+
+	notify := func(obj interface{}) { w.notify(resource) }
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    notify,
+		UpdateFunc: func(oldObj, newObj interface{}) { w.notify(resource) },
+		DeleteFunc: notify,
+	})
+	if err != nil {
+		logging.Logger.Error("Failed to add event handler to %s informer in namespace %s: %v", resource.Resource, w.namespace, err)
+	}
+
+	return informer
+}
+
+// Wake up everybody waiting for change of given resource
+func (w *namespaceWatcher) notify(resource schema.GroupVersionResource) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for waiter := range w.waiters[resource] {
+		select {
+		case waiter <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (w *namespaceWatcher) subscribe(resource schema.GroupVersionResource) chan struct{} {
+	waiter := make(chan struct{}, 1)
+
+	w.mutex.Lock()
+	w.waiters[resource][waiter] = true
+	w.mutex.Unlock()
+
+	return waiter
+}
+
+func (w *namespaceWatcher) unsubscribe(resource schema.GroupVersionResource, waiter chan struct{}) {
+	w.mutex.Lock()
+	delete(w.waiters[resource], waiter)
+	w.mutex.Unlock()
+}
+
+// Stop informers of given namespace, e.g. when user journey is done
+func StopWatching(namespace string) {
+	watchersMutex.Lock()
+	defer watchersMutex.Unlock()
+
+	if w, ok := watchers[namespace]; ok {
+		close(w.stop)
+		delete(watchers, namespace)
+	}
+}
+
+// Wait until condition is met by first (sorted by name) object of given resource in given
+// namespace that matches. Condition is evaluated again whenever some object of the resource
+// in namespace changes. Returns the object that met the condition.
+func waitForObject(f *framework.Framework, namespace string, resource schema.GroupVersionResource, timeout time.Duration, match func(*unstructured.Unstructured) bool, condition func(*unstructured.Unstructured) (bool, error)) (*unstructured.Unstructured, error) {
+	deadline := time.After(timeout)
+	done := f.AsKubeDeveloper.Context().Done()
+
+	w, informer, err := getInformer(f, namespace, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	// Subscribe before first check so we do not miss change that happens in between
+	waiter := w.subscribe(resource)
+	defer w.unsubscribe(resource, waiter)
+
+	for {
+		var matching []*unstructured.Unstructured
+		for _, item := range informer.GetStore().List() {
+			obj, ok := item.(*unstructured.Unstructured)
+			if ok && match(obj) {
+				matching = append(matching, obj)
+			}
+		}
+
+		if len(matching) == 0 {
+			logging.Logger.Debug("No matching %s in namespace %s yet", resource.Resource, namespace)
+		} else {
+			sort.Slice(matching, func(i, j int) bool { return matching[i].GetName() < matching[j].GetName() })
+			done, err := condition(matching[0])
+			if err != nil {
+				return nil, err
+			}
+			if done {
+				return matching[0], nil
+			}
+		}
+
+		select {
+		case <-waiter:
+		case <-deadline:
+			return nil, fmt.Errorf("Timed out after %v waiting for %s in namespace %s", timeout, resource.Resource, namespace)
+		case <-done:
+			return nil, fmt.Errorf("Stopped waiting for %s in namespace %s: %v", resource.Resource, namespace, f.AsKubeDeveloper.Context().Err())
+		}
+	}
+}
+
+// Match objects having all given labels
+func matchLabels(labels map[string]string) func(*unstructured.Unstructured) bool {
+	return func(obj *unstructured.Unstructured) bool {
+		objLabels := obj.GetLabels()
+		for k, v := range labels {
+			if objLabels[k] != v {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Match object with given name
+func matchName(name string) func(*unstructured.Unstructured) bool {
+	return func(obj *unstructured.Unstructured) bool {
+		return obj.GetName() == name
+	}
+}
+
+// Wait for typed object, see waitForObject
+func waitForTypedObject[T any](f *framework.Framework, namespace string, resource schema.GroupVersionResource, timeout time.Duration, match func(*unstructured.Unstructured) bool, condition func(*T) (bool, error)) (*T, error) {
+	var typed *T
+
+	_, err := waitForObject(f, namespace, resource, timeout, match, func(obj *unstructured.Unstructured) (bool, error) {
+		typed = new(T)
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), typed)
+		if err != nil {
+			return false, fmt.Errorf("Unable to convert %s %s in namespace %s: %v", resource.Resource, obj.GetName(), namespace, err)
+		}
+		return condition(typed)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return typed, nil
+}
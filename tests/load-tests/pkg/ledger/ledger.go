@@ -0,0 +1,199 @@
+package ledger
+
+import "bufio"
+import "encoding/json"
+import "fmt"
+import "os"
+import "path/filepath"
+import "sync"
+import "time"
+
+// Kinds of resources journey creates
+const KindNamespace = "Namespace"
+const KindApplication = "Application"
+const KindIntegrationTestScenario = "IntegrationTestScenario"
+const KindComponent = "Component"
+const KindImageRepository = "ImageRepository"
+const KindGithubRepository = "GithubRepository"
+const KindGitBranch = "GitBranch"
+const KindGitlabWebhook = "GitlabWebhook"
+const KindServiceAccount = "ServiceAccount"
+const KindRoleBinding = "RoleBinding"
+const KindEnterpriseContractPolicy = "EnterpriseContractPolicy"
+const KindReleasePlan = "ReleasePlan"
+const KindReleasePlanAdmission = "ReleasePlanAdmission"
+const KindRelease = "Release"
+
+// Ledger entry actions
+const ActionCreated = "created"
+const ActionPurged = "purged"
+
+// Represents resource created (or purged) by the load test. Repo is set for resources
+// living in a git repository, Name of GitlabWebhook is the host its URL contains.
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Action    string    `json:"action"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace,omitempty"`
+	Repo      string    `json:"repo,omitempty"`
+	Name      string    `json:"name"`
+	User      string    `json:"user"`
+	Thread    int       `json:"thread"`
+}
+
+func (e *Entry) String() string {
+	switch {
+	case e.Namespace != "":
+		return fmt.Sprintf("%s %s/%s", e.Kind, e.Namespace, e.Name)
+	case e.Repo != "":
+		return fmt.Sprintf("%s %s in %s", e.Kind, e.Name, e.Repo)
+	default:
+		return fmt.Sprintf("%s %s", e.Kind, e.Name)
+	}
+}
+
+// Identifies resource, so purged entry matches entry of its creation
+func (e *Entry) key() string {
+	return fmt.Sprintf("%s/%s/%s/%s", e.Kind, e.Namespace, e.Repo, e.Name)
+}
+
+var ledgerFile *os.File
+var ledgerMutex sync.Mutex
+
+// Open ledger file for appending entries
+func Open(path string) error {
+	var err error
+	ledgerFile, err = os.OpenFile(filepath.Clean(path), os.O_APPEND|os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("Error opening ledger file %s: %v", path, err)
+	}
+
+	// Do not append entries to a line cut short by a crash
+	info, err := ledgerFile.Stat()
+	if err != nil {
+		return fmt.Errorf("Error checking ledger file %s: %v", path, err)
+	}
+	if info.Size() > 0 {
+		last := make([]byte, 1)
+		_, err = ledgerFile.ReadAt(last, info.Size()-1)
+		if err != nil {
+			return fmt.Errorf("Error reading ledger file %s: %v", path, err)
+		}
+		if last[0] != '\n' {
+			_, err = ledgerFile.Write([]byte("\n"))
+			if err != nil {
+				return fmt.Errorf("Error writing ledger file %s: %v", path, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Close ledger file
+func Close() error {
+	ledgerMutex.Lock()
+	defer ledgerMutex.Unlock()
+
+	if ledgerFile == nil {
+		return nil
+	}
+	err := ledgerFile.Close()
+	ledgerFile = nil
+	return err
+}
+
+// Append entry to the ledger. Every entry is synced to disk right away so ledger
+// survives crash of the test.
+func Record(e Entry) error {
+	ledgerMutex.Lock()
+	defer ledgerMutex.Unlock()
+
+	if ledgerFile == nil {
+		return nil
+	}
+
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("Error marshalling ledger entry: %v", err)
+	}
+
+	_, err = ledgerFile.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("Error writing ledger entry: %v", err)
+	}
+
+	return ledgerFile.Sync()
+}
+
+// Record that resource was created
+func Created(e Entry) error {
+	e.Action = ActionCreated
+	return Record(e)
+}
+
+// Record that resource was purged
+func Purged(e Entry) error {
+	e.Action = ActionPurged
+	e.Timestamp = time.Time{}
+	return Record(e)
+}
+
+// Load entries of resources created and not purged yet, in order in which they were created.
+// Also returns number of lines that could not be parsed.
+func Pending(path string) ([]Entry, int, error) {
+	skipped := 0
+
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, 0, fmt.Errorf("Error opening ledger file %s: %v", path, err)
+	}
+	defer file.Close()
+
+	var created []Entry
+	purged := map[string]bool{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		// Line cut short by a crash is skipped
+		var e Entry
+		err = json.Unmarshal(scanner.Bytes(), &e)
+		if err != nil {
+			skipped++
+			continue
+		}
+
+		switch e.Action {
+		case ActionCreated:
+			delete(purged, e.key())
+			created = append(created, e)
+		case ActionPurged:
+			purged[e.key()] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("Error reading ledger file %s: %v", path, err)
+	}
+
+	// Resource might have been created again after it was purged
+	pending := []Entry{}
+	seen := map[string]bool{}
+	for i := len(created) - 1; i >= 0; i-- {
+		key := created[i].key()
+		if purged[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		pending = append([]Entry{created[i]}, pending...)
+	}
+
+	return pending, skipped, nil
+}
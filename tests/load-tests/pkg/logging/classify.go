@@ -0,0 +1,184 @@
+package logging
+
+import "encoding/json"
+import "fmt"
+import "os"
+import "regexp"
+import "sort"
+import "strconv"
+import "sync"
+import "time"
+
+// How many sample messages to keep per category
+const categorySamples = 5
+
+// Represents class of errors journey steps hit, Fatal ones make the test stop when --fail-fast is used
+type ErrorCategory struct {
+	Name     string
+	Fatal    bool
+	Patterns []*regexp.Regexp
+}
+
+// Categories are matched in this order, first matching one wins
+var ErrorCategories = []ErrorCategory{
+	{
+		Name:  "quota-exceeded",
+		Fatal: true,
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)exceeded quota`),
+			regexp.MustCompile(`(?i)quota exceeded`),
+		},
+	},
+	{
+		Name:  "unauthorized",
+		Fatal: true,
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)\bunauthorized\b`),
+			regexp.MustCompile(`(?i)token (is )?expired`),
+			regexp.MustCompile(`(?i)bad credentials`),
+		},
+	},
+	{
+		Name:  "git-rate-limit",
+		Fatal: true,
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)\brate limit\b`),
+			regexp.MustCompile(`(?i)abuse detection`),
+			regexp.MustCompile(`(?i)X-RateLimit-Remaining:? 0\b`),
+			regexp.MustCompile(`429 Too Many Requests`),
+		},
+	},
+	{
+		Name: "api-throttling",
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)too many requests`),
+			regexp.MustCompile(`(?i)client rate limiter`),
+			regexp.MustCompile(`(?i)throttl`),
+			regexp.MustCompile(`(?i)the server is currently unable to handle the request`),
+		},
+	},
+	{
+		Name: "pipelinerun-couldnt-get-task",
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`CouldntGetTask`),
+			regexp.MustCompile(`CouldntGetPipeline`),
+		},
+	},
+	{
+		Name: "image-pull",
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`ImagePullBackOff`),
+			regexp.MustCompile(`ErrImagePull`),
+			regexp.MustCompile(`(?i)failed to pull image`),
+			regexp.MustCompile(`(?i)manifest unknown`),
+		},
+	},
+	{
+		Name: "cancelled",
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`context canceled`),
+			regexp.MustCompile(`(?i)journeys were stopped`),
+			regexp.MustCompile(`(?i)stopped waiting for`),
+		},
+	},
+	{
+		Name: "timeout",
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)timed out`),
+			regexp.MustCompile(`(?i)context deadline exceeded`),
+			regexp.MustCompile(`(?i)\btimeout\b`),
+		},
+	},
+}
+
+// Category of errors not matching any other category
+const UnknownErrorCategory = "unknown"
+
+// Statistics of errors in one category
+type CategoryStats struct {
+	Fatal   bool           `json:"fatal"`
+	Count   int            `json:"count"`
+	First   time.Time      `json:"first"`
+	Last    time.Time      `json:"last"`
+	Codes   map[string]int `json:"codes"` // error code identifies the step, e.g. timeouts per step
+	Samples []string       `json:"samples"`
+}
+
+// Statistics of all the errors classified so far
+type ErrorsReport struct {
+	Total      int                       `json:"total"`
+	Categories map[string]*CategoryStats `json:"categories"`
+}
+
+var errorsReport = ErrorsReport{Categories: map[string]*CategoryStats{}}
+var errorsReportMutex sync.Mutex
+
+// Find category of error message, returns unknown category if nothing matches
+func ClassifyError(message string) *ErrorCategory {
+	for i := range ErrorCategories {
+		for _, pattern := range ErrorCategories[i].Patterns {
+			if pattern.MatchString(message) {
+				return &ErrorCategories[i]
+			}
+		}
+	}
+	return &ErrorCategory{Name: UnknownErrorCategory}
+}
+
+// Count classified error
+func recordClassifiedError(category *ErrorCategory, code int, message string, when time.Time) {
+	errorsReportMutex.Lock()
+	defer errorsReportMutex.Unlock()
+
+	stats, ok := errorsReport.Categories[category.Name]
+	if !ok {
+		stats = &CategoryStats{Fatal: category.Fatal, First: when, Codes: map[string]int{}, Samples: []string{}}
+		errorsReport.Categories[category.Name] = stats
+	}
+
+	stats.Count++
+	stats.Last = when
+	stats.Codes[strconv.Itoa(code)]++
+	if len(stats.Samples) < categorySamples {
+		stats.Samples = append(stats.Samples, message)
+	}
+	errorsReport.Total++
+}
+
+// Get copy of errors report as of now
+func GetErrorsReport() ErrorsReport {
+	errorsReportMutex.Lock()
+	defer errorsReportMutex.Unlock()
+
+	report := ErrorsReport{Total: errorsReport.Total, Categories: map[string]*CategoryStats{}}
+	for name, stats := range errorsReport.Categories {
+		copied := *stats
+		report.Categories[name] = &copied
+	}
+	return report
+}
+
+// Write errors report as JSON
+func writeErrorsReport(path string) error {
+	report := GetErrorsReport()
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Error marshalling errors report: %v", err)
+	}
+	err = os.WriteFile(path, data, 0600)
+	if err != nil {
+		return fmt.Errorf("Error writing errors report to %s: %v", path, err)
+	}
+
+	names := []string{}
+	for name := range report.Categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		Logger.Info("Errors in category %s: %d", name, report.Categories[name].Count)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package logging
+
+import "testing"
+
+import assert "github.com/stretchr/testify/assert"
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		message  string
+		category string
+	}{
+		{"pods \"x\" is forbidden: exceeded quota: compute-resources", "quota-exceeded"},
+		{"Unauthorized", "unauthorized"},
+		{"GET https://api.github.com/repos/o/r: 401 Bad credentials []", "unauthorized"},
+		{"GET https://api.github.com/repos/o/r: 403 API rate limit exceeded for installation ID 1. []", "git-rate-limit"},
+		{"You have exceeded a secondary rate limit", "git-rate-limit"},
+		{"POST https://gitlab.com/api/v4/projects: 429 Too Many Requests", "git-rate-limit"},
+		{"failed to create fork: unexpected status code: 403 (X-RateLimit-Remaining: 0)", "git-rate-limit"},
+		// Authorization errors are 403 as well, but they are not rate limiting
+		{"failed to create fork: unexpected status code: 403", UnknownErrorCategory},
+		{"GET https://api.github.com/repos/o/r: 403 Forbidden []", UnknownErrorCategory},
+		{"GET https://api.github.com/orgs/o/repos: 403 Resource protected by organization SAML enforcement. []", UnknownErrorCategory},
+		{"the server has received too many requests and has asked us to try again later", "api-throttling"},
+		{"client rate limiter Wait returned an error: context deadline exceeded", "api-throttling"},
+		// Repository or issue numbers containing 403 or 429 are not rate limiting
+		{"github repository o/r-403 not found", UnknownErrorCategory},
+		{"gitlab merge request 4290 has no pipeline", UnknownErrorCategory},
+		{"PipelineRun failed: CouldntGetTask", "pipelinerun-couldnt-get-task"},
+		{"Back-off pulling image: ImagePullBackOff", "image-pull"},
+		{"FAIL(90): Step build-pipeline-run timed out after 10m", "timeout"},
+		{"journeys were stopped", "cancelled"},
+		{"something else", UnknownErrorCategory},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.category, ClassifyError(tt.message).Name, tt.message)
+	}
+}
+
+func TestFailFast(t *testing.T) {
+	MeasurementsStart(t.TempDir())
+	t.Cleanup(MeasurementsStop)
+
+	stops := 0
+	l := &logger{Level: FATAL, FailFast: true, Stop: func() { stops++ }}
+
+	// Failures in non fatal categories do not stop the test
+	assert.EqualError(t, l.Fail(10, "Step %s timed out", "x"), "FAIL(10): Step x timed out")
+	assert.Equal(t, 0, stops)
+	assert.Equal(t, "", l.FailedFast())
+
+	// First fatal failure stops user journeys and the test continues so results are written
+	assert.Error(t, l.Fail(20, "exceeded quota"))
+	assert.Error(t, l.Fail(30, "Unauthorized"))
+	assert.Equal(t, 1, stops)
+	assert.Equal(t, "failure in fatal error category quota-exceeded: FAIL(20): exceeded quota", l.FailedFast())
+}
@@ -1,6 +1,7 @@
 package logging
 
 import "fmt"
+import "sync"
 import "time"
 
 import klog "k8s.io/klog/v2"
@@ -16,8 +17,12 @@ var Logger = logger{}
 
 // Logger setup
 type logger struct {
-	Level    int  // 0 = trace, 1 = debug, 2 = info, 3 = warning, 4 = error, 5 = fatal
-	FailFast bool // Should even errors be fatal?
+	Level    int    // 0 = trace, 1 = debug, 2 = info, 3 = warning, 4 = error, 5 = fatal
+	FailFast bool   // Should failures in fatal error categories stop the test?
+	Stop     func() // Called to stop user journeys on first failure in fatal error category when failing fast
+
+	stopMutex  sync.Mutex
+	stopReason string
 }
 
 func (l *logger) Trace(msg string, params ...interface{}) {
@@ -46,11 +51,7 @@ func (l *logger) Warning(msg string, params ...interface{}) {
 
 func (l *logger) Error(msg string, params ...interface{}) {
 	if l.Level <= ERROR {
-		if l.FailFast {
-			klog.Fatalf("ERROR(fatal)"+msg, params...)
-		} else {
-			klog.Errorf("ERROR "+msg, params...)
-		}
+		klog.Errorf("ERROR "+msg, params...)
 	}
 }
 
@@ -60,15 +61,47 @@ func (l *logger) Fatal(msg string, params ...interface{}) {
 	}
 }
 
-// Log test failure with error code to CSV file so we can compile a statistic later
+// Log test failure with error code and its category to CSV file so we can compile a statistic later.
+// With fail fast, failure in fatal category stops the test.
 func (l *logger) Fail(errCode int, msg string, params ...interface{}) error {
 	errorMessage := fmt.Sprintf("FAIL(%d): %s", errCode, msg)
-	klog.Infof(errorMessage, params...)
+	message := fmt.Sprintf(errorMessage, params...)
+	category := ClassifyError(message)
+	klog.Infof("%s [%s]", message, category.Name)
 	data := ErrorEntry{
 		Timestamp: time.Now(),
 		Code:      errCode,
-		Message:   fmt.Sprintf(errorMessage, params...),
+		Message:   message,
+		Category:  category.Name,
 	}
+	recordClassifiedError(category, errCode, message, data.Timestamp)
 	errorsQueue <- data
+	observeFailure(errCode, category.Name)
+	if l.FailFast && category.Fatal {
+		l.failFast(category, message)
+	}
 	return fmt.Errorf(errorMessage, params...)
 }
+
+// Stop user journeys on first failure in fatal error category. Test is not exited right
+// away so resources are purged and measurements and errors report are written.
+func (l *logger) failFast(category *ErrorCategory, message string) {
+	l.stopMutex.Lock()
+	defer l.stopMutex.Unlock()
+
+	if l.stopReason != "" {
+		return
+	}
+	l.stopReason = fmt.Sprintf("failure in fatal error category %s: %s", category.Name, message)
+	l.Error("Stopping user journeys after %s", l.stopReason)
+	if l.Stop != nil {
+		l.Stop()
+	}
+}
+
+// Return reason why user journeys were stopped by fail fast, empty if they were not
+func (l *logger) FailedFast() string {
+	l.stopMutex.Lock()
+	defer l.stopMutex.Unlock()
+	return l.stopReason
+}
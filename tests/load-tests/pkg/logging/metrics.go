@@ -0,0 +1,128 @@
+package logging
+
+import "context"
+import "errors"
+import "net/http"
+import "strconv"
+import "strings"
+import "time"
+
+import prometheus "github.com/prometheus/client_golang/prometheus"
+import promhttp "github.com/prometheus/client_golang/prometheus/promhttp"
+import push "github.com/prometheus/client_golang/prometheus/push"
+
+// Registry with live metrics of running load test
+var metricsRegistry = prometheus.NewRegistry()
+
+var measurementDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "loadtest_measurement_duration_seconds",
+		Help:    "Duration of measured functions",
+		Buckets: prometheus.ExponentialBuckets(0.1, 2, 16), // 0.1s to ~55m
+	},
+	[]string{"metric", "result", "phase"},
+)
+
+var failuresTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "loadtest_failures_total",
+		Help: "Number of failures by error code and category",
+	},
+	[]string{"code", "category"},
+)
+
+var activeThreads = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "loadtest_active_threads",
+		Help: "Number of running threads per journey level",
+	},
+	[]string{"level"},
+)
+
+var metricsServer *http.Server
+var metricsPusher *push.Pusher
+var metricsPushStop chan struct{}
+
+func init() {
+	metricsRegistry.MustRegister(measurementDuration, failuresTotal, activeThreads)
+}
+
+// Start serving metrics on given address (if not empty) and pushing them to given Pushgateway (if not empty)
+func MetricsStart(listen, pushURL, pushJob string, pushInterval time.Duration) {
+	if listen != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{EnableOpenMetrics: true}))
+		metricsServer = &http.Server{Addr: listen, Handler: mux, ReadHeaderTimeout: time.Second * 10}
+		go func() {
+			err := metricsServer.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				Logger.Error("Metrics endpoint failed: %v", err)
+			}
+		}()
+		Logger.Info("Serving metrics on %s/metrics", listen)
+	}
+
+	if pushURL != "" {
+		metricsPusher = push.New(pushURL, pushJob).Gatherer(metricsRegistry)
+		metricsPushStop = make(chan struct{})
+		go func() {
+			ticker := time.NewTicker(pushInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					metricsPush()
+				case <-metricsPushStop:
+					return
+				}
+			}
+		}()
+		Logger.Info("Pushing metrics to %s every %s", pushURL, pushInterval)
+	}
+}
+
+// Push final values and stop serving metrics
+func MetricsStop() {
+	if metricsPusher != nil {
+		close(metricsPushStop)
+		metricsPush()
+	}
+
+	if metricsServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+		err := metricsServer.Shutdown(ctx)
+		if err != nil {
+			Logger.Error("Failed to stop metrics endpoint: %v", err)
+		}
+	}
+}
+
+func metricsPush() {
+	err := metricsPusher.Push()
+	if err != nil {
+		Logger.Warning("Failed to push metrics: %v", err)
+	}
+}
+
+// Count running thread of given journey level (user, application, component), call returned function when thread ends
+func TrackThread(level string) func() {
+	activeThreads.WithLabelValues(level).Inc()
+	return func() {
+		activeThreads.WithLabelValues(level).Dec()
+	}
+}
+
+// Record measurement in live metrics, metric is function name without package path
+func observeMeasurement(metric string, elapsed time.Duration, err error, phase string) {
+	result := "pass"
+	if err != nil {
+		result = "fail"
+	}
+	measurementDuration.WithLabelValues(metric[strings.LastIndex(metric, "/")+1:], result, phase).Observe(elapsed.Seconds())
+}
+
+// Record failure in live metrics
+func observeFailure(code int, category string) {
+	failuresTotal.WithLabelValues(strconv.Itoa(code), category).Inc()
+}
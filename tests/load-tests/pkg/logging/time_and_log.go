@@ -16,11 +16,21 @@ var errorsQueue chan ErrorEntry // chanel to send failures to
 
 var measurementsOutput string // path to CSV where to save measurements
 var errorsOutput string // path to CSV where to save measurements
+var errorsReportOutput string // path to JSON where to save classified errors
 
 var writerWaitGroup sync.WaitGroup
 
 var batchSize int // when we accumulate this many of records, we dump them to CSV (this is to batch writest to the file, possibly make it faster)
 
+var phasesStart time.Time // when first phase started
+var phases []Phase // phases of the test measurements are tagged with
+
+// Represents phase of the test, e.g. ramp-up of arrival rate, with its end relative to the start of the test
+type Phase struct {
+	Name string
+	End  time.Duration
+}
+
 // Represents the data about measurement we want to store to CSV
 type MeasurementEntry struct {
 	Timestamp  time.Time
@@ -28,11 +38,13 @@ type MeasurementEntry struct {
 	Duration   time.Duration
 	Parameters string
 	Error      error
+	Phase      string
+	User       string
 }
 
 // Helper function to convert struct to slice of string which is needed when converting to CSV
 func (e *MeasurementEntry) GetSliceOfStrings() []string {
-	return []string{e.Timestamp.Format(time.RFC3339Nano), e.Metric, fmt.Sprintf("%f", e.Duration.Seconds()), e.Parameters, fmt.Sprintf("%v", e.Error)}
+	return []string{e.Timestamp.Format(time.RFC3339Nano), e.Metric, fmt.Sprintf("%f", e.Duration.Seconds()), e.Parameters, fmt.Sprintf("%v", e.Error), e.Phase, e.User}
 }
 
 // Represents the data about failure we want to store to CSV
@@ -40,11 +52,12 @@ type ErrorEntry struct {
 	Timestamp time.Time
 	Code      int
 	Message   string
+	Category  string
 }
 
 // Helper function to convert struct to slice of string which is needed when converting to CSV
 func (e *ErrorEntry) GetSliceOfStrings() []string {
-	return []string{e.Timestamp.Format(time.RFC3339Nano), fmt.Sprintf("%d", e.Code), e.Message}
+	return []string{e.Timestamp.Format(time.RFC3339Nano), fmt.Sprintf("%d", e.Code), e.Message, e.Category}
 }
 
 
@@ -60,14 +73,57 @@ func MeasurementsStart(directory string) {
 
 	errorsQueue = make(chan ErrorEntry)
 	errorsOutput = directory + "/load-test-errors.csv"
+	errorsReportOutput = directory + "/load-test-errors.json"
 	go errorsWriter()
 }
 
+// Set phases measurements are tagged with, needs to be called before measured threads are started
+func MeasurementsPhases(start time.Time, p []Phase) {
+	phasesStart = start
+	phases = p
+}
+
+// Return name of the phase given time belongs to, "drain" after the last phase
+// ends and empty string if there are no phases or the first one did not start yet
+func phaseAt(when time.Time) string {
+	if len(phases) == 0 || when.Before(phasesStart) {
+		return ""
+	}
+	for _, p := range phases {
+		if when.Sub(phasesStart) < p.End {
+			return p.Name
+		}
+	}
+	return "drain"
+}
+
+// Implemented by contexts of user journey threads, so measurements are tagged
+// with user they belong to and phase the user arrived in
+type JourneyThread interface {
+	JourneyUser() string // empty until the user is created
+	ArrivalPhase() string
+}
+
+// Return journey thread among parameters of measured function, nil if there is none
+func journeyThread(params []interface{}) JourneyThread {
+	for _, param := range params {
+		if thread, ok := param.(JourneyThread); ok {
+			return thread
+		}
+	}
+	return nil
+}
+
 // Close channels and wait to ensure any remaining records are written to CSV
 func MeasurementsStop() {
 	close(measurementsQueue)
 	close(errorsQueue)
 	writerWaitGroup.Wait()
+
+	err := writeErrorsReport(errorsReportOutput)
+	if err != nil {
+		Logger.Error("Failed to write errors report: %v", err)
+	}
 }
 
 // Append slice to a CSV file
@@ -200,11 +256,15 @@ func Measure(fn interface{}, params ...interface{}) (interface{}, error) {
 	// Get function name
 	funcName := runtime.FuncForPC(funcValue.Pointer()).Name()
 
+	// Record span on the track of journey thread given parameters belong to
+	span := TraceSpanStart("measurement", funcName[strings.LastIndex(funcName, "/")+1:], traceKey(params), map[string]string{"code.function": funcName})
+
 	startTime := time.Now()
 
 	defer func() {
 		elapsed := time.Since(startTime)
-		LogMeasurement(funcName, paramsStorable, elapsed, fmt.Sprintf("%+v", resultInterValue), errInterValue)
+		TraceSpanEnd(span, errInterValue)
+		LogThreadMeasurement(funcName, paramsStorable, elapsed, fmt.Sprintf("%+v", resultInterValue), errInterValue, journeyThread(params))
 	}()
 
 	// Call the function with provided arguments
@@ -223,8 +283,14 @@ func Measure(fn interface{}, params ...interface{}) (interface{}, error) {
 	return nil, errInterValue
 }
 
-// Store given measurement
+// Store given measurement tagged with phase it was taken in
 func LogMeasurement(metric string, params map[string]string, elapsed time.Duration, result string, err error) {
+	LogThreadMeasurement(metric, params, elapsed, result, err, nil)
+}
+
+// Store given measurement of user journey thread, tagged with its user and phase the user arrived in.
+// Without thread, measurement is tagged with phase it was taken in.
+func LogThreadMeasurement(metric string, params map[string]string, elapsed time.Duration, result string, err error, thread JourneyThread) {
 	// Extract parameter keys into a slice so we can sort them
 	var paramsKeys []string
 	for k := range params {
@@ -253,5 +319,12 @@ func LogMeasurement(metric string, params map[string]string, elapsed time.Durati
 		Parameters: params_string,
 		Error:      err,
 	}
+	if thread != nil {
+		data.Phase = thread.ArrivalPhase()
+		data.User = thread.JourneyUser()
+	} else {
+		data.Phase = phaseAt(data.Timestamp)
+	}
+	observeMeasurement(metric, elapsed, err, data.Phase)
 	measurementsQueue <- data
 }
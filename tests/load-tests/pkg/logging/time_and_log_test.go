@@ -0,0 +1,52 @@
+package logging
+
+import "testing"
+import "time"
+
+import assert "github.com/stretchr/testify/assert"
+
+type testThread struct {
+	user  string
+	phase string
+}
+
+func (t *testThread) JourneyUser() string {
+	return t.user
+}
+
+func (t *testThread) ArrivalPhase() string {
+	return t.phase
+}
+
+func TestLogThreadMeasurement(t *testing.T) {
+	MeasurementsPhases(time.Now().Add(-90*time.Second), []Phase{{Name: "ramp-up", End: time.Minute}, {Name: "steady", End: 2 * time.Minute}})
+	measurementsQueue = make(chan MeasurementEntry, 2)
+	t.Cleanup(func() {
+		MeasurementsPhases(time.Time{}, nil)
+		measurementsQueue = nil
+	})
+
+	// Measurement of a user is tagged with the user and phase it arrived in, even when taken later
+	thread := journeyThread([]interface{}{"x", &testThread{user: "user-1", phase: "ramp-up"}})
+	LogThreadMeasurement("github.com/x.handle", nil, time.Second, "", nil, thread)
+	entry := <-measurementsQueue
+	assert.Equal(t, "user-1", entry.User)
+	assert.Equal(t, "ramp-up", entry.Phase)
+
+	// Other measurements are tagged with phase they were taken in
+	LogMeasurement("github.com/x.collect", nil, time.Second, "", nil)
+	entry = <-measurementsQueue
+	assert.Equal(t, "", entry.User)
+	assert.Equal(t, "steady", entry.Phase)
+	assert.Nil(t, journeyThread([]interface{}{"x"}))
+}
+
+func TestPhaseAt(t *testing.T) {
+	start := time.Now()
+	MeasurementsPhases(start, []Phase{{Name: "ramp-up", End: time.Minute}})
+	t.Cleanup(func() { MeasurementsPhases(time.Time{}, nil) })
+
+	assert.Equal(t, "", phaseAt(start.Add(-time.Second)))
+	assert.Equal(t, "ramp-up", phaseAt(start.Add(30*time.Second)))
+	assert.Equal(t, "drain", phaseAt(start.Add(time.Minute)))
+}
@@ -0,0 +1,358 @@
+package logging
+
+import "crypto/rand"
+import "encoding/hex"
+import "encoding/json"
+import "fmt"
+import "os"
+import "reflect"
+import "sort"
+import "strconv"
+import "sync"
+import "time"
+
+// Represents one span of the trace: journey thread, journey step or measured function call
+type Span struct {
+	Kind       string // thread, step or measurement
+	ID         string
+	ParentID   string
+	Name       string
+	Start      time.Time
+	End        time.Time
+	Attributes map[string]string
+	Error      string
+	track      *track
+	parent     *Span
+}
+
+// Spans of one journey thread (user, application or component). Spans on one track are
+// nested, so measured function called from another measured function becomes its child.
+type track struct {
+	id      int
+	label   string
+	current *Span
+	keys    []interface{}
+}
+
+var traceEnabled bool
+var traceOutput string // path prefix of trace files
+var traceID string
+var traceMutex sync.Mutex
+var traceSpans []*Span                     // finished spans
+var traceTracks = map[interface{}]*track{} // tracks by the objects (contexts, frameworks) threads pass to measured functions
+var traceTracksCount int
+
+// Start collecting spans, they are written to directory when tracing stops
+func TraceStart(directory string) {
+	traceMutex.Lock()
+	defer traceMutex.Unlock()
+
+	traceEnabled = true
+	traceOutput = directory + "/load-test-trace"
+	traceID = randomID(16)
+}
+
+// Write collected spans in OTLP JSON and Chrome trace event formats
+func TraceStop() error {
+	traceMutex.Lock()
+	defer traceMutex.Unlock()
+
+	if !traceEnabled {
+		return nil
+	}
+	traceEnabled = false
+
+	sort.Slice(traceSpans, func(i, j int) bool { return traceSpans[i].Start.Before(traceSpans[j].Start) })
+
+	err := writeTraceJSON(traceOutput+".otlp.json", traceOTLP())
+	if err != nil {
+		return err
+	}
+	return writeTraceJSON(traceOutput+".chrome.json", traceChrome())
+}
+
+// Start span of journey thread with its own track. Span is child of the current span of the
+// track parentKey is bound to (if any). Measured functions getting any of keys (or keys bound
+// later by TraceBind) as parameter are recorded on the new track.
+func TraceThreadStart(name, label string, parentKey interface{}, attributes map[string]string, keys ...interface{}) *Span {
+	traceMutex.Lock()
+	defer traceMutex.Unlock()
+
+	if !traceEnabled {
+		return nil
+	}
+
+	var parent *Span
+	if parentTrack, ok := traceTracks[parentKey]; ok && parentKey != nil {
+		parent = parentTrack.current
+	}
+
+	traceTracksCount++
+	t := &track{id: traceTracksCount, label: label}
+	span := newSpan("thread", name, parent, t, attributes)
+	t.current = span
+
+	for _, key := range keys {
+		traceTracks[key] = t
+		t.keys = append(t.keys, key)
+	}
+
+	return span
+}
+
+// Bind more keys to the track of given thread span, e.g. framework created by the thread
+func TraceBind(span *Span, keys ...interface{}) {
+	traceMutex.Lock()
+	defer traceMutex.Unlock()
+
+	if span == nil || !traceEnabled {
+		return
+	}
+
+	for _, key := range keys {
+		traceTracks[key] = span.track
+		span.track.keys = append(span.track.keys, key)
+	}
+}
+
+// Set attribute of span, e.g. once thread knows name of what it works on
+func TraceSetAttribute(span *Span, key, value string) {
+	traceMutex.Lock()
+	defer traceMutex.Unlock()
+
+	if span == nil {
+		return
+	}
+	span.Attributes[key] = value
+}
+
+// End span of journey thread and forget keys bound to its track
+func TraceThreadEnd(span *Span) {
+	traceMutex.Lock()
+	defer traceMutex.Unlock()
+
+	if span == nil {
+		return
+	}
+
+	for _, key := range span.track.keys {
+		if traceTracks[key] == span.track {
+			delete(traceTracks, key)
+		}
+	}
+	endSpan(span, nil)
+}
+
+// Start span of given kind nested in the current span of the track key is bound to, nil if key is not bound
+func TraceSpanStart(kind, name string, key interface{}, attributes map[string]string) *Span {
+	traceMutex.Lock()
+	defer traceMutex.Unlock()
+
+	if !traceEnabled {
+		return nil
+	}
+
+	t, ok := traceTracks[key]
+	if !ok {
+		return nil
+	}
+
+	span := newSpan(kind, name, t.current, t, attributes)
+	t.current = span
+	return span
+}
+
+// End span started by TraceSpanStart
+func TraceSpanEnd(span *Span, err error) {
+	traceMutex.Lock()
+	defer traceMutex.Unlock()
+
+	if span == nil {
+		return
+	}
+	endSpan(span, err)
+}
+
+// Find track of measured function call by its parameters
+func traceKey(params []interface{}) interface{} {
+	traceMutex.Lock()
+	defer traceMutex.Unlock()
+
+	if !traceEnabled {
+		return nil
+	}
+
+	// Only pointers are used as keys, other values might not be hashable
+	for _, param := range params {
+		if param == nil || reflect.ValueOf(param).Kind() != reflect.Ptr {
+			continue
+		}
+		if _, ok := traceTracks[param]; ok {
+			return param
+		}
+	}
+	return nil
+}
+
+// Has to be called with traceMutex locked
+func newSpan(kind, name string, parent *Span, t *track, attributes map[string]string) *Span {
+	span := &Span{
+		Kind:       kind,
+		ID:         randomID(8),
+		Name:       name,
+		Start:      time.Now(),
+		Attributes: map[string]string{},
+		track:      t,
+		parent:     parent,
+	}
+	if parent != nil {
+		span.ParentID = parent.ID
+	}
+	for k, v := range attributes {
+		span.Attributes[k] = v
+	}
+	return span
+}
+
+// Has to be called with traceMutex locked. If span is (an ancestor of) the current span of its track,
+// its parent becomes current again. That way spans left running (e.g. by step that timed out) do not
+// become parents of spans that follow.
+func endSpan(span *Span, err error) {
+	span.End = time.Now()
+	if err != nil {
+		span.Error = err.Error()
+	}
+
+	for s := span.track.current; s != nil; s = s.parent {
+		if s == span {
+			span.track.current = span.parent
+			break
+		}
+	}
+
+	traceSpans = append(traceSpans, span)
+}
+
+func randomID(size int) string {
+	data := make([]byte, size)
+	_, err := rand.Read(data)
+	if err != nil {
+		Logger.Warning("Failed to generate random trace ID: %v", err)
+	}
+	return hex.EncodeToString(data)
+}
+
+func writeTraceJSON(path string, data interface{}) error {
+	content, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("Error marshalling trace: %v", err)
+	}
+	err = os.WriteFile(path, content, 0600)
+	if err != nil {
+		return fmt.Errorf("Error writing trace to %s: %v", path, err)
+	}
+	return nil
+}
+
+// Trace in OTLP JSON format, as accepted by OpenTelemetry collector OTLP/HTTP receiver
+func traceOTLP() map[string]interface{} {
+	spans := []map[string]interface{}{}
+	for _, span := range traceSpans {
+		attributes := []map[string]interface{}{
+			{"key": "loadtest.span.kind", "value": map[string]interface{}{"stringValue": span.Kind}},
+		}
+		for _, k := range sortedKeys(span.Attributes) {
+			attributes = append(attributes, map[string]interface{}{
+				"key":   k,
+				"value": map[string]interface{}{"stringValue": span.Attributes[k]},
+			})
+		}
+
+		status := map[string]interface{}{"code": 1} // STATUS_CODE_OK
+		if span.Error != "" {
+			status = map[string]interface{}{"code": 2, "message": span.Error} // STATUS_CODE_ERROR
+		}
+
+		spans = append(spans, map[string]interface{}{
+			"traceId":           traceID,
+			"spanId":            span.ID,
+			"parentSpanId":      span.ParentID,
+			"name":              span.Name,
+			"kind":              1, // SPAN_KIND_INTERNAL
+			"startTimeUnixNano": strconv.FormatInt(span.Start.UnixNano(), 10),
+			"endTimeUnixNano":   strconv.FormatInt(span.End.UnixNano(), 10),
+			"attributes":        attributes,
+			"status":            status,
+		})
+	}
+
+	return map[string]interface{}{
+		"resourceSpans": []map[string]interface{}{
+			{
+				"resource": map[string]interface{}{
+					"attributes": []map[string]interface{}{
+						{"key": "service.name", "value": map[string]interface{}{"stringValue": "load-test"}},
+					},
+				},
+				"scopeSpans": []map[string]interface{}{
+					{
+						"scope": map[string]interface{}{"name": "github.com/konflux-ci/e2e-tests/tests/load-tests"},
+						"spans": spans,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Trace in Chrome trace event format, viewable in Perfetto or chrome://tracing. Every
+// track is shown as separate thread, so parallel components are shown next to each other.
+func traceChrome() map[string]interface{} {
+	events := []map[string]interface{}{
+		{"name": "process_name", "ph": "M", "pid": 1, "tid": 0, "args": map[string]interface{}{"name": "load-test"}},
+	}
+
+	labeled := map[int]bool{}
+	for _, span := range traceSpans {
+		if !labeled[span.track.id] {
+			labeled[span.track.id] = true
+			events = append(events, map[string]interface{}{
+				"name": "thread_name", "ph": "M", "pid": 1, "tid": span.track.id,
+				"args": map[string]interface{}{"name": span.track.label},
+			})
+		}
+
+		args := map[string]interface{}{"id": span.ID, "parent_id": span.ParentID}
+		for k, v := range span.Attributes {
+			args[k] = v
+		}
+		if span.Error != "" {
+			args["error"] = span.Error
+		}
+
+		events = append(events, map[string]interface{}{
+			"name": span.Name,
+			"cat":  span.Kind,
+			"ph":   "X",
+			"ts":   span.Start.UnixMicro(),
+			"dur":  span.End.Sub(span.Start).Microseconds(),
+			"pid":  1,
+			"tid":  span.track.id,
+			"args": args,
+		})
+	}
+
+	return map[string]interface{}{
+		"traceEvents":     events,
+		"displayTimeUnit": "ms",
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -8,6 +8,7 @@ import "time"
 // Struct to hold command line options
 type Opts struct {
 	ApplicationsCount             int
+	ArrivalSchedule               []ScheduledArrival `json:"-"` // computed from scenario arrival phases
 	BuildPipelineSelectorBundle   string
 	ComponentContainerContext     string
 	ComponentContainerFile        string
@@ -17,18 +18,30 @@ type Opts struct {
 	Concurrency                   int
 	FailFast                      bool
 	JourneyDuration               string
+	JourneyHardStop               bool
 	JourneyRepeats                int
 	JourneyUntil                  time.Time
+	LedgerFile                    string
 	LogDebug                      bool
 	LogTrace                      bool
+	MetricsListen                 string
+	MetricsPushInterval           time.Duration
+	MetricsPushURL                string
 	LogInfo                       bool
 	OutputDir                     string
 	PipelineMintmakerDisabled     bool
 	PipelineRepoTemplating        bool
 	PipelineImagePullSecrets      []string
 	Purge                         bool
+	PurgeFromLedger               bool
 	PurgeOnly                     bool
 	QuayRepo                      string
+	Release                       bool
+	ReleasePipelineGitURL         string
+	ReleasePipelinePathInRepo     string
+	ReleasePipelineRevision       string
+	Scenario                      *Scenario
+	ScenarioFile                  string
 	Stage                         bool
 	TestScenarioGitURL            string
 	TestScenarioPathInRepo        string
@@ -52,6 +65,43 @@ func (o *Opts) ProcessOptions() error {
 		o.Purge = true
 	}
 
+	// Ledger of created resources lives in output directory by default
+	if o.LedgerFile == "" {
+		o.LedgerFile = o.OutputDir + "/load-test-ledger.jsonl"
+	}
+
+	// Load '--scenario' file or build single profile scenario from options
+	if o.ScenarioFile != "" {
+		o.Scenario, err = LoadScenario(o.ScenarioFile)
+		if err != nil {
+			return err
+		}
+	} else {
+		o.Scenario = o.defaultScenario()
+	}
+	err = o.completeScenario()
+	if err != nil {
+		return err
+	}
+
+	if o.MetricsPushURL != "" && o.MetricsPushInterval <= 0 {
+		return fmt.Errorf("Metrics push interval needs to be positive, got %s", o.MetricsPushInterval)
+	}
+
+	// In open model every user arriving runs its journey once
+	if o.Scenario.Arrival != nil {
+		err = o.Scenario.Arrival.validate()
+		if err != nil {
+			return err
+		}
+		o.ArrivalSchedule = o.Scenario.Arrival.Schedule()
+		if len(o.ArrivalSchedule) == 0 {
+			return fmt.Errorf("Arrival phases do not schedule any user")
+		}
+		o.Concurrency = len(o.ArrivalSchedule)
+		o.JourneyRepeats = 1
+	}
+
 	// Convert options struct to pretty JSON
 	jsonOptions, err2 := json.MarshalIndent(o, "", "  ")
 	if err2 != nil {
@@ -0,0 +1,419 @@
+package options
+
+import "fmt"
+import "math"
+import "os"
+import "sort"
+import "time"
+
+import yaml "sigs.k8s.io/yaml"
+
+// Built-in journey steps
+const StepPersistentVolumeClaims = "persistent-volume-claims"
+const StepApplication = "application"
+const StepIntegrationTestScenarios = "integration-test-scenarios"
+const StepComponent = "component"
+const StepBuildPipelineRun = "build-pipeline-run"
+const StepTestPipelineRun = "test-pipeline-run"
+const StepRelease = "release"
+
+// Journey steps user profile can run on top of creating applications and components
+var optionalSteps = []string{StepIntegrationTestScenarios, StepBuildPipelineRun, StepTestPipelineRun, StepRelease}
+
+// Built-in steps that need other built-in steps to run before them
+var stepDependencies = map[string][]string{
+	StepTestPipelineRun: {StepBuildPipelineRun, StepIntegrationTestScenarios},
+	StepRelease:         {StepBuildPipelineRun},
+}
+
+// Returns steps that have to run before given step
+func StepDependencies(step string) []string {
+	return stepDependencies[step]
+}
+
+// Struct to hold load test scenario, i.e. the mix of user profiles to simulate
+// and optionally the rate at which users arrive
+type Scenario struct {
+	Profiles []Profile `json:"profiles"`
+	Arrival  *Arrival  `json:"arrival,omitempty"`
+}
+
+// Struct to hold open model arrival rate phases: instead of starting all users at once,
+// new users start their journey at given rate (users per minute) that changes linearly
+// from phase start rate to phase end rate
+type Arrival struct {
+	Phases []ArrivalPhase `json:"phases"`
+}
+
+// Struct to hold one arrival rate phase, e.g. ramp-up, steady or ramp-down
+type ArrivalPhase struct {
+	Name      string   `json:"name"`
+	Duration  string   `json:"duration"`
+	StartRate float64  `json:"startRate"`
+	EndRate   *float64 `json:"endRate,omitempty"` // defaults to start rate
+}
+
+// Struct to hold when user should start its journey, relative to test start, and in which phase
+type ScheduledArrival struct {
+	Offset time.Duration
+	Phase  string
+}
+
+// Struct to hold one kind of user: what share of users it represents and what they do
+type Profile struct {
+	Name                      string                    `json:"name"`
+	Weight                    int                       `json:"weight"`
+	ApplicationsCount         int                       `json:"applicationsCount,omitempty"`
+	ComponentsCount           int                       `json:"componentsCount,omitempty"`
+	ComponentRepoUrl          string                    `json:"componentRepoUrl,omitempty"`
+	ComponentRepoRevision     string                    `json:"componentRepoRevision,omitempty"`
+	ComponentContainerFile    string                    `json:"componentContainerFile,omitempty"`
+	ComponentContainerContext string                    `json:"componentContainerContext,omitempty"`
+	IntegrationTestScenarios  []IntegrationTestScenario `json:"integrationTestScenarios,omitempty"`
+	Steps                     []string                  `json:"steps,omitempty"`
+	Journey                   *Journey                  `json:"journey,omitempty"` // built from Steps if not set
+}
+
+// Struct to hold sequences of steps run on each journey level and policies of the steps
+type Journey struct {
+	User        []string              `json:"user"`        // run once user is done with all journey repeats
+	Application []string              `json:"application"` // run by every application thread before its components
+	Component   []string              `json:"component"`   // run by every component thread
+	Policies    map[string]StepPolicy `json:"policies,omitempty"`
+}
+
+// Struct to hold how long step can take and how many times to retry it when it fails
+type StepPolicy struct {
+	Timeout    string `json:"timeout,omitempty"`    // no timeout if not set
+	Retries    int    `json:"retries,omitempty"`    // only retry steps that are safe to run again
+	RetryDelay string `json:"retryDelay,omitempty"` // no delay if not set
+}
+
+// Struct to hold where to get integration test scenario pipeline from
+type IntegrationTestScenario struct {
+	GitURL     string `json:"gitUrl"`
+	Revision   string `json:"revision,omitempty"`
+	PathInRepo string `json:"pathInRepo"`
+}
+
+// Load scenario from YAML (or JSON) file
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading scenario file %s: %v", path, err)
+	}
+
+	scenario := &Scenario{}
+	err = yaml.UnmarshalStrict(data, scenario)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing scenario file %s: %v", path, err)
+	}
+
+	if len(scenario.Profiles) == 0 {
+		return nil, fmt.Errorf("Scenario file %s does not define any profile", path)
+	}
+
+	return scenario, nil
+}
+
+// Scenario with single profile built from command line options, used when no scenario file is given
+func (o *Opts) defaultScenario() *Scenario {
+	steps := []string{StepIntegrationTestScenarios}
+	if o.WaitPipelines {
+		steps = append(steps, StepBuildPipelineRun)
+		if o.WaitIntegrationTestsPipelines {
+			steps = append(steps, StepTestPipelineRun)
+		}
+		if o.Release {
+			steps = append(steps, StepRelease)
+		}
+	}
+
+	return &Scenario{
+		Profiles: []Profile{
+			{
+				Name:   "default",
+				Weight: 1,
+				Steps:  steps,
+			},
+		},
+	}
+}
+
+// Fill in profile fields not set in scenario file from command line options and check the result makes sense
+func (o *Opts) completeScenario() error {
+	for i := range o.Scenario.Profiles {
+		p := &o.Scenario.Profiles[i]
+
+		if p.Name == "" {
+			p.Name = fmt.Sprintf("profile-%d", i)
+		}
+		if p.Weight <= 0 {
+			return fmt.Errorf("Profile %s needs positive weight, got %d", p.Name, p.Weight)
+		}
+		if p.ApplicationsCount == 0 {
+			p.ApplicationsCount = o.ApplicationsCount
+		}
+		if p.ComponentsCount == 0 {
+			p.ComponentsCount = o.ComponentsCount
+		}
+		if p.ComponentRepoUrl == "" {
+			p.ComponentRepoUrl = o.ComponentRepoUrl
+		}
+		if p.ComponentRepoRevision == "" {
+			p.ComponentRepoRevision = o.ComponentRepoRevision
+		}
+		if p.ComponentContainerFile == "" {
+			p.ComponentContainerFile = o.ComponentContainerFile
+		}
+		if p.ComponentContainerContext == "" {
+			p.ComponentContainerContext = o.ComponentContainerContext
+		}
+		if len(p.IntegrationTestScenarios) == 0 {
+			p.IntegrationTestScenarios = []IntegrationTestScenario{
+				{
+					GitURL:     o.TestScenarioGitURL,
+					Revision:   o.TestScenarioRevision,
+					PathInRepo: o.TestScenarioPathInRepo,
+				},
+			}
+		}
+		for j := range p.IntegrationTestScenarios {
+			if p.IntegrationTestScenarios[j].Revision == "" {
+				p.IntegrationTestScenarios[j].Revision = "main"
+			}
+		}
+
+		if p.Journey == nil {
+			journey, err := stepsJourney(p.Steps)
+			if err != nil {
+				return fmt.Errorf("Profile %s: %v", p.Name, err)
+			}
+			p.Journey = journey
+		} else if len(p.Steps) > 0 {
+			return fmt.Errorf("Profile %s can not define both steps and journey", p.Name)
+		}
+		for name, policy := range p.Journey.Policies {
+			err := policy.validate()
+			if err != nil {
+				return fmt.Errorf("Profile %s step %s policy: %v", p.Name, name, err)
+			}
+		}
+
+		for _, step := range sortedKeys(stepDependencies) {
+			for _, dependency := range stepDependencies[step] {
+				if p.HasStep(step) && !p.HasStep(dependency) {
+					return fmt.Errorf("Profile %s step %s requires step %s", p.Name, step, dependency)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Build journey running built-in steps, with given optional steps enabled
+func stepsJourney(steps []string) (*Journey, error) {
+	for _, step := range steps {
+		known := false
+		for _, s := range optionalSteps {
+			if step == s {
+				known = true
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("Unknown step %s, known steps are %v", step, optionalSteps)
+		}
+	}
+
+	journey := &Journey{
+		User:        []string{StepPersistentVolumeClaims},
+		Application: []string{StepApplication},
+		Component:   []string{StepComponent},
+	}
+	for _, step := range optionalSteps {
+		if !contains(steps, step) {
+			continue
+		}
+		if step == StepIntegrationTestScenarios {
+			journey.Application = append(journey.Application, step)
+		} else {
+			journey.Component = append(journey.Component, step)
+		}
+	}
+	return journey, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(list []string, item string) bool {
+	for _, i := range list {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if profile runs given journey step on any level
+func (p *Profile) HasStep(step string) bool {
+	if p.Journey == nil {
+		return contains(p.Steps, step)
+	}
+	return contains(p.Journey.User, step) || contains(p.Journey.Application, step) || contains(p.Journey.Component, step)
+}
+
+// Returns policy of given step, default policy has no timeout and no retries
+func (j *Journey) Policy(step string) StepPolicy {
+	return j.Policies[step]
+}
+
+// Check step policy is valid
+func (p *StepPolicy) validate() error {
+	if p.Timeout != "" {
+		d, err := time.ParseDuration(p.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout: %v", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("timeout needs to be positive, got %s", p.Timeout)
+		}
+	}
+	if p.RetryDelay != "" {
+		_, err := time.ParseDuration(p.RetryDelay)
+		if err != nil {
+			return fmt.Errorf("invalid retry delay: %v", err)
+		}
+	}
+	if p.Retries < 0 {
+		return fmt.Errorf("retries can not be negative, got %d", p.Retries)
+	}
+	return nil
+}
+
+// Returns step timeout, zero means no timeout
+func (p *StepPolicy) TimeoutDuration() time.Duration {
+	d, _ := time.ParseDuration(p.Timeout)
+	return d
+}
+
+// Returns delay before step is retried
+func (p *StepPolicy) RetryDelayDuration() time.Duration {
+	d, _ := time.ParseDuration(p.RetryDelay)
+	return d
+}
+
+// Distribute given number of users among scenario profiles according to their weights.
+// Uses smooth weighted round-robin, so profiles are interleaved and any prefix of users
+// follows weights as close as possible.
+func (s *Scenario) AssignProfiles(users int) []*Profile {
+	total := 0
+	for i := range s.Profiles {
+		total += s.Profiles[i].Weight
+	}
+
+	assigned := make([]*Profile, 0, users)
+	current := make([]int, len(s.Profiles))
+	for u := 0; u < users; u++ {
+		best := 0
+		for i := range s.Profiles {
+			current[i] += s.Profiles[i].Weight
+			if current[i] > current[best] {
+				best = i
+			}
+		}
+		current[best] -= total
+		assigned = append(assigned, &s.Profiles[best])
+	}
+
+	return assigned
+}
+
+// Returns rate at the end of the phase
+func (p *ArrivalPhase) endRate() float64 {
+	if p.EndRate == nil {
+		return p.StartRate
+	}
+	return *p.EndRate
+}
+
+// Check arrival phases are valid
+func (a *Arrival) validate() error {
+	if len(a.Phases) == 0 {
+		return fmt.Errorf("Arrival does not define any phase")
+	}
+	for i := range a.Phases {
+		p := &a.Phases[i]
+		if p.Name == "" {
+			p.Name = fmt.Sprintf("phase-%d", i)
+		}
+		d, err := time.ParseDuration(p.Duration)
+		if err != nil {
+			return fmt.Errorf("Arrival phase %s has invalid duration: %v", p.Name, err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("Arrival phase %s needs positive duration, got %s", p.Name, p.Duration)
+		}
+		if p.StartRate < 0 || p.endRate() < 0 {
+			return fmt.Errorf("Arrival phase %s can not have negative rate", p.Name)
+		}
+	}
+	return nil
+}
+
+// Returns end of each phase relative to test start
+func (a *Arrival) PhaseEnds() []time.Duration {
+	var ends []time.Duration
+	var end time.Duration
+	for _, p := range a.Phases {
+		d, _ := time.ParseDuration(p.Duration)
+		end += d
+		ends = append(ends, end)
+	}
+	return ends
+}
+
+// Compute when users arrive: n-th user arrives when the number of expected arrivals
+// (integral of the rate over time) reaches n
+func (a *Arrival) Schedule() []ScheduledArrival {
+	var schedule []ScheduledArrival
+	var phaseStart time.Duration
+	arrived := 0.0 // expected arrivals in previous phases
+	next := 1.0
+	ends := a.PhaseEnds()
+
+	for i, p := range a.Phases {
+		minutes := (ends[i] - phaseStart).Minutes()
+		startRate, endRate := p.StartRate, p.endRate()
+		total := (startRate + endRate) / 2 * minutes
+
+		for next <= arrived+total+1e-9 {
+			n := next - arrived
+			var t float64 // minutes since phase start
+			if startRate == endRate {
+				t = n / startRate
+			} else {
+				// Solve startRate*t + (endRate-startRate)/(2*minutes)*t^2 = n
+				c := (endRate - startRate) / (2 * minutes)
+				t = (-startRate + math.Sqrt(math.Max(0, startRate*startRate+4*c*n))) / (2 * c)
+			}
+			schedule = append(schedule, ScheduledArrival{
+				Offset: phaseStart + time.Duration(t*float64(time.Minute)),
+				Phase:  p.Name,
+			})
+			next++
+		}
+
+		arrived += total
+		phaseStart = ends[i]
+	}
+
+	return schedule
+}
@@ -0,0 +1,74 @@
+package options
+
+import "testing"
+import "time"
+
+import assert "github.com/stretchr/testify/assert"
+
+func TestAssignProfiles(t *testing.T) {
+	scenario := &Scenario{
+		Profiles: []Profile{
+			{Name: "heavy", Weight: 1},
+			{Name: "light", Weight: 3},
+		},
+	}
+
+	var names []string
+	for _, p := range scenario.AssignProfiles(8) {
+		names = append(names, p.Name)
+	}
+
+	// Profiles are interleaved and each prefix of users follows weights
+	assert.Equal(t, []string{"light", "heavy", "light", "light", "light", "heavy", "light", "light"}, names)
+}
+
+func TestAssignProfilesSingleProfile(t *testing.T) {
+	scenario := &Scenario{Profiles: []Profile{{Name: "default", Weight: 1}}}
+
+	assigned := scenario.AssignProfiles(3)
+
+	assert.Len(t, assigned, 3)
+	for _, p := range assigned {
+		assert.Same(t, &scenario.Profiles[0], p)
+	}
+}
+
+func TestArrivalSchedule(t *testing.T) {
+	six := 6.0
+	arrival := &Arrival{
+		Phases: []ArrivalPhase{
+			{Name: "ramp-up", Duration: "2m", StartRate: 0, EndRate: &six},
+			{Name: "steady", Duration: "1m", StartRate: 6},
+		},
+	}
+	assert.NoError(t, arrival.validate())
+	assert.Equal(t, []time.Duration{2 * time.Minute, 3 * time.Minute}, arrival.PhaseEnds())
+
+	schedule := arrival.Schedule()
+
+	// Ramp-up from 0 to 6 users per minute over 2 minutes brings 6 users, steady minute another 6
+	phases := map[string]int{}
+	for i, a := range schedule {
+		phases[a.Phase]++
+		if i > 0 {
+			assert.Greater(t, a.Offset, schedule[i-1].Offset)
+		}
+	}
+	assert.Equal(t, map[string]int{"ramp-up": 6, "steady": 6}, phases)
+
+	// n-th user of the ramp-up arrives when 1.5*t^2 = n (t in minutes)
+	assert.InDelta(t, (49 * time.Second).Seconds(), schedule[0].Offset.Seconds(), 0.1)
+	assert.Equal(t, 2*time.Minute, schedule[5].Offset)
+	// Steady phase users arrive every 10 seconds
+	assert.Equal(t, 2*time.Minute+10*time.Second, schedule[6].Offset)
+	assert.Equal(t, 3*time.Minute, schedule[11].Offset)
+}
+
+func TestArrivalValidate(t *testing.T) {
+	assert.EqualError(t, (&Arrival{}).validate(), "Arrival does not define any phase")
+	assert.EqualError(t, (&Arrival{Phases: []ArrivalPhase{{Name: "steady", Duration: "0s", StartRate: 1}}}).validate(), "Arrival phase steady needs positive duration, got 0s")
+
+	arrival := &Arrival{Phases: []ArrivalPhase{{Duration: "1m", StartRate: 1}}}
+	assert.NoError(t, arrival.validate())
+	assert.Equal(t, "phase-0", arrival.Phases[0].Name)
+}
@@ -94,14 +94,14 @@ load_test() {
     set -u
 
     echo "[$(date --utc -Ins)] Create summary JSON with timings"
-    ./evaluate.py "$workdir/load-test-timings.csv" "$workdir/load-test-timings.json"
+    go run loadtest.go evaluate --input-dir "$workdir" --output-dir "$workdir"
 
     echo "[$(date --utc -Ins)] Creating main status data file"
     STATUS_DATA_FILE="$workdir/load-test.json"
     status_data.py \
         --status-data-file "${STATUS_DATA_FILE}" \
         --set "name=Konflux loadtest" "started=$(cat started)" "ended=$(cat ended)" \
-        --set-subtree-json "parameters.options=$workdir/load-test-options.json" "results.measurements=$workdir/load-test-timings.json"
+        --set-subtree-json "parameters.options=$workdir/load-test-options.json"
 
     deactivate
 
@@ -62,6 +62,10 @@ fi
 ## Run the actual load test
 options=""
 [[ -n "${PIPELINE_IMAGE_PULL_SECRETS:-}" ]] && options="$options --pipeline-image-pull-secrets $PIPELINE_IMAGE_PULL_SECRETS"
+[[ -n "${SCENARIO_FILE:-}" ]] && options="$options --scenario $SCENARIO_FILE"
+[[ -n "${METRICS_LISTEN:-}" ]] && options="$options --metrics-listen $METRICS_LISTEN"
+[[ -n "${METRICS_PUSH_URL:-}" ]] && options="$options --metrics-push-url $METRICS_PUSH_URL"
+[[ -n "${RELEASE_PIPELINE_PATH_IN_REPO:-}" ]] && options="$options --release-pipeline-path-in-repo $RELEASE_PIPELINE_PATH_IN_REPO"
 date -Ins --utc >started
 go run loadtest.go \
     --applications-count "${APPLICATIONS_COUNT:-1}" \
@@ -79,6 +83,7 @@ go run loadtest.go \
     --output-dir "${OUTPUT_DIR:-.}" \
     --purge="${PURGE:-true}" \
     --quay-repo "${QUAY_REPO:-stonesoup_perfscale}" \
+    --release="${RELEASE:-false}" \
     --test-scenario-git-url "${TEST_SCENARIO_GIT_URL:-https://github.com/konflux-ci/integration-examples.git}" \
     --test-scenario-path-in-repo "${TEST_SCENARIO_PATH_IN_REPO:-pipelines/integration_resolver_pipeline_pass.yaml}" \
     --test-scenario-revision "${TEST_SCENARIO_REVISION:-main}" \